@@ -9,6 +9,7 @@ import (
 	"go-corenglish/internal/repositories"
 	"go-corenglish/internal/services"
 	"go-corenglish/pkg/database"
+	"go-corenglish/pkg/storage"
 	"go-corenglish/pkg/token"
 	"log"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // user time zones must resolve in minimal images too
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -46,16 +48,69 @@ func main() {
 	redisClient := database.ConnectRedis(cfg, logger)
 	defer redisClient.Close()
 
+	// Attachment storage
+	attachmentStorage, err := storage.New(cfg)
+	if err != nil {
+		logger.Fatalf("Failed to set up attachment storage: %v", err)
+	}
+
 	tokenManager := token.NewTokenManager(cfg.JWTSecret, cfg.JWTExpiry)
 
 	taskRepo := repositories.NewTaskRepository(db, logger)
 	userRepo := repositories.NewUserRepository(db, logger)
+	labelRepo := repositories.NewLabelRepository(db, logger)
+	dependencyRepo := repositories.NewTaskDependencyRepository(db, logger)
+	workflowRepo := repositories.NewWorkflowRepository(db, logger)
+	historyRepo := repositories.NewTaskHistoryRepository(db, logger)
+	wipLimitRepo := repositories.NewWIPLimitRepository(db, logger)
+	checklistRepo := repositories.NewChecklistRepository(db, logger)
+	commentRepo := repositories.NewCommentRepository(db, logger)
+	attachmentRepo := repositories.NewAttachmentRepository(db, logger)
+	templateRepo := repositories.NewTemplateRepository(db, logger)
+	memberRepo := repositories.NewTaskMemberRepository(db, logger)
+	shareLinkRepo := repositories.NewShareLinkRepository(db, logger)
+	workspaceRepo := repositories.NewWorkspaceRepository(db, logger)
+	projectRepo := repositories.NewProjectRepository(db, logger)
+	classRepo := repositories.NewClassRepository(db, logger)
 
-	taskService := services.NewTaskService(taskRepo, logger, redisClient)
+	workflowService := services.NewWorkflowService(workflowRepo, logger)
+	if err := workflowService.Load(); err != nil {
+		logger.Fatalf("Failed to load task workflow: %v", err)
+	}
+
+	recurrenceService := services.NewRecurrenceService(taskRepo, historyRepo, userRepo, logger)
+	taskService := services.NewTaskService(taskRepo, labelRepo, dependencyRepo, historyRepo, wipLimitRepo, checklistRepo, commentRepo, userRepo, memberRepo, shareLinkRepo, projectRepo, workflowService, recurrenceService, cfg, logger, redisClient)
 	authService := services.NewAuthService(userRepo, cfg, logger, tokenManager)
+	labelService := services.NewLabelService(labelRepo, logger, redisClient)
+	dependencyService := services.NewDependencyService(taskRepo, dependencyRepo, memberRepo, logger)
+	statsService := services.NewStatsService(historyRepo, logger)
+	userService := services.NewUserService(userRepo, logger)
+	checklistService := services.NewChecklistService(taskRepo, checklistRepo, memberRepo, logger, redisClient)
+	commentService := services.NewCommentService(taskRepo, commentRepo, memberRepo, logger, redisClient)
+	attachmentService := services.NewAttachmentService(taskRepo, attachmentRepo, memberRepo, attachmentStorage, cfg, logger)
+	boardService := services.NewBoardService(taskService, wipLimitRepo, workflowService, logger)
+	templateService := services.NewTemplateService(templateRepo, taskService, workflowService, logger)
+	memberService := services.NewTaskMemberService(taskRepo, memberRepo, userRepo, workspaceRepo, logger, redisClient)
+	workspaceService := services.NewWorkspaceService(workspaceRepo, userRepo, logger, redisClient)
+	projectService := services.NewProjectService(projectRepo, taskService, logger, redisClient)
+	classService := services.NewClassService(classRepo, taskRepo, historyRepo, userRepo, workspaceRepo, logger, redisClient)
 
 	taskHandler := handlers.NewTaskHandler(taskService, logger)
 	authHandler := handlers.NewAuthHandler(authService, logger)
+	labelHandler := handlers.NewLabelHandler(labelService, logger)
+	dependencyHandler := handlers.NewDependencyHandler(dependencyService, logger)
+	workflowHandler := handlers.NewWorkflowHandler(workflowService, logger)
+	statsHandler := handlers.NewStatsHandler(statsService, logger)
+	userHandler := handlers.NewUserHandler(userService, logger)
+	boardHandler := handlers.NewBoardHandler(boardService, logger)
+	checklistHandler := handlers.NewChecklistHandler(checklistService, logger)
+	commentHandler := handlers.NewCommentHandler(commentService, logger)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService, cfg.AttachmentMaxSizeMB, logger)
+	templateHandler := handlers.NewTemplateHandler(templateService, logger)
+	memberHandler := handlers.NewTaskMemberHandler(memberService, logger)
+	workspaceHandler := handlers.NewWorkspaceHandler(workspaceService, logger)
+	projectHandler := handlers.NewProjectHandler(projectService, logger)
+	classHandler := handlers.NewClassHandler(classService, logger)
 
 	// Setup Gin router
 	if cfg.AppEnv == "production" {
@@ -97,15 +152,164 @@ func main() {
 			auth.POST("/login", authHandler.Login)
 		}
 
-		// Task routes (protected)
-		tasks := v1.Group("/tasks")
-		tasks.Use(middleware.AuthMiddleware(tokenManager, logger))
-		{
+		// Task routes (protected), served under /tasks for the workspace
+		// named by the X-Workspace-ID header and under
+		// /workspaces/:workspaceId/tasks for the one in the path.
+		registerTaskRoutes := func(tasks *gin.RouterGroup) {
 			tasks.POST("", taskHandler.CreateTask)
+			tasks.POST("/quick", taskHandler.QuickAddTask)
 			tasks.GET("", taskHandler.GetTasks)
+			tasks.POST("/clone", taskHandler.CloneTasks)
+			tasks.GET("/stats", statsHandler.GetTaskStats)
+			tasks.GET("/trash", taskHandler.GetTrash)
+			tasks.GET("/today", taskHandler.GetMyDay)
 			tasks.GET("/:id", taskHandler.GetTask)
+			tasks.GET("/:id/subtasks", taskHandler.GetSubtasks)
+			tasks.GET("/:id/history", taskHandler.GetTaskHistory)
 			tasks.PATCH("/:id", taskHandler.UpdateTask)
+			tasks.POST("/:id/reopen", taskHandler.ReopenTask)
 			tasks.DELETE("/:id", taskHandler.DeleteTask)
+			tasks.POST("/:id/restore", taskHandler.RestoreTask)
+			tasks.POST("/:id/archive", taskHandler.ArchiveTask)
+			tasks.POST("/:id/unarchive", taskHandler.UnarchiveTask)
+			tasks.POST("/:id/move", taskHandler.MoveTask)
+			tasks.POST("/:id/snooze", taskHandler.SnoozeTask)
+			tasks.POST("/:id/unsnooze", taskHandler.UnsnoozeTask)
+			tasks.POST("/:id/today", taskHandler.AddToMyDay)
+			tasks.DELETE("/:id/today", taskHandler.RemoveFromMyDay)
+			tasks.POST("/:id/clone", taskHandler.CloneTask)
+
+			tasks.POST("/:id/dependencies", dependencyHandler.AddDependency)
+			tasks.GET("/:id/dependencies", dependencyHandler.GetDependencies)
+			tasks.DELETE("/:id/dependencies/:blockerId", dependencyHandler.RemoveDependency)
+
+			tasks.GET("/:id/checklist", checklistHandler.GetChecklist)
+			tasks.POST("/:id/checklist", checklistHandler.AddItem)
+			tasks.PATCH("/:id/checklist/:itemId", checklistHandler.UpdateItem)
+			tasks.POST("/:id/checklist/:itemId/toggle", checklistHandler.ToggleItem)
+			tasks.POST("/:id/checklist/:itemId/move", checklistHandler.MoveItem)
+			tasks.DELETE("/:id/checklist/:itemId", checklistHandler.DeleteItem)
+
+			tasks.POST("/:id/comments", commentHandler.CreateComment)
+			tasks.GET("/:id/comments", commentHandler.GetComments)
+			tasks.PATCH("/:id/comments/:commentId", commentHandler.UpdateComment)
+			tasks.DELETE("/:id/comments/:commentId", commentHandler.DeleteComment)
+
+			tasks.POST("/:id/attachments", attachmentHandler.UploadAttachment)
+			tasks.GET("/:id/attachments", attachmentHandler.GetAttachments)
+			tasks.GET("/:id/attachments/:attachmentId", attachmentHandler.GetAttachment)
+			tasks.DELETE("/:id/attachments/:attachmentId", attachmentHandler.DeleteAttachment)
+
+			tasks.GET("/:id/members", memberHandler.GetMembers)
+			tasks.POST("/:id/members", memberHandler.InviteMember)
+			tasks.DELETE("/:id/members/:member", memberHandler.RevokeMember)
+
+			tasks.POST("/:id/share-links", taskHandler.CreateShareLink)
+			tasks.GET("/:id/share-links", taskHandler.GetShareLinks)
+			tasks.DELETE("/:id/share-links/:linkId", taskHandler.RevokeShareLink)
+		}
+
+		tasks := v1.Group("/tasks")
+		tasks.Use(middleware.AuthMiddleware(tokenManager, logger), middleware.WorkspaceMiddleware(workspaceRepo, logger))
+		registerTaskRoutes(tasks)
+
+		// Workspace routes (protected)
+		workspaces := v1.Group("/workspaces")
+		workspaces.Use(middleware.AuthMiddleware(tokenManager, logger))
+		{
+			workspaces.POST("", workspaceHandler.CreateWorkspace)
+			workspaces.GET("", workspaceHandler.GetWorkspaces)
+			workspaces.GET("/:workspaceId", workspaceHandler.GetWorkspace)
+			workspaces.PATCH("/:workspaceId", workspaceHandler.UpdateWorkspace)
+			workspaces.DELETE("/:workspaceId", workspaceHandler.DeleteWorkspace)
+			workspaces.GET("/:workspaceId/members", workspaceHandler.GetMembers)
+			workspaces.POST("/:workspaceId/members", workspaceHandler.AddMember)
+			workspaces.DELETE("/:workspaceId/members/:member", workspaceHandler.RemoveMember)
+
+			workspaceTasks := workspaces.Group("/:workspaceId/tasks")
+			workspaceTasks.Use(middleware.WorkspaceMiddleware(workspaceRepo, logger))
+			registerTaskRoutes(workspaceTasks)
+		}
+
+		// User routes (protected)
+		users := v1.Group("/users")
+		users.Use(middleware.AuthMiddleware(tokenManager, logger))
+		{
+			users.GET("/me", userHandler.GetProfile)
+			users.PATCH("/me/settings", userHandler.UpdateSettings)
+		}
+
+		// Attachment downloads (public, authorized by a signed URL)
+		v1.GET("/attachments/:id/download", attachmentHandler.DownloadAttachment)
+
+		// Shared tasks (public, authorized by a share link token)
+		v1.GET("/shared/:token", middleware.SharedRateLimitMiddleware(redisClient, cfg), taskHandler.GetSharedTask)
+
+		// Board routes (protected)
+		board := v1.Group("/board")
+		board.Use(middleware.AuthMiddleware(tokenManager, logger), middleware.WorkspaceMiddleware(workspaceRepo, logger))
+		{
+			board.GET("", boardHandler.GetBoard)
+			board.PUT("/wip-limits/:status", boardHandler.SetWIPLimit)
+			board.DELETE("/wip-limits/:status", boardHandler.DeleteWIPLimit)
+		}
+
+		// Workflow routes (protected)
+		workflow := v1.Group("/workflow")
+		workflow.Use(middleware.AuthMiddleware(tokenManager, logger))
+		{
+			workflow.GET("", workflowHandler.GetWorkflow)
+		}
+
+		// Label routes (protected)
+		labels := v1.Group("/labels")
+		labels.Use(middleware.AuthMiddleware(tokenManager, logger))
+		{
+			labels.POST("", labelHandler.CreateLabel)
+			labels.GET("", labelHandler.GetLabels)
+			labels.GET("/:id", labelHandler.GetLabel)
+			labels.PATCH("/:id", labelHandler.UpdateLabel)
+			labels.DELETE("/:id", labelHandler.DeleteLabel)
+		}
+
+		// Project routes (protected)
+		projects := v1.Group("/projects")
+		projects.Use(middleware.AuthMiddleware(tokenManager, logger), middleware.WorkspaceMiddleware(workspaceRepo, logger))
+		{
+			projects.POST("", projectHandler.CreateProject)
+			projects.GET("", projectHandler.GetProjects)
+			projects.GET("/:id", projectHandler.GetProject)
+			projects.PATCH("/:id", projectHandler.UpdateProject)
+			projects.DELETE("/:id", projectHandler.DeleteProject)
+			projects.GET("/:id/tasks", projectHandler.GetProjectTasks)
+		}
+
+		// Class routes (protected)
+		classes := v1.Group("/classes")
+		classes.Use(middleware.AuthMiddleware(tokenManager, logger))
+		{
+			classes.POST("", classHandler.CreateClass)
+			classes.GET("", classHandler.GetClasses)
+			classes.GET("/:id", classHandler.GetClass)
+			classes.DELETE("/:id", classHandler.DeleteClass)
+			classes.GET("/:id/students", classHandler.GetStudents)
+			classes.POST("/:id/students", classHandler.AddStudent)
+			classes.DELETE("/:id/students/:student", classHandler.RemoveStudent)
+			classes.POST("/:id/assignments", classHandler.CreateAssignment)
+			classes.GET("/:id/assignments", classHandler.GetAssignments)
+			classes.GET("/:id/assignments/:assignmentId", classHandler.GetAssignmentProgress)
+		}
+
+		// Template routes (protected)
+		templates := v1.Group("/templates")
+		templates.Use(middleware.AuthMiddleware(tokenManager, logger), middleware.WorkspaceMiddleware(workspaceRepo, logger))
+		{
+			templates.POST("", templateHandler.CreateTemplate)
+			templates.GET("", templateHandler.GetTemplates)
+			templates.GET("/:id", templateHandler.GetTemplate)
+			templates.PATCH("/:id", templateHandler.UpdateTemplate)
+			templates.DELETE("/:id", templateHandler.DeleteTemplate)
+			templates.POST("/:id/instantiate", templateHandler.InstantiateTemplate)
 		}
 	}
 
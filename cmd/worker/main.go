@@ -1,6 +1,9 @@
 package main
 
-import "go-corenglish/internal/worker"
+import (
+	"go-corenglish/internal/worker"
+	_ "time/tzdata" // recurring tasks repeat in their owner's time zone
+)
 
 func main() {
 	worker.Run()
@@ -27,7 +27,7 @@ var (
 	}
 	notFoundError = CustomError{
 		Code:       "ERR0003",
-		StatusCode: http.StatusBadRequest,
+		StatusCode: http.StatusNotFound,
 		Status:     false,
 		Message:    "NOT FOUND ERROR",
 	}
@@ -43,6 +43,30 @@ var (
 		Status:     false,
 		Message:    "BAD REQUEST ERROR",
 	}
+	taskBlockedError = CustomError{
+		Code:       "ERR0006",
+		StatusCode: http.StatusConflict,
+		Status:     false,
+		Message:    "TASK BLOCKED",
+	}
+	conflictError = CustomError{
+		Code:       "ERR0007",
+		StatusCode: http.StatusConflict,
+		Status:     false,
+		Message:    "CONFLICT ERROR",
+	}
+	wipLimitError = CustomError{
+		Code:       "ERR0008",
+		StatusCode: http.StatusConflict,
+		Status:     false,
+		Message:    "WIP LIMIT EXCEEDED",
+	}
+	forbiddenError = CustomError{
+		Code:       "ERR0009",
+		StatusCode: http.StatusForbidden,
+		Status:     false,
+		Message:    "FORBIDDEN",
+	}
 )
 
 func GeneralError(message ...string) *CustomError {
@@ -127,3 +151,52 @@ func BadRequestErrorWithAdditionalInfo(info interface{}, message ...string) *Cus
 	}
 	return &err
 }
+
+func ConflictError(message ...string) *CustomError {
+	err := conflictError
+	if len(message) != 0 {
+		err.Message = message[0]
+	}
+	return &err
+}
+
+func ConflictErrorWithAdditionalInfo(info interface{}, message ...string) *CustomError {
+	err := conflictError
+	err.AdditionalInfo = info
+	if len(message) != 0 {
+		err.Message = message[0]
+	}
+	return &err
+}
+
+// TaskBlockedErrorWithAdditionalInfo reports a status change refused because
+// the task still has unfinished blockers, which are listed in info.
+func TaskBlockedErrorWithAdditionalInfo(info interface{}, message ...string) *CustomError {
+	err := taskBlockedError
+	err.AdditionalInfo = info
+	if len(message) != 0 {
+		err.Message = message[0]
+	}
+	return &err
+}
+
+// WIPLimitErrorWithAdditionalInfo reports a status change refused because
+// the target column already holds as many tasks as its WIP limit allows.
+func WIPLimitErrorWithAdditionalInfo(info interface{}, message ...string) *CustomError {
+	err := wipLimitError
+	err.AdditionalInfo = info
+	if len(message) != 0 {
+		err.Message = message[0]
+	}
+	return &err
+}
+
+// ForbiddenError reports a request by a user who can see the resource but
+// whose role does not allow the action.
+func ForbiddenError(message ...string) *CustomError {
+	err := forbiddenError
+	if len(message) != 0 {
+		err.Message = message[0]
+	}
+	return &err
+}
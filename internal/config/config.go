@@ -35,6 +35,48 @@ type Config struct {
 	// Rate limiting settings
 	RateLimitRequests int
 	RateLimitWindow   int
+
+	// Share link settings
+	SharedRateLimitRequests int
+	SharedRateLimitWindow   int
+
+	// Trash settings
+	TrashRetentionDays int
+	TrashPurgeInterval int
+
+	// Archive settings
+	ArchiveInterval int
+
+	// Clone settings
+	CloneTitleSuffix string
+
+	// Recurring task settings
+	RecurrenceInterval      int
+	RecurrenceLookaheadDays int
+
+	// Reminder settings
+	ReminderPollInterval      int
+	ReminderReconcileInterval int
+	SMTPHost                  string
+	SMTPPort                  string
+	SMTPUsername              string
+	SMTPPassword              string
+	SMTPFrom                  string
+	ReminderWebhookURL        string
+	ReminderWebhookSecret     string
+
+	// Attachment storage settings
+	StorageDriver             string
+	StorageLocalPath          string
+	S3Endpoint                string
+	S3Region                  string
+	S3Bucket                  string
+	S3AccessKey               string
+	S3SecretKey               string
+	AttachmentMaxSizeMB       int
+	AttachmentURLSecret       string
+	AttachmentURLTTL          int
+	AttachmentCleanupInterval int
 }
 
 func Load() (*Config, error) {
@@ -60,6 +102,41 @@ func Load() (*Config, error) {
 		RateLimitRequests: getEnvAsInt("RATE_LIMIT_REQUESTS", 100),
 		RateLimitWindow:   getEnvAsInt("RATE_LIMIT_WINDOW", 60),
 
+		SharedRateLimitRequests: getEnvAsInt("SHARED_RATE_LIMIT_REQUESTS", 20),
+		SharedRateLimitWindow:   getEnvAsInt("SHARED_RATE_LIMIT_WINDOW", 60),
+
+		TrashRetentionDays: getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+		TrashPurgeInterval: getEnvAsInt("TRASH_PURGE_INTERVAL_MINUTES", 60),
+
+		ArchiveInterval: getEnvAsInt("ARCHIVE_INTERVAL_MINUTES", 60),
+
+		CloneTitleSuffix: getEnv("CLONE_TITLE_SUFFIX", " (copy)"),
+
+		RecurrenceInterval:      getEnvAsInt("RECURRENCE_INTERVAL_MINUTES", 60),
+		RecurrenceLookaheadDays: getEnvAsInt("RECURRENCE_LOOKAHEAD_DAYS", 7),
+
+		ReminderPollInterval:      getEnvAsInt("REMINDER_POLL_INTERVAL_SECONDS", 10),
+		ReminderReconcileInterval: getEnvAsInt("REMINDER_RECONCILE_INTERVAL_MINUTES", 15),
+		SMTPHost:                  getEnv("SMTP_HOST", ""),
+		SMTPPort:                  getEnv("SMTP_PORT", "587"),
+		SMTPUsername:              getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:              getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:                  getEnv("SMTP_FROM", "reminders@corenglish.local"),
+		ReminderWebhookURL:        getEnv("REMINDER_WEBHOOK_URL", ""),
+		ReminderWebhookSecret:     getEnv("REMINDER_WEBHOOK_SECRET", ""),
+
+		StorageDriver:             getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalPath:          getEnv("STORAGE_LOCAL_PATH", "data/attachments"),
+		S3Endpoint:                getEnv("S3_ENDPOINT", ""),
+		S3Region:                  getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:                  getEnv("S3_BUCKET", ""),
+		S3AccessKey:               getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:               getEnv("S3_SECRET_KEY", ""),
+		AttachmentMaxSizeMB:       getEnvAsInt("ATTACHMENT_MAX_SIZE_MB", 10),
+		AttachmentURLSecret:       getEnv("ATTACHMENT_URL_SECRET", "default-attachment-secret"),
+		AttachmentURLTTL:          getEnvAsInt("ATTACHMENT_URL_TTL_MINUTES", 15),
+		AttachmentCleanupInterval: getEnvAsInt("ATTACHMENT_CLEANUP_INTERVAL_MINUTES", 10),
+
 		JWTSecret:  getEnv("JWT_SECRET", "default-secret-key"),
 		JWTExpiry:  getEnvAsInt("JWT_EXPIRY", 24),
 		BcryptCost: getEnvAsInt("BCRYPT_COST", 10),
@@ -1,5 +1,7 @@
 package enum
 
+import "sync"
+
 type TaskStatus string
 
 const (
@@ -8,6 +10,144 @@ const (
 	StatusDone       TaskStatus = "DONE"
 )
 
+// StatusCategory groups statuses by meaning so that code can reason about
+// "not started", "in progress" and "finished" without knowing every status
+// configured in the workflow.
+type StatusCategory string
+
+const (
+	CategoryToDo       StatusCategory = "TO_DO"
+	CategoryInProgress StatusCategory = "IN_PROGRESS"
+	CategoryDone       StatusCategory = "DONE"
+)
+
+func (c StatusCategory) IsValid() bool {
+	return c == CategoryToDo || c == CategoryInProgress || c == CategoryDone
+}
+
+var (
+	statusMu sync.RWMutex
+	// statuses holds the workflow's statuses. It starts with the built-in
+	// ones and is replaced by RegisterStatuses once the workflow is loaded.
+	statuses = map[TaskStatus]StatusCategory{
+		StatusToDo:       CategoryToDo,
+		StatusInProgress: CategoryInProgress,
+		StatusDone:       CategoryDone,
+	}
+)
+
+// RegisterStatuses replaces the set of valid statuses and their categories.
+func RegisterStatuses(registered map[TaskStatus]StatusCategory) {
+	copied := make(map[TaskStatus]StatusCategory, len(registered))
+	for status, category := range registered {
+		copied[status] = category
+	}
+
+	statusMu.Lock()
+	statuses = copied
+	statusMu.Unlock()
+}
+
 func (s TaskStatus) IsValid() bool {
-	return s == StatusToDo || s == StatusInProgress || s == StatusDone
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	_, ok := statuses[s]
+	return ok
+}
+
+// Category returns the category of a registered status, or "" if unknown.
+func (s TaskStatus) Category() StatusCategory {
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	return statuses[s]
+}
+
+// IsDone reports whether the status counts as finished.
+func (s TaskStatus) IsDone() bool {
+	return s.Category() == CategoryDone
+}
+
+type TaskPriority string
+
+const (
+	PriorityLow    TaskPriority = "LOW"
+	PriorityMedium TaskPriority = "MEDIUM"
+	PriorityHigh   TaskPriority = "HIGH"
+	PriorityUrgent TaskPriority = "URGENT"
+)
+
+func (p TaskPriority) IsValid() bool {
+	return p == PriorityLow || p == PriorityMedium || p == PriorityHigh || p == PriorityUrgent
+}
+
+// ReminderChannel is how the worker delivers a task reminder.
+type ReminderChannel string
+
+const (
+	ReminderChannelEmail   ReminderChannel = "EMAIL"
+	ReminderChannelWebhook ReminderChannel = "WEBHOOK"
+)
+
+func (c ReminderChannel) IsValid() bool {
+	return c == ReminderChannelEmail || c == ReminderChannelWebhook
+}
+
+// TaskRole is what a user may do with a task. The owner can do everything,
+// including sharing; members are invited as editors or viewers.
+type TaskRole string
+
+const (
+	RoleOwner  TaskRole = "OWNER"
+	RoleEditor TaskRole = "EDITOR"
+	RoleViewer TaskRole = "VIEWER"
+)
+
+var roleRanks = map[TaskRole]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// IsValid reports whether the role can be given to a member.
+func (r TaskRole) IsValid() bool {
+	return r == RoleEditor || r == RoleViewer
+}
+
+// Allows reports whether the role includes everything required may do.
+func (r TaskRole) Allows(required TaskRole) bool {
+	return roleRanks[r] >= roleRanks[required] && roleRanks[required] > 0
+}
+
+// WorkspaceRole is what a user may do in a workspace. The owner can do
+// everything, admins manage members, and members work on their own tasks.
+type WorkspaceRole string
+
+const (
+	WorkspaceOwner  WorkspaceRole = "OWNER"
+	WorkspaceAdmin  WorkspaceRole = "ADMIN"
+	WorkspaceMember WorkspaceRole = "MEMBER"
+)
+
+var workspaceRoleRanks = map[WorkspaceRole]int{
+	WorkspaceMember: 1,
+	WorkspaceAdmin:  2,
+	WorkspaceOwner:  3,
+}
+
+// IsValid reports whether the role can be given to a member.
+func (r WorkspaceRole) IsValid() bool {
+	return r == WorkspaceAdmin || r == WorkspaceMember
 }
+
+// Allows reports whether the role includes everything required may do.
+func (r WorkspaceRole) Allows(required WorkspaceRole) bool {
+	return workspaceRoleRanks[r] >= workspaceRoleRanks[required] && workspaceRoleRanks[required] > 0
+}
+
+// ClassRole is how a user takes part in a class.
+type ClassRole string
+
+const (
+	ClassTeacher ClassRole = "TEACHER"
+	ClassStudent ClassRole = "STUDENT"
+)
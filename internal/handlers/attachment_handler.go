@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/services"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// multipartOverhead is allowed on top of the maximum file size for the
+// multipart boundaries and headers of an upload.
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	attachmentService services.AttachmentService
+	maxUploadSize     int64
+	logger            *logrus.Logger
+}
+
+func NewAttachmentHandler(attachmentService services.AttachmentService, maxUploadSizeMB int, logger *logrus.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		maxUploadSize:     int64(maxUploadSizeMB) << 20,
+		logger:            logger,
+	}
+}
+
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_request",
+			"message": fmt.Sprintf("A multipart \"file\" field of at most %d bytes is required", h.maxUploadSize),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to open uploaded file")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_request",
+			"message": "Failed to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	attachment, custErr := h.attachmentService.UploadAttachment(taskID, userID, workspaceID, fileHeader.Filename, fileHeader.Size, file)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(attachment)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	attachments, custErr := h.attachmentService.GetAttachments(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get attachments", attachments)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	attachmentID, ok := getUUIDParam(c, "attachmentId", "invalid_attachment_id", "Invalid attachment ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	attachment, custErr := h.attachmentService.GetAttachment(taskID, attachmentID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get attachment", attachment)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	attachmentID, ok := getUUIDParam(c, "attachmentId", "invalid_attachment_id", "Invalid attachment ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	if custErr := h.attachmentService.DeleteAttachment(taskID, attachmentID, userID, workspaceID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success delete attachment", nil)
+	c.JSON(http.StatusOK, resp)
+}
+
+// DownloadAttachment streams an attachment. It is not behind AuthMiddleware;
+// the signed expires/signature query parameters authorize the request.
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	attachmentID, ok := getUUIDParam(c, "id", "invalid_attachment_id", "Invalid attachment ID format")
+	if !ok {
+		return
+	}
+
+	attachment, content, custErr := h.attachmentService.OpenDownload(attachmentID, c.Query("expires"), c.Query("signature"))
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.SizeBytes, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"X-Checksum-SHA256":      attachment.ChecksumSHA256,
+	})
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type BoardHandler struct {
+	boardService services.BoardService
+	logger       *logrus.Logger
+	validator    *validator.Validate
+}
+
+func NewBoardHandler(boardService services.BoardService, logger *logrus.Logger) *BoardHandler {
+	return &BoardHandler{
+		boardService: boardService,
+		logger:       logger,
+		validator:    validator.New(),
+	}
+}
+
+func (h *BoardHandler) GetBoard(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	filter, err := parseTaskFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+	filter.WorkspaceID = workspaceID
+
+	// Columns are the statuses, and cards follow the manual order unless
+	// another sort is requested.
+	filter.Status = ""
+	if len(filter.Sort) == 0 {
+		filter.Sort = []params.SortField{{Field: "position"}}
+	}
+
+	pages, err := parseColumnPages(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	board, custErr := h.boardService.GetBoard(userID, filter, pages)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get board", board)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *BoardHandler) SetWIPLimit(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req params.SetWIPLimitRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	limit, custErr := h.boardService.SetWIPLimit(userID, enum.TaskStatus(c.Param("status")), &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success set wip limit", limit)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *BoardHandler) DeleteWIPLimit(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	if custErr := h.boardService.DeleteWIPLimit(userID, enum.TaskStatus(c.Param("status"))); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success delete wip limit", nil)
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseColumnPages reads page[STATUS]=N query parameters, which select the
+// page of a single board column.
+func parseColumnPages(c *gin.Context) (map[enum.TaskStatus]int, error) {
+	pages := make(map[enum.TaskStatus]int)
+	for status, value := range c.QueryMap("page") {
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return nil, fmt.Errorf("invalid page for status %s: must be a positive integer", status)
+		}
+		pages[enum.TaskStatus(status)] = page
+	}
+	return pages, nil
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ChecklistHandler struct {
+	checklistService services.ChecklistService
+	logger           *logrus.Logger
+	validator        *validator.Validate
+}
+
+func NewChecklistHandler(checklistService services.ChecklistService, logger *logrus.Logger) *ChecklistHandler {
+	return &ChecklistHandler{
+		checklistService: checklistService,
+		logger:           logger,
+		validator:        validator.New(),
+	}
+}
+
+func (h *ChecklistHandler) GetChecklist(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	checklist, custErr := h.checklistService.GetChecklist(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get checklist", checklist)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ChecklistHandler) AddItem(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	var req params.CreateChecklistItemRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	item, custErr := h.checklistService.AddItem(taskID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(item)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *ChecklistHandler) UpdateItem(c *gin.Context) {
+	userID, taskID, itemID, ok := getChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	var req params.UpdateChecklistItemRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	item, custErr := h.checklistService.UpdateItem(taskID, itemID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success update checklist item", item)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ChecklistHandler) ToggleItem(c *gin.Context) {
+	userID, taskID, itemID, ok := getChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	item, custErr := h.checklistService.ToggleItem(taskID, itemID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success toggle checklist item", item)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ChecklistHandler) MoveItem(c *gin.Context) {
+	userID, taskID, itemID, ok := getChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	var req params.MoveChecklistItemRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	item, custErr := h.checklistService.MoveItem(taskID, itemID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success move checklist item", item)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ChecklistHandler) DeleteItem(c *gin.Context) {
+	userID, taskID, itemID, ok := getChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	if custErr := h.checklistService.DeleteItem(taskID, itemID, userID, workspaceID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success delete checklist item", nil)
+	c.JSON(http.StatusOK, resp)
+}
+
+// getChecklistItemParams reads the user and the task and item path
+// parameters shared by the item endpoints.
+func getChecklistItemParams(c *gin.Context) (userID, taskID, itemID uuid.UUID, ok bool) {
+	if userID, ok = getUserID(c); !ok {
+		return
+	}
+	if taskID, ok = getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format"); !ok {
+		return
+	}
+	itemID, ok = getUUIDParam(c, "itemId", "invalid_item_id", "Invalid checklist item ID format")
+	return
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type ClassHandler struct {
+	classService services.ClassService
+	logger       *logrus.Logger
+	validator    *validator.Validate
+}
+
+func NewClassHandler(classService services.ClassService, logger *logrus.Logger) *ClassHandler {
+	return &ClassHandler{
+		classService: classService,
+		logger:       logger,
+		validator:    validator.New(),
+	}
+}
+
+func (h *ClassHandler) CreateClass(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req params.CreateClassRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	class, custErr := h.classService.CreateClass(userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(class)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *ClassHandler) GetClasses(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	classes, custErr := h.classService.GetClasses(userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get classes", classes)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ClassHandler) GetClass(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	classID, ok := getUUIDParam(c, "id", "invalid_class_id", "Invalid class ID format")
+	if !ok {
+		return
+	}
+
+	class, custErr := h.classService.GetClass(classID, userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get class", class)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ClassHandler) DeleteClass(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	classID, ok := getUUIDParam(c, "id", "invalid_class_id", "Invalid class ID format")
+	if !ok {
+		return
+	}
+
+	if custErr := h.classService.DeleteClass(classID, userID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success delete class", nil)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ClassHandler) GetStudents(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	classID, ok := getUUIDParam(c, "id", "invalid_class_id", "Invalid class ID format")
+	if !ok {
+		return
+	}
+
+	students, custErr := h.classService.GetStudents(classID, userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get class students", students)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ClassHandler) AddStudent(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	classID, ok := getUUIDParam(c, "id", "invalid_class_id", "Invalid class ID format")
+	if !ok {
+		return
+	}
+
+	var req params.AddClassStudentRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	student, custErr := h.classService.AddStudent(classID, userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success add class student", student)
+	c.JSON(http.StatusOK, resp)
+}
+
+// RemoveStudent takes the student, by username or email, from the path.
+func (h *ClassHandler) RemoveStudent(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	classID, ok := getUUIDParam(c, "id", "invalid_class_id", "Invalid class ID format")
+	if !ok {
+		return
+	}
+
+	if custErr := h.classService.RemoveStudent(classID, userID, c.Param("student")); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success remove class student", nil)
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateAssignment hands a task out to every student of the class and
+// returns the progress view of the new assignment.
+func (h *ClassHandler) CreateAssignment(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	classID, ok := getUUIDParam(c, "id", "invalid_class_id", "Invalid class ID format")
+	if !ok {
+		return
+	}
+
+	var req params.CreateAssignmentRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	progress, custErr := h.classService.CreateAssignment(classID, userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(progress)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *ClassHandler) GetAssignments(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	classID, ok := getUUIDParam(c, "id", "invalid_class_id", "Invalid class ID format")
+	if !ok {
+		return
+	}
+
+	assignments, custErr := h.classService.GetAssignments(classID, userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get assignments", assignments)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ClassHandler) GetAssignmentProgress(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	classID, ok := getUUIDParam(c, "id", "invalid_class_id", "Invalid class ID format")
+	if !ok {
+		return
+	}
+
+	assignmentID, ok := getUUIDParam(c, "assignmentId", "invalid_assignment_id", "Invalid assignment ID format")
+	if !ok {
+		return
+	}
+
+	progress, custErr := h.classService.GetAssignmentProgress(classID, assignmentID, userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get assignment progress", progress)
+	c.JSON(http.StatusOK, resp)
+}
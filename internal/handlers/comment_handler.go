@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type CommentHandler struct {
+	commentService services.CommentService
+	logger         *logrus.Logger
+	validator      *validator.Validate
+}
+
+func NewCommentHandler(commentService services.CommentService, logger *logrus.Logger) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		logger:         logger,
+		validator:      validator.New(),
+	}
+}
+
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	var req params.CreateCommentRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	comment, custErr := h.commentService.CreateComment(taskID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(comment)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	_, limit := parsePagination(c)
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	comments, custErr := h.commentService.GetComments(taskID, userID, workspaceID, c.Query("cursor"), limit)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get comments", comments)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	commentID, ok := getUUIDParam(c, "commentId", "invalid_comment_id", "Invalid comment ID format")
+	if !ok {
+		return
+	}
+
+	var req params.UpdateCommentRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	comment, custErr := h.commentService.UpdateComment(taskID, commentID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success update comment", comment)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	commentID, ok := getUUIDParam(c, "commentId", "invalid_comment_id", "Invalid comment ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	if custErr := h.commentService.DeleteComment(taskID, commentID, userID, workspaceID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success delete comment", nil)
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// getUserID returns the authenticated user set by AuthMiddleware. When it is
+// missing the request is answered with 401 and ok is false.
+func getUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  false,
+			"error":   "unauthorized",
+			"message": "User ID not found in context",
+		})
+		return uuid.Nil, false
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  false,
+			"error":   "unauthorized",
+			"message": "Invalid user ID format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
+
+// getWorkspaceID returns the workspace set by WorkspaceMiddleware. When it is
+// missing the request is answered with 400 and ok is false.
+func getWorkspaceID(c *gin.Context) (uuid.UUID, bool) {
+	value, _ := c.Get("workspace_id")
+	workspaceID, ok := value.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_workspace",
+			"message": "Workspace not found in context",
+		})
+		return uuid.Nil, false
+	}
+
+	return workspaceID, true
+}
+
+// getUUIDParam parses the named path parameter as a UUID. When it is invalid
+// the request is answered with 400 and ok is false.
+func getUUIDParam(c *gin.Context, name, errCode, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   errCode,
+			"message": message,
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// bindJSON decodes and validates the request body into req. When either step
+// fails the request is answered with 400 and false is returned.
+func bindJSON(c *gin.Context, v *validator.Validate, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_request",
+			"message": "Invalid JSON format",
+		})
+		return false
+	}
+
+	if err := v.Struct(req); err != nil {
+		details := make(map[string]string)
+		for _, err := range err.(validator.ValidationErrors) {
+			details[err.Field()] = getValidationErrorMessage(err)
+		}
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"message": "Validation failed",
+			"errors":  details,
+		})
+		return false
+	}
+
+	return true
+}
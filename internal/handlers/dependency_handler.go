@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type DependencyHandler struct {
+	dependencyService services.DependencyService
+	logger            *logrus.Logger
+	validator         *validator.Validate
+}
+
+func NewDependencyHandler(dependencyService services.DependencyService, logger *logrus.Logger) *DependencyHandler {
+	return &DependencyHandler{
+		dependencyService: dependencyService,
+		logger:            logger,
+		validator:         validator.New(),
+	}
+}
+
+func (h *DependencyHandler) AddDependency(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	var req params.AddDependencyRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	dependencies, custErr := h.dependencyService.AddDependency(taskID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(dependencies)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *DependencyHandler) GetDependencies(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	dependencies, custErr := h.dependencyService.GetDependencies(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get dependencies", dependencies)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *DependencyHandler) RemoveDependency(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	blockedByID, ok := getUUIDParam(c, "blockerId", "invalid_task_id", "Invalid blocking task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	if custErr := h.dependencyService.RemoveDependency(taskID, blockedByID, userID, workspaceID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success remove dependency", nil)
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type LabelHandler struct {
+	labelService services.LabelService
+	logger       *logrus.Logger
+	validator    *validator.Validate
+}
+
+func NewLabelHandler(labelService services.LabelService, logger *logrus.Logger) *LabelHandler {
+	return &LabelHandler{
+		labelService: labelService,
+		logger:       logger,
+		validator:    validator.New(),
+	}
+}
+
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req params.CreateLabelRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	label, custErr := h.labelService.CreateLabel(userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(label)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *LabelHandler) GetLabels(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	labels, custErr := h.labelService.GetLabels(userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get labels", labels)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *LabelHandler) GetLabel(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	labelID, ok := getUUIDParam(c, "id", "invalid_label_id", "Invalid label ID format")
+	if !ok {
+		return
+	}
+
+	label, custErr := h.labelService.GetLabel(labelID, userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get label", label)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	labelID, ok := getUUIDParam(c, "id", "invalid_label_id", "Invalid label ID format")
+	if !ok {
+		return
+	}
+
+	var req params.UpdateLabelRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	label, custErr := h.labelService.UpdateLabel(labelID, userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success update label", label)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	labelID, ok := getUUIDParam(c, "id", "invalid_label_id", "Invalid label ID format")
+	if !ok {
+		return
+	}
+
+	if custErr := h.labelService.DeleteLabel(labelID, userID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success delete label", nil)
+	c.JSON(http.StatusOK, resp)
+}
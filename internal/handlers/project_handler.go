@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectHandler struct {
+	projectService services.ProjectService
+	logger         *logrus.Logger
+	validator      *validator.Validate
+}
+
+func NewProjectHandler(projectService services.ProjectService, logger *logrus.Logger) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+		logger:         logger,
+		validator:      validator.New(),
+	}
+}
+
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	var req params.CreateProjectRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	project, custErr := h.projectService.CreateProject(userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(project)
+	c.JSON(resp.StatusCode, resp)
+}
+
+// GetProjects lists the projects of the current workspace. Archived projects
+// are left out unless include_archived=true.
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	includeArchived, err := parseBoolQuery(c, "include_archived")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	projects, custErr := h.projectService.GetProjects(userID, workspaceID, includeArchived)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get projects", projects)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	projectID, ok := getUUIDParam(c, "id", "invalid_project_id", "Invalid project ID format")
+	if !ok {
+		return
+	}
+
+	project, custErr := h.projectService.GetProject(projectID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get project", project)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	projectID, ok := getUUIDParam(c, "id", "invalid_project_id", "Invalid project ID format")
+	if !ok {
+		return
+	}
+
+	var req params.UpdateProjectRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	project, custErr := h.projectService.UpdateProject(projectID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success update project", project)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	projectID, ok := getUUIDParam(c, "id", "invalid_project_id", "Invalid project ID format")
+	if !ok {
+		return
+	}
+
+	if custErr := h.projectService.DeleteProject(projectID, userID, workspaceID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success delete project", nil)
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetProjectTasks lists the tasks of a project. It takes the same query
+// parameters as GET /api/v1/tasks.
+func (h *ProjectHandler) GetProjectTasks(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	projectID, ok := getUUIDParam(c, "id", "invalid_project_id", "Invalid project ID format")
+	if !ok {
+		return
+	}
+
+	filter, err := parseTaskFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+		return
+	}
+	filter.WorkspaceID = workspaceID
+
+	tasks, custErr := h.projectService.GetProjectTasks(projectID, userID, filter)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get project tasks", tasks)
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type StatsHandler struct {
+	statsService services.StatsService
+	logger       *logrus.Logger
+}
+
+func NewStatsHandler(statsService services.StatsService, logger *logrus.Logger) *StatsHandler {
+	return &StatsHandler{
+		statsService: statsService,
+		logger:       logger,
+	}
+}
+
+func (h *StatsHandler) GetTaskStats(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	stats, custErr := h.statsService.GetTaskStats(userID, workspaceID, from, to)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get task stats", stats)
+	c.JSON(http.StatusOK, resp)
+}
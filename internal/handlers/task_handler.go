@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"fmt"
 	"go-corenglish/internal/commons/response"
 	"go-corenglish/internal/params"
 	"go-corenglish/internal/services"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -72,7 +75,38 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
-	task, custErr := h.taskService.CreateTask(userUUID, &req)
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.CreateTask(userUUID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(task)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *TaskHandler) QuickAddTask(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req params.QuickAddTaskRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.QuickAddTask(userID, workspaceID, &req)
 	if custErr != nil {
 		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
 		return
@@ -103,18 +137,23 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 		return
 	}
 
-	status := c.Query("status")
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-
-	if page < 1 {
-		page = 1
+	filter, err := parseTaskFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+		return
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
 	}
+	filter.WorkspaceID = workspaceID
 
-	tasks, custErr := h.taskService.GetTasks(userUUID, status, page, limit)
+	tasks, custErr := h.taskService.GetTasks(userUUID, filter)
 	if custErr != nil {
 		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
 		return
@@ -156,7 +195,12 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 		return
 	}
 
-	task, custErr := h.taskService.GetTask(taskID, userUUID)
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.GetTask(taskID, userUUID, workspaceID)
 	if custErr != nil {
 		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
 		return
@@ -223,7 +267,12 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
-	task, custErr := h.taskService.UpdateTask(taskID, userUUID, &req)
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.UpdateTask(taskID, userUUID, workspaceID, &req)
 	if custErr != nil {
 		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
 		return
@@ -234,6 +283,90 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *TaskHandler) ReopenTask(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	// The body is optional; without it the task is reopened to TO_DO.
+	var req params.ReopenTaskRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.ReopenTask(taskID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success reopen task", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) GetSubtasks(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	subtasks, custErr := h.taskService.GetSubtasks(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get subtasks", subtasks)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) GetTaskHistory(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	history, custErr := h.taskService.GetTaskHistory(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get task history", history)
+	c.JSON(http.StatusOK, resp)
+}
+
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -266,7 +399,12 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	custErr := h.taskService.DeleteTask(taskID, userUUID)
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	custErr := h.taskService.DeleteTask(taskID, userUUID, workspaceID)
 	if custErr != nil {
 		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
 		return
@@ -275,6 +413,561 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *TaskHandler) GetTrash(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	page, limit := parsePagination(c)
+
+	tasks, custErr := h.taskService.GetTrash(userID, workspaceID, page, limit)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get trashed tasks", tasks)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) RestoreTask(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.RestoreTask(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success restore task", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) ArchiveTask(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.ArchiveTask(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success archive task", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) UnarchiveTask(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.UnarchiveTask(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success unarchive task", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	var req params.MoveTaskRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.MoveTask(taskID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success move task", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) SnoozeTask(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	var req params.SnoozeTaskRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.SnoozeTask(taskID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success snooze task", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) UnsnoozeTask(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.UnsnoozeTask(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success unsnooze task", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) GetMyDay(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	filter, err := parseTaskFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+	filter.WorkspaceID = workspaceID
+
+	myDay, custErr := h.taskService.GetMyDay(userID, filter)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get my day", myDay)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) AddToMyDay(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.AddToMyDay(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success add task to my day", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) RemoveFromMyDay(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.RemoveFromMyDay(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success remove task from my day", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) CloneTask(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	// The body is optional; without it the defaults are used.
+	var req params.CloneTaskRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	task, custErr := h.taskService.CloneTask(taskID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(task)
+	c.JSON(resp.StatusCode, resp)
+}
+
+// CloneTasks copies the tasks matched by the same query parameters as
+// GetTasks.
+func (h *TaskHandler) CloneTasks(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	filter, err := parseTaskFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+	filter.WorkspaceID = workspaceID
+
+	var req params.CloneTaskRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	tasks, custErr := h.taskService.CloneTasks(userID, filter, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(tasks)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *TaskHandler) CreateShareLink(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	// The body is optional; without it the link never expires.
+	var req params.CreateShareLinkRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	link, custErr := h.taskService.CreateShareLink(taskID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(link)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *TaskHandler) GetShareLinks(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	links, custErr := h.taskService.GetShareLinks(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get share links", links)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskHandler) RevokeShareLink(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	linkID, ok := getUUIDParam(c, "linkId", "invalid_share_link_id", "Invalid share link ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	if custErr := h.taskService.RevokeShareLink(taskID, linkID, userID, workspaceID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success revoke share link", nil)
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetSharedTask serves a task to anyone holding a share link token. It is
+// not behind AuthMiddleware.
+func (h *TaskHandler) GetSharedTask(c *gin.Context) {
+	task, custErr := h.taskService.GetSharedTask(c.Param("token"))
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get shared task", task)
+	c.JSON(http.StatusOK, resp)
+}
+
+// parsePagination reads page and limit, falling back to the defaults when
+// they are missing or out of range.
+func parsePagination(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return page, limit
+}
+
+// parseTaskFilter reads the filtering and pagination options of GET /api/v1/tasks.
+func parseTaskFilter(c *gin.Context) (*params.TaskFilter, error) {
+	page, limit := parsePagination(c)
+
+	filter := &params.TaskFilter{
+		Status:     c.Query("status"),
+		Priority:   c.Query("priority"),
+		Labels:     splitQueryList(c.Query("label")),
+		LabelMatch: c.DefaultQuery("label_match", params.LabelMatchAny),
+		Sort:       parseSortQuery(c.Query("sort")),
+		Page:       page,
+		Limit:      limit,
+	}
+
+	var err error
+	if filter.DueBefore, err = parseTimeQuery(c, "due_before"); err != nil {
+		return nil, err
+	}
+	if filter.DueAfter, err = parseTimeQuery(c, "due_after"); err != nil {
+		return nil, err
+	}
+	if filter.Overdue, err = parseBoolQuery(c, "overdue"); err != nil {
+		return nil, err
+	}
+	if filter.IncludeArchived, err = parseBoolQuery(c, "include_archived"); err != nil {
+		return nil, err
+	}
+	if filter.IncludeSnoozed, err = parseBoolQuery(c, "include_snoozed"); err != nil {
+		return nil, err
+	}
+	if c.Query("shared") != "" {
+		shared, err := parseBoolQuery(c, "shared")
+		if err != nil {
+			return nil, err
+		}
+		filter.Shared = &shared
+	}
+
+	return filter, nil
+}
+
+// parseSortQuery splits a sort=priority,-due_at,title value into sort keys.
+// A leading "-" requests descending order. Keys are validated by the service.
+func parseSortQuery(value string) []params.SortField {
+	var fields []params.SortField
+	for _, key := range splitQueryList(value) {
+		field := params.SortField{Field: key}
+		if strings.HasPrefix(key, "-") {
+			field.Field = strings.TrimPrefix(key, "-")
+			field.Desc = true
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// splitQueryList splits a comma-separated query value, dropping empty items.
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseTimeQuery(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC3339 timestamp", key)
+	}
+	return &t, nil
+}
+
+func parseBoolQuery(c *gin.Context, key string) (bool, error) {
+	value := c.Query(key)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean", key)
+	}
+	return b, nil
+}
+
 func getValidationErrorMessage(err validator.FieldError) string {
 	switch err.Tag() {
 	case "required":
@@ -287,6 +980,10 @@ func getValidationErrorMessage(err validator.FieldError) string {
 		return "This field must be a valid email"
 	case "oneof":
 		return "This field must be one of: " + err.Param()
+	case "hexcolor":
+		return "This field must be a hex colour such as #1E90FF"
+	case "unique":
+		return "This field must not contain duplicates"
 	default:
 		return "This field is invalid"
 	}
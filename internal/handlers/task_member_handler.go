@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type TaskMemberHandler struct {
+	memberService services.TaskMemberService
+	logger        *logrus.Logger
+	validator     *validator.Validate
+}
+
+func NewTaskMemberHandler(memberService services.TaskMemberService, logger *logrus.Logger) *TaskMemberHandler {
+	return &TaskMemberHandler{
+		memberService: memberService,
+		logger:        logger,
+		validator:     validator.New(),
+	}
+}
+
+func (h *TaskMemberHandler) GetMembers(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	members, custErr := h.memberService.GetMembers(taskID, userID, workspaceID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get task members", members)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TaskMemberHandler) InviteMember(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	var req params.InviteMemberRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	member, custErr := h.memberService.InviteMember(taskID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success invite task member", member)
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeMember removes the member named by :member, a username or an email.
+func (h *TaskMemberHandler) RevokeMember(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	taskID, ok := getUUIDParam(c, "id", "invalid_task_id", "Invalid task ID format")
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	if custErr := h.memberService.RevokeMember(taskID, userID, workspaceID, c.Param("member")); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success revoke task member", nil)
+	c.JSON(http.StatusOK, resp)
+}
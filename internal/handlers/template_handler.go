@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type TemplateHandler struct {
+	templateService services.TemplateService
+	logger          *logrus.Logger
+	validator       *validator.Validate
+}
+
+func NewTemplateHandler(templateService services.TemplateService, logger *logrus.Logger) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+		logger:          logger,
+		validator:       validator.New(),
+	}
+}
+
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req params.CreateTemplateRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	template, custErr := h.templateService.CreateTemplate(userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(template)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *TemplateHandler) GetTemplates(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	templates, custErr := h.templateService.GetTemplates(userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get templates", templates)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	templateID, ok := getUUIDParam(c, "id", "invalid_template_id", "Invalid template ID format")
+	if !ok {
+		return
+	}
+
+	template, custErr := h.templateService.GetTemplate(templateID, userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get template", template)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	templateID, ok := getUUIDParam(c, "id", "invalid_template_id", "Invalid template ID format")
+	if !ok {
+		return
+	}
+
+	var req params.UpdateTemplateRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	template, custErr := h.templateService.UpdateTemplate(templateID, userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success update template", template)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	templateID, ok := getUUIDParam(c, "id", "invalid_template_id", "Invalid template ID format")
+	if !ok {
+		return
+	}
+
+	if custErr := h.templateService.DeleteTemplate(templateID, userID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success delete template", nil)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TemplateHandler) InstantiateTemplate(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	templateID, ok := getUUIDParam(c, "id", "invalid_template_id", "Invalid template ID format")
+	if !ok {
+		return
+	}
+
+	// The body is optional: without instances a single task is created.
+	var req params.InstantiateTemplateRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspaceID, ok := getWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	tasks, custErr := h.templateService.InstantiateTemplate(templateID, userID, workspaceID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(tasks)
+	c.JSON(resp.StatusCode, resp)
+}
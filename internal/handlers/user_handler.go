@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type UserHandler struct {
+	userService services.UserService
+	logger      *logrus.Logger
+	validator   *validator.Validate
+}
+
+func NewUserHandler(userService services.UserService, logger *logrus.Logger) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		logger:      logger,
+		validator:   validator.New(),
+	}
+}
+
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	profile, custErr := h.userService.GetProfile(userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get profile", profile)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) UpdateSettings(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req params.UpdateUserSettingsRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	profile, custErr := h.userService.UpdateSettings(userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success update settings", profile)
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type WorkflowHandler struct {
+	workflowService services.WorkflowService
+	logger          *logrus.Logger
+}
+
+func NewWorkflowHandler(workflowService services.WorkflowService, logger *logrus.Logger) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+		logger:          logger,
+	}
+}
+
+func (h *WorkflowHandler) GetWorkflow(c *gin.Context) {
+	workflow, custErr := h.workflowService.GetWorkflow()
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get workflow", workflow)
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type WorkspaceHandler struct {
+	workspaceService services.WorkspaceService
+	logger           *logrus.Logger
+	validator        *validator.Validate
+}
+
+func NewWorkspaceHandler(workspaceService services.WorkspaceService, logger *logrus.Logger) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceService: workspaceService,
+		logger:           logger,
+		validator:        validator.New(),
+	}
+}
+
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req params.CreateWorkspaceRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspace, custErr := h.workspaceService.CreateWorkspace(userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.CreatedSuccessWithPayload(workspace)
+	c.JSON(resp.StatusCode, resp)
+}
+
+func (h *WorkspaceHandler) GetWorkspaces(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaces, custErr := h.workspaceService.GetWorkspaces(userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get workspaces", workspaces)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getUUIDParam(c, "workspaceId", "invalid_workspace_id", "Invalid workspace ID format")
+	if !ok {
+		return
+	}
+
+	workspace, custErr := h.workspaceService.GetWorkspace(workspaceID, userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get workspace", workspace)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getUUIDParam(c, "workspaceId", "invalid_workspace_id", "Invalid workspace ID format")
+	if !ok {
+		return
+	}
+
+	var req params.UpdateWorkspaceRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	workspace, custErr := h.workspaceService.UpdateWorkspace(workspaceID, userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success update workspace", workspace)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getUUIDParam(c, "workspaceId", "invalid_workspace_id", "Invalid workspace ID format")
+	if !ok {
+		return
+	}
+
+	if custErr := h.workspaceService.DeleteWorkspace(workspaceID, userID); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success delete workspace", nil)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WorkspaceHandler) GetMembers(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getUUIDParam(c, "workspaceId", "invalid_workspace_id", "Invalid workspace ID format")
+	if !ok {
+		return
+	}
+
+	members, custErr := h.workspaceService.GetMembers(workspaceID, userID)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success get workspace members", members)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WorkspaceHandler) AddMember(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getUUIDParam(c, "workspaceId", "invalid_workspace_id", "Invalid workspace ID format")
+	if !ok {
+		return
+	}
+
+	var req params.AddWorkspaceMemberRequest
+	if !bindJSON(c, h.validator, &req) {
+		return
+	}
+
+	member, custErr := h.workspaceService.AddMember(workspaceID, userID, &req)
+	if custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success add workspace member", member)
+	c.JSON(http.StatusOK, resp)
+}
+
+// RemoveMember removes the member named by :member, a username or an email.
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaceID, ok := getUUIDParam(c, "workspaceId", "invalid_workspace_id", "Invalid workspace ID format")
+	if !ok {
+		return
+	}
+
+	if custErr := h.workspaceService.RemoveMember(workspaceID, userID, c.Param("member")); custErr != nil {
+		c.AbortWithStatusJSON(custErr.StatusCode, custErr)
+		return
+	}
+
+	resp := response.GeneralSuccessCustomMessageAndPayload("Success remove workspace member", nil)
+	c.JSON(http.StatusOK, resp)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go-corenglish/internal/commons/response"
 	"go-corenglish/internal/config"
+	"go-corenglish/internal/repositories"
 	"go-corenglish/pkg/token"
 	"net/http"
 	"strconv"
@@ -75,7 +76,7 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Workspace-ID")
 		c.Header("Access-Control-Allow-Credentials", "true")
 
 		if c.Request.Method == "OPTIONS" {
@@ -137,15 +138,90 @@ func AuthMiddleware(tm *token.TokenManager, logger *logrus.Logger) gin.HandlerFu
 	}
 }
 
+// WorkspaceHeader names the workspace a request works in when the path does
+// not.
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceMiddleware resolves the workspace of a request from the
+// :workspaceId path segment or the X-Workspace-ID header, falling back to
+// the user's personal workspace, and checks that the user belongs to it. It
+// must run after AuthMiddleware. Workspaces the user is not a member of are
+// reported as not found.
+func WorkspaceMiddleware(workspaceRepo repositories.WorkspaceRepository, logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get("user_id")
+		userID, ok := value.(uuid.UUID)
+		if !ok {
+			resp := response.UnauthorizedErrorWithAdditionalInfo(nil, "Invalid user ID in context")
+			c.AbortWithStatusJSON(resp.StatusCode, resp)
+			return
+		}
+
+		raw := c.Param("workspaceId")
+		if raw == "" {
+			raw = c.GetHeader(WorkspaceHeader)
+		}
+
+		var workspaceID uuid.UUID
+		if raw == "" {
+			workspace, err := workspaceRepo.GetPersonal(userID)
+			if err != nil {
+				logger.WithError(err).WithField("user_id", userID).Error("Failed to resolve personal workspace")
+				resp := response.RepositoryError("failed to resolve workspace")
+				c.AbortWithStatusJSON(resp.StatusCode, resp)
+				return
+			}
+			workspaceID = workspace.ID
+		} else {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				resp := response.BadRequestError("invalid workspace ID")
+				c.AbortWithStatusJSON(resp.StatusCode, resp)
+				return
+			}
+			workspaceID = id
+		}
+
+		role, err := workspaceRepo.GetRole(workspaceID, userID)
+		if err != nil {
+			logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to resolve workspace")
+			resp := response.RepositoryError("failed to resolve workspace")
+			c.AbortWithStatusJSON(resp.StatusCode, resp)
+			return
+		}
+		if role == "" {
+			resp := response.NotFoundError("workspace not found")
+			c.AbortWithStatusJSON(resp.StatusCode, resp)
+			return
+		}
+
+		c.Set("workspace_id", workspaceID)
+		c.Set("workspace_role", role)
+		c.Next()
+	}
+}
+
 // RateLimitMiddleware implements rate limiting using Redis
 func RateLimitMiddleware(redisClient *redis.Client, cfg *config.Config) gin.HandlerFunc {
+	return redisRateLimit(redisClient, "rate_limit:", cfg.RateLimitRequests, cfg.RateLimitWindow)
+}
+
+// SharedRateLimitMiddleware limits the public share link endpoint on its own
+// counter, on top of the global limit, so that guessing tokens stays slow.
+func SharedRateLimitMiddleware(redisClient *redis.Client, cfg *config.Config) gin.HandlerFunc {
+	return redisRateLimit(redisClient, "rate_limit:shared:", cfg.SharedRateLimitRequests, cfg.SharedRateLimitWindow)
+}
+
+// redisRateLimit allows requests per window seconds for each client IP,
+// counted in Redis under the given key prefix.
+func redisRateLimit(redisClient *redis.Client, prefix string, requests int, window int) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if redisClient == nil {
 			c.Next()
 			return
 		}
 
-		key := "rate_limit:" + c.ClientIP()
+		key := prefix + c.ClientIP()
 		ctx := context.Background()
 
 		current, err := redisClient.Get(ctx, key).Int()
@@ -154,11 +230,11 @@ func RateLimitMiddleware(redisClient *redis.Client, cfg *config.Config) gin.Hand
 			return
 		}
 
-		if current >= cfg.RateLimitRequests {
+		if current >= requests {
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"status":  false,
 				"error":   "rate_limit_exceeded",
-				"message": fmt.Sprintf("Rate limit exceeded. Maximum %d requests per %d seconds", cfg.RateLimitRequests, cfg.RateLimitWindow),
+				"message": fmt.Sprintf("Rate limit exceeded. Maximum %d requests per %d seconds", requests, window),
 			})
 			c.Abort()
 			return
@@ -167,7 +243,7 @@ func RateLimitMiddleware(redisClient *redis.Client, cfg *config.Config) gin.Hand
 		// Increment counter
 		pipe := redisClient.Pipeline()
 		pipe.Incr(ctx, key)
-		pipe.Expire(ctx, key, time.Duration(cfg.RateLimitWindow)*time.Second)
+		pipe.Expire(ctx, key, time.Duration(window)*time.Second)
 		_, err = pipe.Exec(ctx)
 		if err != nil {
 			c.Next()
@@ -175,14 +251,14 @@ func RateLimitMiddleware(redisClient *redis.Client, cfg *config.Config) gin.Hand
 		}
 
 		// Add rate limit headers
-		remaining := cfg.RateLimitRequests - (current + 1)
+		remaining := requests - (current + 1)
 		if remaining < 0 {
 			remaining = 0
 		}
 
-		c.Header("X-RateLimit-Limit", strconv.Itoa(cfg.RateLimitRequests))
+		c.Header("X-RateLimit-Limit", strconv.Itoa(requests))
 		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
-		c.Header("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Duration(cfg.RateLimitWindow)*time.Second).Unix(), 10))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Duration(window)*time.Second).Unix(), 10))
 
 		c.Next()
 	}
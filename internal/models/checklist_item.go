@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChecklistItem is a single step of a task's checklist.
+type ChecklistItem struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TaskID    uuid.UUID `json:"task_id" gorm:"type:uuid;not null;index"`
+	Text      string    `json:"text" gorm:"size:500;not null" validate:"required,max=500"`
+	Checked   bool      `json:"checked" gorm:"not null;default:false"`
+	Position  string    `json:"position" gorm:"type:varchar(255);not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+
+	Task Task `json:"-" gorm:"foreignKey:TaskID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (ChecklistItem) TableName() string {
+	return "task_checklist_items"
+}
+
+func (i *ChecklistItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Class is a group of students led by a teacher, who hands out assignments
+// to all of them at once.
+type Class struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TeacherID uuid.UUID `json:"teacher_id" gorm:"type:uuid;not null;index"`
+	Name      string    `json:"name" gorm:"size:100;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+}
+
+func (c *Class) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// ClassStudent is a user's enrolment in a class.
+type ClassStudent struct {
+	ClassID   uuid.UUID `json:"class_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// ClassAssignment is a task handed out to every student of a class. Each
+// student works on their own copy, a Task with AssignmentID set.
+type ClassAssignment struct {
+	ID          uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ClassID     uuid.UUID         `json:"class_id" gorm:"type:uuid;not null;index"`
+	Title       string            `json:"title" gorm:"size:255;not null"`
+	Description *string           `json:"description" gorm:"type:text"`
+	Priority    enum.TaskPriority `json:"priority" gorm:"type:varchar(20);not null;default:'MEDIUM'"`
+	DueAt       *time.Time        `json:"due_at" gorm:"type:timestamptz"`
+	CreatedAt   time.Time         `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time         `json:"updated_at" gorm:"not null"`
+}
+
+func (a *ClassAssignment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Project groups some of a user's tasks inside a workspace.
+type Project struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WorkspaceID uuid.UUID  `json:"workspace_id" gorm:"type:uuid;not null"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	Name        string     `json:"name" gorm:"size:100;not null"`
+	Color       string     `json:"color" gorm:"size:7;not null;default:'#808080'"`
+	ArchivedAt  *time.Time `json:"archived_at" gorm:"type:timestamptz"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"not null"`
+}
+
+func (p *Project) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
@@ -9,15 +9,37 @@ import (
 )
 
 type Task struct {
-	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Title       string          `json:"title" gorm:"size:255;not null" validate:"required,max=255"`
-	Description *string         `json:"description" gorm:"type:text"`
-	Status      enum.TaskStatus `json:"status" gorm:"type:varchar(20);not null;default:'TO_DO'" validate:"required,oneof=TO_DO IN_PROGRESS DONE"`
-	UserID      uuid.UUID       `json:"user_id" gorm:"type:uuid;not null"`
-	CreatedAt   time.Time       `json:"created_at" gorm:"not null"`
-	UpdatedAt   time.Time       `json:"updated_at" gorm:"not null"`
+	ID                 uuid.UUID            `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Title              string               `json:"title" gorm:"size:255;not null" validate:"required,max=255"`
+	Description        *string              `json:"description" gorm:"type:text"`
+	Status             enum.TaskStatus      `json:"status" gorm:"type:varchar(50);not null;default:'TO_DO'" validate:"required,max=50"`
+	Priority           enum.TaskPriority    `json:"priority" gorm:"type:varchar(20);not null;default:'MEDIUM'" validate:"required,oneof=LOW MEDIUM HIGH URGENT"`
+	UserID             uuid.UUID            `json:"user_id" gorm:"type:uuid;not null"`
+	WorkspaceID        uuid.UUID            `json:"workspace_id" gorm:"type:uuid;not null;index"`
+	ParentID           *uuid.UUID           `json:"parent_id" gorm:"type:uuid;index"`
+	ProjectID          *uuid.UUID           `json:"project_id" gorm:"type:uuid;index"`
+	AssignmentID       *uuid.UUID           `json:"assignment_id" gorm:"type:uuid;index"`
+	DueAt              *time.Time           `json:"due_at" gorm:"type:timestamptz"`
+	DueAllDay          bool                 `json:"due_all_day" gorm:"not null;default:false"`
+	StartedAt          *time.Time           `json:"started_at" gorm:"type:timestamptz"`
+	CompletedAt        *time.Time           `json:"completed_at" gorm:"type:timestamptz"`
+	ArchivedAt         *time.Time           `json:"archived_at" gorm:"type:timestamptz"`
+	Position           string               `json:"position" gorm:"type:varchar(255);not null"`
+	RecurrenceRule     *string              `json:"recurrence_rule" gorm:"type:varchar(255)"`
+	RecurrenceSeriesID *uuid.UUID           `json:"recurrence_series_id" gorm:"type:uuid"`
+	RecurrenceStart    *time.Time           `json:"recurrence_start" gorm:"type:timestamptz"`
+	RecurrenceIndex    int                  `json:"recurrence_index" gorm:"not null;default:0"`
+	RemindAt           *time.Time           `json:"remind_at" gorm:"type:timestamptz"`
+	ReminderChannel    enum.ReminderChannel `json:"reminder_channel" gorm:"type:varchar(20);not null;default:'EMAIL'"`
+	ReminderSentAt     *time.Time           `json:"reminder_sent_at" gorm:"type:timestamptz"`
+	SnoozedUntil       *time.Time           `json:"snoozed_until" gorm:"type:timestamptz"`
+	FocusDate          *time.Time           `json:"focus_date" gorm:"type:date"`
+	CreatedAt          time.Time            `json:"created_at" gorm:"not null"`
+	UpdatedAt          time.Time            `json:"updated_at" gorm:"not null"`
+	DeletedAt          gorm.DeletedAt       `json:"deleted_at" gorm:"index"`
 
-	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	User   User    `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Labels []Label `json:"labels" gorm:"many2many:task_labels"`
 }
 
 func (t *Task) BeforeCreate(tx *gorm.DB) error {
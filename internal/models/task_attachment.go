@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskAttachment describes a file attached to a task. The content itself is
+// kept in the attachment storage under StorageKey.
+type TaskAttachment struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TaskID         uuid.UUID `json:"task_id" gorm:"type:uuid;not null;index"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	FileName       string    `json:"file_name" gorm:"size:255;not null"`
+	ContentType    string    `json:"content_type" gorm:"size:100;not null"`
+	SizeBytes      int64     `json:"size_bytes" gorm:"not null"`
+	ChecksumSHA256 string    `json:"checksum_sha256" gorm:"column:checksum_sha256;type:char(64);not null"`
+	StorageKey     string    `json:"-" gorm:"size:500;not null;uniqueIndex"`
+	CreatedAt      time.Time `json:"created_at" gorm:"not null"`
+
+	Task Task `json:"-" gorm:"foreignKey:TaskID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (a *TaskAttachment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// AttachmentBlobDeletion queues a stored blob whose attachment row has been
+// deleted, so that the worker removes it from the storage.
+type AttachmentBlobDeletion struct {
+	StorageKey string    `json:"storage_key" gorm:"size:500;primaryKey"`
+	QueuedAt   time.Time `json:"queued_at" gorm:"not null"`
+}
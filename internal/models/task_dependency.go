@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskDependency records that TaskID is blocked by BlockedByID.
+type TaskDependency struct {
+	TaskID      uuid.UUID `json:"task_id" gorm:"type:uuid;primaryKey"`
+	BlockedByID uuid.UUID `json:"blocked_by_id" gorm:"type:uuid;primaryKey"`
+	CreatedAt   time.Time `json:"created_at" gorm:"not null"`
+
+	Task      Task `json:"-" gorm:"foreignKey:TaskID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	BlockedBy Task `json:"-" gorm:"foreignKey:BlockedByID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
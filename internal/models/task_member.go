@@ -0,0 +1,21 @@
+package models
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskMember is a user a task has been shared with.
+type TaskMember struct {
+	TaskID    uuid.UUID     `json:"task_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID     `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Role      enum.TaskRole `json:"role" gorm:"type:varchar(20);not null"`
+	InvitedBy uuid.UUID     `json:"invited_by" gorm:"type:uuid;not null"`
+	CreatedAt time.Time     `json:"created_at" gorm:"not null"`
+	UpdatedAt time.Time     `json:"updated_at" gorm:"not null"`
+
+	Task Task `json:"-" gorm:"foreignKey:TaskID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskStatusHistory records one status change of a task. FromStatus is nil
+// for the entry written when the task is created.
+type TaskStatusHistory struct {
+	ID         uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TaskID     uuid.UUID        `json:"task_id" gorm:"type:uuid;not null"`
+	UserID     uuid.UUID        `json:"user_id" gorm:"type:uuid;not null"`
+	FromStatus *enum.TaskStatus `json:"from_status" gorm:"type:varchar(50)"`
+	ToStatus   enum.TaskStatus  `json:"to_status" gorm:"type:varchar(50);not null"`
+	ChangedAt  time.Time        `json:"changed_at" gorm:"not null"`
+}
+
+func (TaskStatusHistory) TableName() string {
+	return "task_status_history"
+}
+
+func (h *TaskStatusHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskTemplate is a reusable task structure. Title and Description may
+// contain {{variable}} placeholders that are filled in when the template is
+// instantiated.
+type TaskTemplate struct {
+	ID          uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID      uuid.UUID         `json:"user_id" gorm:"type:uuid;not null;index"`
+	Name        string            `json:"name" gorm:"size:100;not null"`
+	Title       string            `json:"title" gorm:"size:255;not null"`
+	Description *string           `json:"description" gorm:"type:text"`
+	Status      enum.TaskStatus   `json:"status" gorm:"type:varchar(50);not null;default:'TO_DO'"`
+	Priority    enum.TaskPriority `json:"priority" gorm:"type:varchar(20);not null;default:'MEDIUM'"`
+	CreatedAt   time.Time         `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time         `json:"updated_at" gorm:"not null"`
+}
+
+func (TaskTemplate) TableName() string {
+	return "task_templates"
+}
+
+func (t *TaskTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"go-corenglish/internal/enum"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,20 +9,41 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Username  string    `json:"username" gorm:"size:100;uniqueIndex;not null" validate:"required,min=3,max=100"`
-	Email     string    `json:"email" gorm:"size:255;uniqueIndex;not null" validate:"required,email,max=255"`
-	Password  string    `json:"-" gorm:"size:255;not null" validate:"required,min=6"`
-	CreatedAt time.Time `json:"created_at" gorm:"not null"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+	ID              uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Username        string    `json:"username" gorm:"size:100;uniqueIndex;not null" validate:"required,min=3,max=100"`
+	Email           string    `json:"email" gorm:"size:255;uniqueIndex;not null" validate:"required,email,max=255"`
+	Password        string    `json:"-" gorm:"size:255;not null" validate:"required,min=6"`
+	AutoArchiveDays int       `json:"auto_archive_days" gorm:"not null;default:0"`
+	Timezone        string    `json:"timezone" gorm:"size:64;not null;default:'UTC'"`
+	CreatedAt       time.Time `json:"created_at" gorm:"not null"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"not null"`
 
 	// Relationship
 	Tasks []Task `json:"-" gorm:"foreignKey:UserID"`
 }
 
+// Location returns the user's time zone, falling back to UTC.
+func (u *User) Location() *time.Location {
+	if loc, err := time.LoadLocation(u.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
 func (u *User) BeforeCreate(tx *gorm.DB) error {
 	if u.ID == uuid.Nil {
 		u.ID = uuid.New()
 	}
 	return nil
 }
+
+// AfterCreate gives a new user their personal workspace in the same
+// transaction, so no user ever exists without one.
+func (u *User) AfterCreate(tx *gorm.DB) error {
+	return tx.Create(&Workspace{
+		Name:      PersonalWorkspaceName,
+		Personal:  true,
+		CreatedBy: u.ID,
+		Members:   []WorkspaceMember{{UserID: u.ID, Role: enum.WorkspaceOwner}},
+	}).Error
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WIPLimit caps how many tasks a user may have in a status at once.
+type WIPLimit struct {
+	UserID    uuid.UUID       `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Status    enum.TaskStatus `json:"status" gorm:"type:varchar(50);primaryKey"`
+	Limit     int             `json:"wip_limit" gorm:"column:wip_limit;not null"`
+	CreatedAt time.Time       `json:"created_at" gorm:"not null"`
+	UpdatedAt time.Time       `json:"updated_at" gorm:"not null"`
+}
+
+func (WIPLimit) TableName() string {
+	return "wip_limits"
+}
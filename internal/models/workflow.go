@@ -0,0 +1,25 @@
+package models
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+)
+
+// TaskStatusDefinition is a status a task can be in.
+type TaskStatusDefinition struct {
+	Name      enum.TaskStatus     `json:"name" gorm:"type:varchar(50);primaryKey"`
+	Category  enum.StatusCategory `json:"category" gorm:"type:varchar(20);not null"`
+	Position  int                 `json:"position" gorm:"not null;default:0"`
+	CreatedAt time.Time           `json:"created_at" gorm:"not null"`
+}
+
+func (TaskStatusDefinition) TableName() string {
+	return "task_statuses"
+}
+
+// TaskStatusTransition allows a task to move from FromStatus to ToStatus.
+type TaskStatusTransition struct {
+	FromStatus     enum.TaskStatus `json:"from_status" gorm:"type:varchar(50);primaryKey"`
+	ToStatus       enum.TaskStatus `json:"to_status" gorm:"type:varchar(50);primaryKey"`
+	RequiresReopen bool            `json:"requires_reopen" gorm:"not null;default:false"`
+}
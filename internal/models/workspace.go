@@ -0,0 +1,45 @@
+package models
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PersonalWorkspaceName is the name given to the workspace every user gets
+// when they register.
+const PersonalWorkspaceName = "Personal"
+
+// Workspace groups tasks and the users who may work on them. A personal
+// workspace belongs to a single user and cannot be shared or deleted.
+type Workspace struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name      string    `json:"name" gorm:"size:100;not null"`
+	Personal  bool      `json:"personal" gorm:"not null;default:false"`
+	CreatedBy uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+
+	Members []WorkspaceMember `json:"-" gorm:"foreignKey:WorkspaceID"`
+}
+
+func (w *Workspace) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// WorkspaceMember is a user's membership of a workspace.
+type WorkspaceMember struct {
+	WorkspaceID uuid.UUID          `json:"workspace_id" gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID          `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Role        enum.WorkspaceRole `json:"role" gorm:"type:varchar(20);not null"`
+	CreatedAt   time.Time          `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time          `json:"updated_at" gorm:"not null"`
+
+	Workspace Workspace `json:"-" gorm:"foreignKey:WorkspaceID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	User      User      `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,20 @@
+package params
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentResponse describes an attachment. DownloadURL is a signed link
+// that works without authentication until DownloadURLExpiresAt.
+type AttachmentResponse struct {
+	ID                   uuid.UUID `json:"id"`
+	FileName             string    `json:"file_name"`
+	ContentType          string    `json:"content_type"`
+	SizeBytes            int64     `json:"size_bytes"`
+	ChecksumSHA256       string    `json:"checksum_sha256"`
+	DownloadURL          string    `json:"download_url"`
+	DownloadURLExpiresAt time.Time `json:"download_url_expires_at"`
+	CreatedAt            time.Time `json:"created_at"`
+}
@@ -0,0 +1,5 @@
+package params
+
+type SetWIPLimitRequest struct {
+	WIPLimit int `json:"wip_limit" validate:"required,min=1,max=1000"`
+}
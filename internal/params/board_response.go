@@ -0,0 +1,21 @@
+package params
+
+import "go-corenglish/internal/enum"
+
+// BoardColumnResponse is one status column of the board with its own page
+// of tasks. WIPLimit is nil when the column is unlimited.
+type BoardColumnResponse struct {
+	Status   enum.TaskStatus     `json:"status"`
+	Category enum.StatusCategory `json:"category"`
+	WIPLimit *int                `json:"wip_limit"`
+	TasksResponse
+}
+
+type BoardResponse struct {
+	Columns []BoardColumnResponse `json:"columns"`
+}
+
+type WIPLimitResponse struct {
+	Status   enum.TaskStatus `json:"status"`
+	WIPLimit int             `json:"wip_limit"`
+}
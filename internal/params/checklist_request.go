@@ -0,0 +1,20 @@
+package params
+
+import "github.com/google/uuid"
+
+type CreateChecklistItemRequest struct {
+	Text    string `json:"text" validate:"required,max=500"`
+	Checked bool   `json:"checked"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Text    *string `json:"text" validate:"omitempty,min=1,max=500"`
+	Checked *bool   `json:"checked"`
+}
+
+// MoveChecklistItemRequest places an item directly before or after another
+// item of the same checklist.
+type MoveChecklistItemRequest struct {
+	BeforeID *uuid.UUID `json:"before_id"`
+	AfterID  *uuid.UUID `json:"after_id"`
+}
@@ -0,0 +1,28 @@
+package params
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChecklistItemResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Text      string    `json:"text"`
+	Checked   bool      `json:"checked"`
+	Position  string    `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ChecklistResponse struct {
+	Items   []ChecklistItemResponse `json:"items"`
+	Checked int64                   `json:"checked"`
+	Total   int64                   `json:"total"`
+}
+
+// ChecklistSummary is the checked / total item count of a task's checklist.
+type ChecklistSummary struct {
+	Checked int64 `json:"checked"`
+	Total   int64 `json:"total"`
+}
@@ -0,0 +1,25 @@
+package params
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+)
+
+type CreateClassRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// AddClassStudentRequest enrols the user found by either username or email.
+type AddClassStudentRequest struct {
+	Username string `json:"username" validate:"omitempty,max=100"`
+	Email    string `json:"email" validate:"omitempty,email,max=255"`
+}
+
+// CreateAssignmentRequest describes the task every student of the class
+// receives.
+type CreateAssignmentRequest struct {
+	Title       string             `json:"title" validate:"required,max=255"`
+	Description *string            `json:"description"`
+	Priority    *enum.TaskPriority `json:"priority" validate:"omitempty,oneof=LOW MEDIUM HIGH URGENT"`
+	DueAt       *time.Time         `json:"due_at"`
+}
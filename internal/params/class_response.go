@@ -0,0 +1,64 @@
+package params
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClassResponse is a class as seen by its teacher or one of its students;
+// Role tells which.
+type ClassResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	TeacherID uuid.UUID      `json:"teacher_id"`
+	Role      enum.ClassRole `json:"role"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type ClassStudentResponse struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ClassStudentsResponse struct {
+	ClassID  uuid.UUID              `json:"class_id"`
+	Students []ClassStudentResponse `json:"students"`
+}
+
+type AssignmentResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	ClassID     uuid.UUID         `json:"class_id"`
+	Title       string            `json:"title"`
+	Description *string           `json:"description"`
+	Priority    enum.TaskPriority `json:"priority"`
+	DueAt       *time.Time        `json:"due_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// AssignmentProgressResponse is the teacher's view of an assignment: where
+// every student stands with their task.
+type AssignmentProgressResponse struct {
+	Assignment AssignmentResponse        `json:"assignment"`
+	Total      int                       `json:"total"`
+	Completed  int                       `json:"completed"`
+	Students   []AssignmentStudentStatus `json:"students"`
+}
+
+// AssignmentStudentStatus is one student's progress on an assignment.
+// Students enrolled after the assignment was handed out have no task, and
+// students who left the class keep theirs with Enrolled false.
+type AssignmentStudentStatus struct {
+	UserID      uuid.UUID        `json:"user_id"`
+	Username    string           `json:"username"`
+	Enrolled    bool             `json:"enrolled"`
+	TaskID      *uuid.UUID       `json:"task_id"`
+	Status      *enum.TaskStatus `json:"status"`
+	Completed   bool             `json:"completed"`
+	CompletedAt *time.Time       `json:"completed_at"`
+	Deleted     bool             `json:"deleted"`
+}
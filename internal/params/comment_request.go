@@ -0,0 +1,9 @@
+package params
+
+type CreateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
@@ -0,0 +1,29 @@
+package params
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CommentAuthorResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+}
+
+type CommentResponse struct {
+	ID        uuid.UUID             `json:"id"`
+	Body      string                `json:"body"`
+	Author    CommentAuthorResponse `json:"author"`
+	Edited    bool                  `json:"edited"`
+	EditedAt  *time.Time            `json:"edited_at"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// CommentsResponse is a page of comments. NextCursor is nil on the last page.
+type CommentsResponse struct {
+	Comments   []CommentResponse `json:"comments"`
+	NextCursor *string           `json:"next_cursor"`
+	Limit      int               `json:"limit"`
+}
@@ -0,0 +1,7 @@
+package params
+
+import "github.com/google/uuid"
+
+type AddDependencyRequest struct {
+	BlockedByID uuid.UUID `json:"blocked_by_id" validate:"required"`
+}
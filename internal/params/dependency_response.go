@@ -0,0 +1,19 @@
+package params
+
+import (
+	"go-corenglish/internal/enum"
+
+	"github.com/google/uuid"
+)
+
+// TaskSummaryResponse is the short form of a task used when listing related tasks.
+type TaskSummaryResponse struct {
+	ID     uuid.UUID       `json:"id"`
+	Title  string          `json:"title"`
+	Status enum.TaskStatus `json:"status"`
+}
+
+type DependenciesResponse struct {
+	TaskID    uuid.UUID             `json:"task_id"`
+	BlockedBy []TaskSummaryResponse `json:"blocked_by"`
+}
@@ -0,0 +1,11 @@
+package params
+
+type CreateLabelRequest struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Color string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+type UpdateLabelRequest struct {
+	Name  *string `json:"name" validate:"omitempty,max=50"`
+	Color *string `json:"color" validate:"omitempty,hexcolor"`
+}
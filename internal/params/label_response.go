@@ -0,0 +1,15 @@
+package params
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LabelResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,11 @@
+package params
+
+import "go-corenglish/internal/enum"
+
+// InviteMemberRequest shares a task with the user found by either username
+// or email.
+type InviteMemberRequest struct {
+	Username string        `json:"username" validate:"omitempty,max=100"`
+	Email    string        `json:"email" validate:"omitempty,email,max=255"`
+	Role     enum.TaskRole `json:"role" validate:"required"`
+}
@@ -0,0 +1,21 @@
+package params
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskMemberResponse struct {
+	UserID    uuid.UUID     `json:"user_id"`
+	Username  string        `json:"username"`
+	Role      enum.TaskRole `json:"role"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// TaskMembersResponse lists everyone with access to a task, the owner first.
+type TaskMembersResponse struct {
+	TaskID  uuid.UUID            `json:"task_id"`
+	Members []TaskMemberResponse `json:"members"`
+}
@@ -0,0 +1,13 @@
+package params
+
+type CreateProjectRequest struct {
+	Name  string `json:"name" validate:"required,max=100"`
+	Color string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+// UpdateProjectRequest renames, recolours, archives or unarchives a project.
+type UpdateProjectRequest struct {
+	Name     *string `json:"name" validate:"omitempty,max=100"`
+	Color    *string `json:"color" validate:"omitempty,hexcolor"`
+	Archived *bool   `json:"archived"`
+}
@@ -0,0 +1,22 @@
+package params
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectResponse is a project with the number of its tasks in each status.
+// Archived tasks are not counted.
+type ProjectResponse struct {
+	ID          uuid.UUID                 `json:"id"`
+	WorkspaceID uuid.UUID                 `json:"workspace_id"`
+	Name        string                    `json:"name"`
+	Color       string                    `json:"color"`
+	Archived    bool                      `json:"archived"`
+	ArchivedAt  *time.Time                `json:"archived_at"`
+	TaskCounts  map[enum.TaskStatus]int64 `json:"task_counts"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+}
@@ -0,0 +1,9 @@
+package params
+
+import "time"
+
+// CreateShareLinkRequest creates a share link. Without ExpiresAt the link
+// works until it is revoked.
+type CreateShareLinkRequest struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,18 @@
+package params
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareLinkResponse describes a share link. Token and URL are only returned
+// when the link is created, since only a hash of the token is kept.
+type ShareLinkResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Token     string     `json:"token,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Expired   bool       `json:"expired"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,40 @@
+package params
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DurationStats summarises a set of durations in hours. Values are nil when
+// there is no data for the period.
+type DurationStats struct {
+	AverageHours *float64 `json:"average_hours"`
+	MedianHours  *float64 `json:"median_hours"`
+	Samples      int64    `json:"samples"`
+}
+
+type WeeklyThroughput struct {
+	WeekStart time.Time `json:"week_start"`
+	Completed int64     `json:"completed"`
+}
+
+// TaskStatsResponse describes the tasks completed between From and To.
+// Lead time runs from creation to completion, cycle time from the first
+// start to completion.
+type TaskStatsResponse struct {
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Completed  int64              `json:"completed"`
+	LeadTime   DurationStats      `json:"lead_time"`
+	CycleTime  DurationStats      `json:"cycle_time"`
+	Throughput []WeeklyThroughput `json:"throughput"`
+}
+
+type TaskStatusHistoryResponse struct {
+	ID         uuid.UUID        `json:"id"`
+	FromStatus *enum.TaskStatus `json:"from_status"`
+	ToStatus   enum.TaskStatus  `json:"to_status"`
+	ChangedAt  time.Time        `json:"changed_at"`
+}
@@ -1,14 +1,106 @@
 package params
 
-import "go-corenglish/internal/enum"
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type CreateTaskRequest struct {
-	Title       string  `json:"title" validate:"required,max=255"`
-	Description *string `json:"description"`
+	Title           string                `json:"title" validate:"required,max=255"`
+	Description     *string               `json:"description"`
+	Priority        *enum.TaskPriority    `json:"priority" validate:"omitempty,oneof=LOW MEDIUM HIGH URGENT"`
+	DueAt           *time.Time            `json:"due_at"`
+	DueAllDay       bool                  `json:"due_all_day"`
+	ParentID        *uuid.UUID            `json:"parent_id"`
+	ProjectID       *uuid.UUID            `json:"project_id"`
+	LabelIDs        []uuid.UUID           `json:"label_ids" validate:"omitempty,max=20,unique"`
+	RecurrenceRule  *string               `json:"recurrence_rule" validate:"omitempty,max=255"`
+	RemindAt        *time.Time            `json:"remind_at"`
+	ReminderChannel *enum.ReminderChannel `json:"reminder_channel" validate:"omitempty,oneof=EMAIL WEBHOOK"`
 }
 
 type UpdateTaskRequest struct {
-	Title       *string          `json:"title" validate:"omitempty,max=255"`
-	Description *string          `json:"description"`
-	Status      *enum.TaskStatus `json:"status" validate:"omitempty,oneof=TO_DO IN_PROGRESS DONE"`
+	Title           *string               `json:"title" validate:"omitempty,max=255"`
+	Description     *string               `json:"description"`
+	Status          *enum.TaskStatus      `json:"status" validate:"omitempty,max=50"`
+	Priority        *enum.TaskPriority    `json:"priority" validate:"omitempty,oneof=LOW MEDIUM HIGH URGENT"`
+	DueAt           *time.Time            `json:"due_at"`
+	ClearDueAt      bool                  `json:"clear_due_at"`
+	DueAllDay       *bool                 `json:"due_all_day"`
+	ParentID        *uuid.UUID            `json:"parent_id"`
+	ClearParent     bool                  `json:"clear_parent"`
+	ProjectID       *uuid.UUID            `json:"project_id"`
+	ClearProject    bool                  `json:"clear_project"`
+	LabelIDs        *[]uuid.UUID          `json:"label_ids" validate:"omitempty,max=20,unique"`
+	RecurrenceRule  *string               `json:"recurrence_rule" validate:"omitempty,max=255"`
+	ClearRecurrence bool                  `json:"clear_recurrence"`
+	RemindAt        *time.Time            `json:"remind_at"`
+	ClearReminder   bool                  `json:"clear_reminder"`
+	ReminderChannel *enum.ReminderChannel `json:"reminder_channel" validate:"omitempty,oneof=EMAIL WEBHOOK"`
+}
+
+// QuickAddTaskRequest creates a task from a single line of text such as
+// "Review essay tomorrow 5pm #writing !high".
+type QuickAddTaskRequest struct {
+	Text string `json:"text" validate:"required,max=500"`
+}
+
+// ReopenTaskRequest moves a finished task back into the workflow. Status
+// defaults to TO_DO.
+type ReopenTaskRequest struct {
+	Status *enum.TaskStatus `json:"status" validate:"omitempty,max=50"`
+}
+
+// SnoozeTaskRequest hides a task from the task lists until the given time.
+type SnoozeTaskRequest struct {
+	Until time.Time `json:"until" validate:"required"`
+}
+
+// MoveTaskRequest places a task directly before or after another task,
+// optionally moving it into another status column on the way.
+type MoveTaskRequest struct {
+	BeforeID *uuid.UUID       `json:"before_id"`
+	AfterID  *uuid.UUID       `json:"after_id"`
+	Status   *enum.TaskStatus `json:"status" validate:"omitempty,max=50"`
+}
+
+// CloneTaskRequest controls how tasks are copied. TitleSuffix is appended to
+// the title of every copied task (not its subtasks) and defaults to the
+// server's CLONE_TITLE_SUFFIX; IncludeSubtasks defaults to true.
+type CloneTaskRequest struct {
+	TitleSuffix     *string `json:"title_suffix" validate:"omitempty,max=50"`
+	IncludeSubtasks *bool   `json:"include_subtasks"`
+}
+
+const (
+	LabelMatchAny = "any"
+	LabelMatchAll = "all"
+)
+
+// SortField is a single key of a sort=priority,-due_at style query parameter.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// TaskFilter holds the query options accepted by GET /api/v1/tasks.
+type TaskFilter struct {
+	WorkspaceID     uuid.UUID  // set from the request's workspace, never from the query
+	ProjectID       *uuid.UUID // set from the path of GET /api/v1/projects/:id/tasks
+	Status          string
+	Priority        string
+	Labels          []string // label names
+	LabelMatch      string   // "any" or "all"
+	DueBefore       *time.Time
+	DueAfter        *time.Time
+	Overdue         bool
+	IncludeArchived bool
+	IncludeSnoozed  bool
+	FocusDate       *time.Time // only tasks pinned to this day's "My Day" list
+	Shared          *bool      // nil for all tasks, true for shared with the user, false for owned
+	Sort            []SortField
+	Page            int
+	Limit           int
 }
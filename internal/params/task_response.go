@@ -8,12 +8,57 @@ import (
 )
 
 type TaskResponse struct {
-	ID          uuid.UUID       `json:"id"`
-	Title       string          `json:"title"`
-	Description *string         `json:"description"`
-	Status      enum.TaskStatus `json:"status"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID           uuid.UUID         `json:"id"`
+	WorkspaceID  uuid.UUID         `json:"workspace_id"`
+	Title        string            `json:"title"`
+	Description  *string           `json:"description"`
+	Status       enum.TaskStatus   `json:"status"`
+	Priority     enum.TaskPriority `json:"priority"`
+	DueAt        *time.Time        `json:"due_at"`
+	DueAllDay    bool              `json:"due_all_day"`
+	Overdue      bool              `json:"overdue"`
+	StartedAt    *time.Time        `json:"started_at"`
+	CompletedAt  *time.Time        `json:"completed_at"`
+	Archived     bool              `json:"archived"`
+	ArchivedAt   *time.Time        `json:"archived_at"`
+	Position     string            `json:"position"`
+	Labels       []LabelResponse   `json:"labels"`
+	ParentID     *uuid.UUID        `json:"parent_id"`
+	ProjectID    *uuid.UUID        `json:"project_id"`
+	AssignmentID *uuid.UUID        `json:"assignment_id"`
+	Progress     *TaskProgress     `json:"progress,omitempty"`
+	Checklist    *ChecklistSummary `json:"checklist,omitempty"`
+	CommentCount int64             `json:"comment_count"`
+	Recurrence   *TaskRecurrence   `json:"recurrence,omitempty"`
+	Reminder     *TaskReminder     `json:"reminder,omitempty"`
+	SnoozedUntil *time.Time        `json:"snoozed_until"`
+	FocusDate    *string           `json:"focus_date"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	DeletedAt    *time.Time        `json:"deleted_at,omitempty"`
+}
+
+// TaskProgress is the completion roll-up of a task's direct subtasks.
+type TaskProgress struct {
+	Done    int64   `json:"done"`
+	Total   int64   `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// TaskRecurrence describes the series a recurring task belongs to. Index
+// numbers the occurrences of the series from 0.
+type TaskRecurrence struct {
+	Rule     string    `json:"rule"`
+	SeriesID uuid.UUID `json:"series_id"`
+	Index    int       `json:"index"`
+}
+
+// TaskReminder is the reminder of a task. SentAt is set once the reminder
+// has been delivered.
+type TaskReminder struct {
+	RemindAt time.Time            `json:"remind_at"`
+	Channel  enum.ReminderChannel `json:"channel"`
+	SentAt   *time.Time           `json:"sent_at"`
 }
 
 type TasksResponse struct {
@@ -23,3 +68,11 @@ type TasksResponse struct {
 	Limit      int            `json:"limit"`
 	TotalPages int            `json:"total_pages"`
 }
+
+// MyDayResponse is the "My Day" list of the current day in the user's time
+// zone.
+type MyDayResponse struct {
+	Date     string `json:"date"`
+	Timezone string `json:"timezone"`
+	TasksResponse
+}
@@ -0,0 +1,39 @@
+package params
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateTemplateRequest struct {
+	Name        string             `json:"name" validate:"required,max=100"`
+	Title       string             `json:"title" validate:"required,max=255"`
+	Description *string            `json:"description"`
+	Status      *enum.TaskStatus   `json:"status" validate:"omitempty,max=50"`
+	Priority    *enum.TaskPriority `json:"priority" validate:"omitempty,oneof=LOW MEDIUM HIGH URGENT"`
+}
+
+type UpdateTemplateRequest struct {
+	Name             *string            `json:"name" validate:"omitempty,max=100"`
+	Title            *string            `json:"title" validate:"omitempty,max=255"`
+	Description      *string            `json:"description"`
+	ClearDescription bool               `json:"clear_description"`
+	Status           *enum.TaskStatus   `json:"status" validate:"omitempty,max=50"`
+	Priority         *enum.TaskPriority `json:"priority" validate:"omitempty,oneof=LOW MEDIUM HIGH URGENT"`
+}
+
+// InstantiateTemplateRequest creates one task per instance. Without any
+// instance a single task is created from the template as is.
+type InstantiateTemplateRequest struct {
+	Instances []TemplateInstance `json:"instances" validate:"omitempty,max=100,dive"`
+}
+
+// TemplateInstance holds the values of the template's {{variable}}
+// placeholders for one task, e.g. {"week": "3"}.
+type TemplateInstance struct {
+	Variables map[string]string `json:"variables"`
+	DueAt     *time.Time        `json:"due_at"`
+	LabelIDs  []uuid.UUID       `json:"label_ids" validate:"omitempty,max=20,unique"`
+}
@@ -0,0 +1,20 @@
+package params
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TemplateResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	Name        string            `json:"name"`
+	Title       string            `json:"title"`
+	Description *string           `json:"description"`
+	Status      enum.TaskStatus   `json:"status"`
+	Priority    enum.TaskPriority `json:"priority"`
+	Variables   []string          `json:"variables"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
@@ -10,3 +10,8 @@ type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
 }
+
+type UpdateUserSettingsRequest struct {
+	AutoArchiveDays *int    `json:"auto_archive_days" validate:"omitempty,min=0,max=3650"`
+	Timezone        *string `json:"timezone" validate:"omitempty,max=64"`
+}
@@ -10,3 +10,15 @@ type AuthResponse struct {
 		Email    string    `json:"email"`
 	} `json:"user"`
 }
+
+type UserSettingsResponse struct {
+	AutoArchiveDays int    `json:"auto_archive_days"`
+	Timezone        string `json:"timezone"`
+}
+
+type UserProfileResponse struct {
+	ID       uuid.UUID            `json:"id"`
+	Username string               `json:"username"`
+	Email    string               `json:"email"`
+	Settings UserSettingsResponse `json:"settings"`
+}
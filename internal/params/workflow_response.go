@@ -0,0 +1,26 @@
+package params
+
+import "go-corenglish/internal/enum"
+
+type WorkflowStatusResponse struct {
+	Name     enum.TaskStatus     `json:"name"`
+	Category enum.StatusCategory `json:"category"`
+	Position int                 `json:"position"`
+}
+
+type WorkflowTransitionResponse struct {
+	From           enum.TaskStatus `json:"from"`
+	To             enum.TaskStatus `json:"to"`
+	RequiresReopen bool            `json:"requires_reopen"`
+}
+
+type WorkflowResponse struct {
+	Statuses    []WorkflowStatusResponse     `json:"statuses"`
+	Transitions []WorkflowTransitionResponse `json:"transitions"`
+}
+
+// AllowedTransition is a next status reachable from a task's current status.
+type AllowedTransition struct {
+	Status         enum.TaskStatus `json:"status"`
+	RequiresReopen bool            `json:"requires_reopen"`
+}
@@ -0,0 +1,19 @@
+package params
+
+import "go-corenglish/internal/enum"
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type UpdateWorkspaceRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// AddWorkspaceMemberRequest adds the user found by either username or email
+// to a workspace, or changes the role of an existing member.
+type AddWorkspaceMemberRequest struct {
+	Username string             `json:"username" validate:"omitempty,max=100"`
+	Email    string             `json:"email" validate:"omitempty,email,max=255"`
+	Role     enum.WorkspaceRole `json:"role" validate:"required"`
+}
@@ -0,0 +1,31 @@
+package params
+
+import (
+	"go-corenglish/internal/enum"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceResponse is a workspace as seen by one of its members; Role is
+// that member's role.
+type WorkspaceResponse struct {
+	ID        uuid.UUID          `json:"id"`
+	Name      string             `json:"name"`
+	Personal  bool               `json:"personal"`
+	Role      enum.WorkspaceRole `json:"role"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type WorkspaceMemberResponse struct {
+	UserID    uuid.UUID          `json:"user_id"`
+	Username  string             `json:"username"`
+	Role      enum.WorkspaceRole `json:"role"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type WorkspaceMembersResponse struct {
+	WorkspaceID uuid.UUID                 `json:"workspace_id"`
+	Members     []WorkspaceMemberResponse `json:"members"`
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Create(attachment *models.TaskAttachment) error {
+	args := m.Called(attachment)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) GetByID(id uuid.UUID, taskID uuid.UUID) (*models.TaskAttachment, error) {
+	args := m.Called(id, taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TaskAttachment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetDownloadable(id uuid.UUID) (*models.TaskAttachment, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TaskAttachment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetByTaskID(taskID uuid.UUID) ([]models.TaskAttachment, error) {
+	args := m.Called(taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TaskAttachment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Delete(id uuid.UUID, taskID uuid.UUID) error {
+	args := m.Called(id, taskID)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) GetPendingBlobDeletions(limit int) ([]string, error) {
+	args := m.Called(limit)
+	if args.Get(0) != nil {
+		return args.Get(0).([]string), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAttachmentRepository) RemoveBlobDeletion(storageKey string) error {
+	args := m.Called(storageKey)
+	return args.Error(0)
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAttachmentNotFound is returned when an attachment does not exist on the
+// task, or its task is in the trash.
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+type AttachmentRepository interface {
+	Create(attachment *models.TaskAttachment) error
+	GetByID(id uuid.UUID, taskID uuid.UUID) (*models.TaskAttachment, error)
+	GetDownloadable(id uuid.UUID) (*models.TaskAttachment, error)
+	GetByTaskID(taskID uuid.UUID) ([]models.TaskAttachment, error)
+	Delete(id uuid.UUID, taskID uuid.UUID) error
+	GetPendingBlobDeletions(limit int) ([]string, error)
+	RemoveBlobDeletion(storageKey string) error
+}
+
+type attachmentRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewAttachmentRepository(db *gorm.DB, logger *logrus.Logger) AttachmentRepository {
+	return &attachmentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *attachmentRepository) Create(attachment *models.TaskAttachment) error {
+	if err := r.db.Omit(clause.Associations).Create(attachment).Error; err != nil {
+		r.logger.WithError(err).WithField("task_id", attachment.TaskID).Error("Failed to create attachment")
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"attachment_id": attachment.ID,
+		"task_id":       attachment.TaskID,
+	}).Info("Attachment created successfully")
+	return nil
+}
+
+func (r *attachmentRepository) GetByID(id uuid.UUID, taskID uuid.UUID) (*models.TaskAttachment, error) {
+	var attachment models.TaskAttachment
+	err := r.db.Where("id = ? AND task_id = ?", id, taskID).First(&attachment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("attachment_id", id).Warn("Attachment not found")
+			return nil, ErrAttachmentNotFound
+		}
+		r.logger.WithError(err).WithField("attachment_id", id).Error("Failed to get attachment")
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+// GetDownloadable returns an attachment whose task is not in the trash.
+func (r *attachmentRepository) GetDownloadable(id uuid.UUID) (*models.TaskAttachment, error) {
+	var attachment models.TaskAttachment
+	err := r.db.Joins("JOIN tasks ON tasks.id = task_attachments.task_id AND tasks.deleted_at IS NULL").
+		Where("task_attachments.id = ?", id).
+		First(&attachment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("attachment_id", id).Warn("Attachment not found for download")
+			return nil, ErrAttachmentNotFound
+		}
+		r.logger.WithError(err).WithField("attachment_id", id).Error("Failed to get attachment for download")
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) GetByTaskID(taskID uuid.UUID) ([]models.TaskAttachment, error) {
+	var attachments []models.TaskAttachment
+	err := r.db.Where("task_id = ?", taskID).Order("created_at ASC").Find(&attachments).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get attachments")
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// Delete removes an attachment row. Its blob is queued for deletion by a
+// database trigger.
+func (r *attachmentRepository) Delete(id uuid.UUID, taskID uuid.UUID) error {
+	result := r.db.Where("id = ? AND task_id = ?", id, taskID).Delete(&models.TaskAttachment{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("attachment_id", id).Error("Failed to delete attachment")
+		return fmt.Errorf("failed to delete attachment: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("attachment_id", id).Warn("Attachment not found for deletion")
+		return ErrAttachmentNotFound
+	}
+
+	r.logger.WithField("attachment_id", id).Info("Attachment deleted successfully")
+	return nil
+}
+
+func (r *attachmentRepository) GetPendingBlobDeletions(limit int) ([]string, error) {
+	var keys []string
+	err := r.db.Model(&models.AttachmentBlobDeletion{}).
+		Order("queued_at ASC").
+		Limit(limit).
+		Pluck("storage_key", &keys).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get pending blob deletions")
+		return nil, fmt.Errorf("failed to get pending blob deletions: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *attachmentRepository) RemoveBlobDeletion(storageKey string) error {
+	if err := r.db.Where("storage_key = ?", storageKey).Delete(&models.AttachmentBlobDeletion{}).Error; err != nil {
+		r.logger.WithError(err).WithField("storage_key", storageKey).Error("Failed to remove blob deletion")
+		return fmt.Errorf("failed to remove blob deletion: %w", err)
+	}
+
+	return nil
+}
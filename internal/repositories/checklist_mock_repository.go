@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockChecklistRepository struct {
+	mock.Mock
+}
+
+func (m *MockChecklistRepository) Create(item *models.ChecklistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockChecklistRepository) GetByID(id uuid.UUID, taskID uuid.UUID) (*models.ChecklistItem, error) {
+	args := m.Called(id, taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.ChecklistItem), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockChecklistRepository) GetByTaskID(taskID uuid.UUID) ([]models.ChecklistItem, error) {
+	args := m.Called(taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.ChecklistItem), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockChecklistRepository) Update(item *models.ChecklistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockChecklistRepository) Delete(id uuid.UUID, taskID uuid.UUID) error {
+	args := m.Called(id, taskID)
+	return args.Error(0)
+}
+
+func (m *MockChecklistRepository) GetLastPosition(taskID uuid.UUID) (string, error) {
+	args := m.Called(taskID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockChecklistRepository) GetAdjacentPosition(taskID uuid.UUID, position string, excludeID uuid.UUID, before bool) (string, error) {
+	args := m.Called(taskID, position, excludeID, before)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockChecklistRepository) GetCounts(taskIDs []uuid.UUID) (map[uuid.UUID]ChecklistCount, error) {
+	args := m.Called(taskIDs)
+	if args.Get(0) != nil {
+		return args.Get(0).(map[uuid.UUID]ChecklistCount), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
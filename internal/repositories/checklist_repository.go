@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChecklistCount counts the checklist items of a task.
+type ChecklistCount struct {
+	TaskID  uuid.UUID
+	Total   int64
+	Checked int64
+}
+
+type ChecklistRepository interface {
+	Create(item *models.ChecklistItem) error
+	GetByID(id uuid.UUID, taskID uuid.UUID) (*models.ChecklistItem, error)
+	GetByTaskID(taskID uuid.UUID) ([]models.ChecklistItem, error)
+	Update(item *models.ChecklistItem) error
+	Delete(id uuid.UUID, taskID uuid.UUID) error
+	GetLastPosition(taskID uuid.UUID) (string, error)
+	GetAdjacentPosition(taskID uuid.UUID, position string, excludeID uuid.UUID, before bool) (string, error)
+	GetCounts(taskIDs []uuid.UUID) (map[uuid.UUID]ChecklistCount, error)
+}
+
+type checklistRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewChecklistRepository(db *gorm.DB, logger *logrus.Logger) ChecklistRepository {
+	return &checklistRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *checklistRepository) Create(item *models.ChecklistItem) error {
+	if err := r.db.Omit(clause.Associations).Create(item).Error; err != nil {
+		r.logger.WithError(err).WithField("task_id", item.TaskID).Error("Failed to create checklist item")
+		return fmt.Errorf("failed to create checklist item: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"item_id": item.ID,
+		"task_id": item.TaskID,
+	}).Info("Checklist item created successfully")
+	return nil
+}
+
+func (r *checklistRepository) GetByID(id uuid.UUID, taskID uuid.UUID) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	err := r.db.Where("id = ? AND task_id = ?", id, taskID).First(&item).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("item_id", id).Warn("Checklist item not found")
+			return nil, fmt.Errorf("checklist item not found")
+		}
+		r.logger.WithError(err).WithField("item_id", id).Error("Failed to get checklist item")
+		return nil, fmt.Errorf("failed to get checklist item: %w", err)
+	}
+
+	return &item, nil
+}
+
+func (r *checklistRepository) GetByTaskID(taskID uuid.UUID) ([]models.ChecklistItem, error) {
+	var items []models.ChecklistItem
+	err := r.db.Where("task_id = ?", taskID).
+		Order("position ASC").
+		Order("created_at ASC").
+		Find(&items).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get checklist items")
+		return nil, fmt.Errorf("failed to get checklist items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *checklistRepository) Update(item *models.ChecklistItem) error {
+	result := r.db.Model(item).Select("text", "checked", "position").
+		Where("id = ? AND task_id = ?", item.ID, item.TaskID).Updates(item)
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("item_id", item.ID).Error("Failed to update checklist item")
+		return fmt.Errorf("failed to update checklist item: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("item_id", item.ID).Warn("Checklist item not found for update")
+		return fmt.Errorf("checklist item not found")
+	}
+
+	r.logger.WithField("item_id", item.ID).Info("Checklist item updated successfully")
+	return nil
+}
+
+func (r *checklistRepository) Delete(id uuid.UUID, taskID uuid.UUID) error {
+	result := r.db.Where("id = ? AND task_id = ?", id, taskID).Delete(&models.ChecklistItem{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("item_id", id).Error("Failed to delete checklist item")
+		return fmt.Errorf("failed to delete checklist item: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("item_id", id).Warn("Checklist item not found for deletion")
+		return fmt.Errorf("checklist item not found")
+	}
+
+	r.logger.WithField("item_id", id).Info("Checklist item deleted successfully")
+	return nil
+}
+
+// GetLastPosition returns the highest item position of a task, or an empty
+// string when the checklist is empty.
+func (r *checklistRepository) GetLastPosition(taskID uuid.UUID) (string, error) {
+	var position sql.NullString
+	err := r.db.Model(&models.ChecklistItem{}).
+		Where("task_id = ?", taskID).
+		Select("MAX(position)").
+		Row().Scan(&position)
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get last checklist position")
+		return "", fmt.Errorf("failed to get last checklist position: %w", err)
+	}
+
+	return position.String, nil
+}
+
+// GetAdjacentPosition returns the position of the item directly before (or
+// after) the given position, ignoring excludeID. It returns an empty string
+// when there is no such item.
+func (r *checklistRepository) GetAdjacentPosition(taskID uuid.UUID, position string, excludeID uuid.UUID, before bool) (string, error) {
+	query := r.db.Model(&models.ChecklistItem{}).Where("task_id = ? AND id <> ?", taskID, excludeID)
+	if before {
+		query = query.Where("position < ?", position).Select("MAX(position)")
+	} else {
+		query = query.Where("position > ?", position).Select("MIN(position)")
+	}
+
+	var adjacent sql.NullString
+	if err := query.Row().Scan(&adjacent); err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get adjacent checklist position")
+		return "", fmt.Errorf("failed to get adjacent checklist position: %w", err)
+	}
+
+	return adjacent.String, nil
+}
+
+func (r *checklistRepository) GetCounts(taskIDs []uuid.UUID) (map[uuid.UUID]ChecklistCount, error) {
+	counts := make(map[uuid.UUID]ChecklistCount)
+	if len(taskIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []ChecklistCount
+	err := r.db.Model(&models.ChecklistItem{}).
+		Select("task_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE checked) AS checked").
+		Where("task_id IN ?", taskIDs).
+		Group("task_id").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get checklist counts")
+		return nil, fmt.Errorf("failed to get checklist counts: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.TaskID] = row
+	}
+	return counts, nil
+}
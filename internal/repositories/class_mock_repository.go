@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockClassRepository struct {
+	mock.Mock
+}
+
+func (m *MockClassRepository) Create(class *models.Class) error {
+	args := m.Called(class)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) GetByID(id uuid.UUID) (*models.Class, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Class), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClassRepository) GetAll(userID uuid.UUID) ([]models.Class, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Class), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClassRepository) Delete(id uuid.UUID, teacherID uuid.UUID) error {
+	args := m.Called(id, teacherID)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) GetRole(classID uuid.UUID, userID uuid.UUID) (enum.ClassRole, error) {
+	args := m.Called(classID, userID)
+	return args.Get(0).(enum.ClassRole), args.Error(1)
+}
+
+func (m *MockClassRepository) GetStudents(classID uuid.UUID) ([]models.ClassStudent, error) {
+	args := m.Called(classID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.ClassStudent), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClassRepository) AddStudent(student *models.ClassStudent) error {
+	args := m.Called(student)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) RemoveStudent(classID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(classID, userID)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) CreateAssignment(assignment *models.ClassAssignment, tasks []models.Task) error {
+	args := m.Called(assignment, tasks)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) GetAssignment(id uuid.UUID, classID uuid.UUID) (*models.ClassAssignment, error) {
+	args := m.Called(id, classID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.ClassAssignment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClassRepository) GetAssignments(classID uuid.UUID) ([]models.ClassAssignment, error) {
+	args := m.Called(classID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.ClassAssignment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClassRepository) GetAssignmentTasks(assignmentID uuid.UUID) ([]AssignmentTaskStatus, error) {
+	args := m.Called(assignmentID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]AssignmentTaskStatus), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClassRepository) GetAssigneeIDs(classID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(classID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]uuid.UUID), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,281 @@
+package repositories
+
+import (
+	"fmt"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AssignmentTaskStatus is the state of one student's task for an
+// assignment.
+type AssignmentTaskStatus struct {
+	TaskID      uuid.UUID
+	UserID      uuid.UUID
+	Username    string
+	Status      enum.TaskStatus
+	CompletedAt *time.Time
+	DeletedAt   *time.Time
+}
+
+type ClassRepository interface {
+	Create(class *models.Class) error
+	GetByID(id uuid.UUID) (*models.Class, error)
+	GetAll(userID uuid.UUID) ([]models.Class, error)
+	Delete(id uuid.UUID, teacherID uuid.UUID) error
+	GetRole(classID uuid.UUID, userID uuid.UUID) (enum.ClassRole, error)
+	GetStudents(classID uuid.UUID) ([]models.ClassStudent, error)
+	AddStudent(student *models.ClassStudent) error
+	RemoveStudent(classID uuid.UUID, userID uuid.UUID) error
+	CreateAssignment(assignment *models.ClassAssignment, tasks []models.Task) error
+	GetAssignment(id uuid.UUID, classID uuid.UUID) (*models.ClassAssignment, error)
+	GetAssignments(classID uuid.UUID) ([]models.ClassAssignment, error)
+	GetAssignmentTasks(assignmentID uuid.UUID) ([]AssignmentTaskStatus, error)
+	GetAssigneeIDs(classID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type classRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewClassRepository(db *gorm.DB, logger *logrus.Logger) ClassRepository {
+	return &classRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *classRepository) Create(class *models.Class) error {
+	if err := r.db.Create(class).Error; err != nil {
+		r.logger.WithError(err).WithField("teacher_id", class.TeacherID).Error("Failed to create class")
+		return fmt.Errorf("failed to create class: %w", err)
+	}
+
+	r.logger.WithField("class_id", class.ID).Info("Class created successfully")
+	return nil
+}
+
+func (r *classRepository) GetByID(id uuid.UUID) (*models.Class, error) {
+	var class models.Class
+	if err := r.db.Where("id = ?", id).First(&class).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("class_id", id).Warn("Class not found")
+			return nil, fmt.Errorf("class not found")
+		}
+		r.logger.WithError(err).WithField("class_id", id).Error("Failed to get class")
+		return nil, fmt.Errorf("failed to get class: %w", err)
+	}
+
+	return &class, nil
+}
+
+// GetAll lists the classes a user teaches or is enrolled in.
+func (r *classRepository) GetAll(userID uuid.UUID) ([]models.Class, error) {
+	var classes []models.Class
+	err := r.db.Where("teacher_id = ? OR id IN (SELECT class_id FROM class_students WHERE user_id = ?)", userID, userID).
+		Order("name ASC").Order("id ASC").
+		Find(&classes).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get classes")
+		return nil, fmt.Errorf("failed to get classes: %w", err)
+	}
+
+	return classes, nil
+}
+
+// Delete removes a class with its enrolments and assignments. The tasks
+// handed out stay with the students.
+func (r *classRepository) Delete(id uuid.UUID, teacherID uuid.UUID) error {
+	result := r.db.Where("id = ? AND teacher_id = ?", id, teacherID).Delete(&models.Class{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("class_id", id).Error("Failed to delete class")
+		return fmt.Errorf("failed to delete class: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("class_id", id).Warn("Class not found for deletion")
+		return fmt.Errorf("class not found")
+	}
+
+	r.logger.WithField("class_id", id).Info("Class deleted successfully")
+	return nil
+}
+
+// GetRole returns how a user takes part in a class, or "" when they neither
+// teach nor attend it.
+func (r *classRepository) GetRole(classID uuid.UUID, userID uuid.UUID) (enum.ClassRole, error) {
+	var classes []models.Class
+	if err := r.db.Where("id = ?", classID).Limit(1).Find(&classes).Error; err != nil {
+		r.logger.WithError(err).WithField("class_id", classID).Error("Failed to get class role")
+		return "", fmt.Errorf("failed to get class role: %w", err)
+	}
+	if len(classes) == 0 {
+		return "", nil
+	}
+	if classes[0].TeacherID == userID {
+		return enum.ClassTeacher, nil
+	}
+
+	var count int64
+	err := r.db.Model(&models.ClassStudent{}).Where("class_id = ? AND user_id = ?", classID, userID).Count(&count).Error
+	if err != nil {
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"class_id": classID,
+			"user_id":  userID,
+		}).Error("Failed to get class role")
+		return "", fmt.Errorf("failed to get class role: %w", err)
+	}
+
+	if count == 0 {
+		return "", nil
+	}
+	return enum.ClassStudent, nil
+}
+
+func (r *classRepository) GetStudents(classID uuid.UUID) ([]models.ClassStudent, error) {
+	var students []models.ClassStudent
+	err := r.db.Preload("User").Where("class_id = ?", classID).
+		Order("created_at ASC").Order("user_id ASC").
+		Find(&students).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("class_id", classID).Error("Failed to get class students")
+		return nil, fmt.Errorf("failed to get class students: %w", err)
+	}
+
+	return students, nil
+}
+
+// AddStudent enrols a user in a class. Enrolling a student twice is a no-op.
+func (r *classRepository) AddStudent(student *models.ClassStudent) error {
+	err := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(student).Error
+	if err != nil {
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"class_id": student.ClassID,
+			"user_id":  student.UserID,
+		}).Error("Failed to add class student")
+		return fmt.Errorf("failed to add class student: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"class_id": student.ClassID,
+		"user_id":  student.UserID,
+	}).Info("Class student added successfully")
+	return nil
+}
+
+// RemoveStudent ends a user's enrolment. The tasks they were given stay
+// theirs.
+func (r *classRepository) RemoveStudent(classID uuid.UUID, userID uuid.UUID) error {
+	result := r.db.Where("class_id = ? AND user_id = ?", classID, userID).Delete(&models.ClassStudent{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("class_id", classID).Error("Failed to remove class student")
+		return fmt.Errorf("failed to remove class student: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("class_id", classID).Warn("Class student not found for removal")
+		return fmt.Errorf("class student not found")
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"class_id": classID,
+		"user_id":  userID,
+	}).Info("Class student removed successfully")
+	return nil
+}
+
+// CreateAssignment creates an assignment and the task of every student for
+// it in a single transaction, so either every student gets the assignment
+// or nobody does.
+func (r *classRepository) CreateAssignment(assignment *models.ClassAssignment, tasks []models.Task) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(assignment).Error; err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+
+		for i := range tasks {
+			tasks[i].AssignmentID = &assignment.ID
+		}
+		return tx.Omit(clause.Associations).Create(&tasks).Error
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("class_id", assignment.ClassID).Error("Failed to create assignment")
+		return fmt.Errorf("failed to create assignment: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"assignment_id": assignment.ID,
+		"class_id":      assignment.ClassID,
+		"tasks":         len(tasks),
+	}).Info("Assignment created successfully")
+	return nil
+}
+
+func (r *classRepository) GetAssignment(id uuid.UUID, classID uuid.UUID) (*models.ClassAssignment, error) {
+	var assignment models.ClassAssignment
+	if err := r.db.Where("id = ? AND class_id = ?", id, classID).First(&assignment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("assignment_id", id).Warn("Assignment not found")
+			return nil, fmt.Errorf("assignment not found")
+		}
+		r.logger.WithError(err).WithField("assignment_id", id).Error("Failed to get assignment")
+		return nil, fmt.Errorf("failed to get assignment: %w", err)
+	}
+
+	return &assignment, nil
+}
+
+func (r *classRepository) GetAssignments(classID uuid.UUID) ([]models.ClassAssignment, error) {
+	var assignments []models.ClassAssignment
+	err := r.db.Where("class_id = ?", classID).
+		Order("created_at DESC").Order("id ASC").
+		Find(&assignments).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("class_id", classID).Error("Failed to get assignments")
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// GetAssignmentTasks returns the task of every student for an assignment,
+// trashed tasks included, ordered by username.
+func (r *classRepository) GetAssignmentTasks(assignmentID uuid.UUID) ([]AssignmentTaskStatus, error) {
+	var statuses []AssignmentTaskStatus
+	err := r.db.Unscoped().Model(&models.Task{}).
+		Select("tasks.id AS task_id, tasks.user_id, users.username, tasks.status, tasks.completed_at, tasks.deleted_at").
+		Joins("JOIN users ON users.id = tasks.user_id").
+		Where("tasks.assignment_id = ?", assignmentID).
+		Order("users.username ASC").
+		Scan(&statuses).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("assignment_id", assignmentID).Error("Failed to get assignment tasks")
+		return nil, fmt.Errorf("failed to get assignment tasks: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// GetAssigneeIDs lists every user holding a task for one of the class's
+// assignments.
+func (r *classRepository) GetAssigneeIDs(classID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.Unscoped().Model(&models.Task{}).Distinct("user_id").
+		Where("assignment_id IN (SELECT id FROM class_assignments WHERE class_id = ?)", classID).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("class_id", classID).Error("Failed to get assignees")
+		return nil, fmt.Errorf("failed to get assignees: %w", err)
+	}
+
+	return userIDs, nil
+}
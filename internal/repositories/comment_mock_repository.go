@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) Create(comment *models.TaskComment) error {
+	args := m.Called(comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetByID(id uuid.UUID, taskID uuid.UUID) (*models.TaskComment, error) {
+	args := m.Called(id, taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TaskComment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCommentRepository) GetByTaskID(taskID uuid.UUID, after *CommentCursor, limit int) ([]models.TaskComment, error) {
+	args := m.Called(taskID, after, limit)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TaskComment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCommentRepository) Update(comment *models.TaskComment) error {
+	args := m.Called(comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) Delete(id uuid.UUID, taskID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(id, taskID, userID)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetCounts(taskIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	args := m.Called(taskIDs)
+	if args.Get(0) != nil {
+		return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"go-corenglish/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CommentCursor points at the last comment of a page; the next page starts
+// right after it.
+type CommentCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CommentCount counts the comments of a task.
+type CommentCount struct {
+	TaskID uuid.UUID
+	Total  int64
+}
+
+// ErrCommentNotFound is returned when a comment does not exist on the task.
+var ErrCommentNotFound = errors.New("comment not found")
+
+type CommentRepository interface {
+	Create(comment *models.TaskComment) error
+	GetByID(id uuid.UUID, taskID uuid.UUID) (*models.TaskComment, error)
+	GetByTaskID(taskID uuid.UUID, after *CommentCursor, limit int) ([]models.TaskComment, error)
+	Update(comment *models.TaskComment) error
+	Delete(id uuid.UUID, taskID uuid.UUID, userID uuid.UUID) error
+	GetCounts(taskIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+}
+
+type commentRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewCommentRepository(db *gorm.DB, logger *logrus.Logger) CommentRepository {
+	return &commentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *commentRepository) Create(comment *models.TaskComment) error {
+	if err := r.db.Omit(clause.Associations).Create(comment).Error; err != nil {
+		r.logger.WithError(err).WithField("task_id", comment.TaskID).Error("Failed to create comment")
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"comment_id": comment.ID,
+		"task_id":    comment.TaskID,
+	}).Info("Comment created successfully")
+	return nil
+}
+
+func (r *commentRepository) GetByID(id uuid.UUID, taskID uuid.UUID) (*models.TaskComment, error) {
+	var comment models.TaskComment
+	err := r.db.Preload("User").Where("id = ? AND task_id = ?", id, taskID).First(&comment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("comment_id", id).Warn("Comment not found")
+			return nil, ErrCommentNotFound
+		}
+		r.logger.WithError(err).WithField("comment_id", id).Error("Failed to get comment")
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// GetByTaskID returns up to limit comments of a task, oldest first, starting
+// after the cursor when one is given.
+func (r *commentRepository) GetByTaskID(taskID uuid.UUID, after *CommentCursor, limit int) ([]models.TaskComment, error) {
+	query := r.db.Preload("User").Where("task_id = ?", taskID)
+	if after != nil {
+		query = query.Where("(created_at, id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	var comments []models.TaskComment
+	err := query.Order("created_at ASC").Order("id ASC").Limit(limit).Find(&comments).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get comments")
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// Update saves the body of a comment. Only its author can change it.
+func (r *commentRepository) Update(comment *models.TaskComment) error {
+	result := r.db.Model(comment).Select("body", "edited_at").
+		Where("id = ? AND task_id = ? AND user_id = ?", comment.ID, comment.TaskID, comment.UserID).
+		Updates(comment)
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("comment_id", comment.ID).Error("Failed to update comment")
+		return fmt.Errorf("failed to update comment: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("comment_id", comment.ID).Warn("Comment not found for update")
+		return ErrCommentNotFound
+	}
+
+	r.logger.WithField("comment_id", comment.ID).Info("Comment updated successfully")
+	return nil
+}
+
+// Delete removes a comment. Only its author can delete it.
+func (r *commentRepository) Delete(id uuid.UUID, taskID uuid.UUID, userID uuid.UUID) error {
+	result := r.db.Where("id = ? AND task_id = ? AND user_id = ?", id, taskID, userID).Delete(&models.TaskComment{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("comment_id", id).Error("Failed to delete comment")
+		return fmt.Errorf("failed to delete comment: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("comment_id", id).Warn("Comment not found for deletion")
+		return ErrCommentNotFound
+	}
+
+	r.logger.WithField("comment_id", id).Info("Comment deleted successfully")
+	return nil
+}
+
+func (r *commentRepository) GetCounts(taskIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64)
+	if len(taskIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []CommentCount
+	err := r.db.Model(&models.TaskComment{}).
+		Select("task_id, COUNT(*) AS total").
+		Where("task_id IN ?", taskIDs).
+		Group("task_id").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get comment counts")
+		return nil, fmt.Errorf("failed to get comment counts: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.TaskID] = row.Total
+	}
+	return counts, nil
+}
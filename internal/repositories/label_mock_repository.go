@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabelRepository) Create(label *models.Label) error {
+	args := m.Called(label)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) GetByID(id uuid.UUID, userID uuid.UUID) (*models.Label, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Label), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLabelRepository) GetByIDs(ids []uuid.UUID, userID uuid.UUID) ([]models.Label, error) {
+	args := m.Called(ids, userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Label), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLabelRepository) GetByName(name string, userID uuid.UUID) (*models.Label, error) {
+	args := m.Called(name, userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Label), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLabelRepository) GetAll(userID uuid.UUID) ([]models.Label, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Label), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLabelRepository) Update(label *models.Label) error {
+	args := m.Called(label)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"fmt"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LabelRepository interface {
+	Create(label *models.Label) error
+	GetByID(id uuid.UUID, userID uuid.UUID) (*models.Label, error)
+	GetByIDs(ids []uuid.UUID, userID uuid.UUID) ([]models.Label, error)
+	GetByName(name string, userID uuid.UUID) (*models.Label, error)
+	GetAll(userID uuid.UUID) ([]models.Label, error)
+	Update(label *models.Label) error
+	Delete(id uuid.UUID, userID uuid.UUID) error
+}
+
+type labelRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewLabelRepository(db *gorm.DB, logger *logrus.Logger) LabelRepository {
+	return &labelRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *labelRepository) Create(label *models.Label) error {
+	if err := r.db.Create(label).Error; err != nil {
+		r.logger.WithError(err).Error("Failed to create label")
+		return fmt.Errorf("failed to create label: %w", err)
+	}
+
+	r.logger.WithField("label_id", label.ID).Info("Label created successfully")
+	return nil
+}
+
+func (r *labelRepository) GetByID(id uuid.UUID, userID uuid.UUID) (*models.Label, error) {
+	var label models.Label
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&label).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("label_id", id).Warn("Label not found")
+			return nil, fmt.Errorf("label not found")
+		}
+		r.logger.WithError(err).WithField("label_id", id).Error("Failed to get label")
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+
+	return &label, nil
+}
+
+func (r *labelRepository) GetByIDs(ids []uuid.UUID, userID uuid.UUID) ([]models.Label, error) {
+	var labels []models.Label
+	if len(ids) == 0 {
+		return labels, nil
+	}
+
+	if err := r.db.Where("id IN ? AND user_id = ?", ids, userID).Find(&labels).Error; err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get labels by IDs")
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (r *labelRepository) GetByName(name string, userID uuid.UUID) (*models.Label, error) {
+	var label models.Label
+	err := r.db.Where("name = ? AND user_id = ?", name, userID).First(&label).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("label not found")
+		}
+		r.logger.WithError(err).WithField("name", name).Error("Failed to get label by name")
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+
+	return &label, nil
+}
+
+func (r *labelRepository) GetAll(userID uuid.UUID) ([]models.Label, error) {
+	var labels []models.Label
+	if err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&labels).Error; err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get labels")
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (r *labelRepository) Update(label *models.Label) error {
+	result := r.db.Model(label).Where("id = ? AND user_id = ?", label.ID, label.UserID).Updates(label)
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("label_id", label.ID).Error("Failed to update label")
+		return fmt.Errorf("failed to update label: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("label_id", label.ID).Warn("Label not found for update")
+		return fmt.Errorf("label not found")
+	}
+
+	r.logger.WithField("label_id", label.ID).Info("Label updated successfully")
+	return nil
+}
+
+func (r *labelRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Label{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("label_id", id).Error("Failed to delete label")
+		return fmt.Errorf("failed to delete label: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("label_id", id).Warn("Label not found for deletion")
+		return fmt.Errorf("label not found")
+	}
+
+	r.logger.WithField("label_id", id).Info("Label deleted successfully")
+	return nil
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectRepository) Create(project *models.Project) error {
+	args := m.Called(project)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetByID(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Project, error) {
+	args := m.Called(id, userID, workspaceID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Project), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProjectRepository) GetByName(name string, userID uuid.UUID, workspaceID uuid.UUID) (*models.Project, error) {
+	args := m.Called(name, userID, workspaceID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Project), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProjectRepository) GetAll(userID uuid.UUID, workspaceID uuid.UUID, includeArchived bool) ([]models.Project, error) {
+	args := m.Called(userID, workspaceID, includeArchived)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Project), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProjectRepository) Update(project *models.Project) error {
+	args := m.Called(project)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) Delete(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) error {
+	args := m.Called(id, userID, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetStatusCounts(projectIDs []uuid.UUID) (map[uuid.UUID]map[enum.TaskStatus]int64, error) {
+	args := m.Called(projectIDs)
+	if args.Get(0) != nil {
+		return args.Get(0).(map[uuid.UUID]map[enum.TaskStatus]int64), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
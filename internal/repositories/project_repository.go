@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ProjectStatusCount counts the tasks of a project in one status.
+type ProjectStatusCount struct {
+	ProjectID uuid.UUID
+	Status    enum.TaskStatus
+	Count     int64
+}
+
+// ErrProjectNotFound is returned when a project does not exist or belongs to
+// another user or workspace.
+var ErrProjectNotFound = errors.New("project not found")
+
+type ProjectRepository interface {
+	Create(project *models.Project) error
+	GetByID(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Project, error)
+	GetByName(name string, userID uuid.UUID, workspaceID uuid.UUID) (*models.Project, error)
+	GetAll(userID uuid.UUID, workspaceID uuid.UUID, includeArchived bool) ([]models.Project, error)
+	Update(project *models.Project) error
+	Delete(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) error
+	GetStatusCounts(projectIDs []uuid.UUID) (map[uuid.UUID]map[enum.TaskStatus]int64, error)
+}
+
+type projectRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewProjectRepository(db *gorm.DB, logger *logrus.Logger) ProjectRepository {
+	return &projectRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *projectRepository) Create(project *models.Project) error {
+	if err := r.db.Create(project).Error; err != nil {
+		r.logger.WithError(err).WithField("user_id", project.UserID).Error("Failed to create project")
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	r.logger.WithField("project_id", project.ID).Info("Project created successfully")
+	return nil
+}
+
+func (r *projectRepository) GetByID(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Project, error) {
+	var project models.Project
+	err := r.db.Where("id = ? AND user_id = ? AND workspace_id = ?", id, userID, workspaceID).First(&project).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("project_id", id).Warn("Project not found")
+			return nil, ErrProjectNotFound
+		}
+		r.logger.WithError(err).WithField("project_id", id).Error("Failed to get project")
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return &project, nil
+}
+
+func (r *projectRepository) GetByName(name string, userID uuid.UUID, workspaceID uuid.UUID) (*models.Project, error) {
+	var project models.Project
+	err := r.db.Where("name = ? AND user_id = ? AND workspace_id = ?", name, userID, workspaceID).First(&project).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrProjectNotFound
+		}
+		r.logger.WithError(err).WithField("name", name).Error("Failed to get project by name")
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return &project, nil
+}
+
+func (r *projectRepository) GetAll(userID uuid.UUID, workspaceID uuid.UUID, includeArchived bool) ([]models.Project, error) {
+	var projects []models.Project
+	query := r.db.Where("user_id = ? AND workspace_id = ?", userID, workspaceID)
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	if err := query.Order("name ASC").Order("id ASC").Find(&projects).Error; err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get projects")
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// Update saves the name, colour and archived state of a project.
+func (r *projectRepository) Update(project *models.Project) error {
+	result := r.db.Model(project).
+		Where("id = ? AND user_id = ?", project.ID, project.UserID).
+		Select("name", "color", "archived_at").
+		Updates(project)
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("project_id", project.ID).Error("Failed to update project")
+		return fmt.Errorf("failed to update project: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("project_id", project.ID).Warn("Project not found for update")
+		return ErrProjectNotFound
+	}
+
+	r.logger.WithField("project_id", project.ID).Info("Project updated successfully")
+	return nil
+}
+
+// Delete removes a project. Its tasks stay, outside of any project.
+func (r *projectRepository) Delete(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) error {
+	result := r.db.Where("id = ? AND user_id = ? AND workspace_id = ?", id, userID, workspaceID).Delete(&models.Project{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("project_id", id).Error("Failed to delete project")
+		return fmt.Errorf("failed to delete project: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("project_id", id).Warn("Project not found for deletion")
+		return ErrProjectNotFound
+	}
+
+	r.logger.WithField("project_id", id).Info("Project deleted successfully")
+	return nil
+}
+
+// GetStatusCounts counts the tasks of each project by status. Trashed and
+// archived tasks are left out, like in the task lists.
+func (r *projectRepository) GetStatusCounts(projectIDs []uuid.UUID) (map[uuid.UUID]map[enum.TaskStatus]int64, error) {
+	counts := make(map[uuid.UUID]map[enum.TaskStatus]int64)
+	if len(projectIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []ProjectStatusCount
+	err := r.db.Model(&models.Task{}).
+		Select("project_id, status, COUNT(*) AS count").
+		Where("project_id IN ? AND archived_at IS NULL", projectIDs).
+		Group("project_id, status").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get project status counts")
+		return nil, fmt.Errorf("failed to get project status counts: %w", err)
+	}
+
+	for _, row := range rows {
+		if counts[row.ProjectID] == nil {
+			counts[row.ProjectID] = make(map[enum.TaskStatus]int64)
+		}
+		counts[row.ProjectID][row.Status] = row.Count
+	}
+	return counts, nil
+}
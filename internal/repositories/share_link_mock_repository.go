@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockShareLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockShareLinkRepository) Create(link *models.TaskShareLink) error {
+	args := m.Called(link)
+	return args.Error(0)
+}
+
+func (m *MockShareLinkRepository) GetByTokenHash(tokenHash string) (*models.TaskShareLink, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TaskShareLink), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShareLinkRepository) GetByTaskID(taskID uuid.UUID) ([]models.TaskShareLink, error) {
+	args := m.Called(taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TaskShareLink), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShareLinkRepository) Delete(id uuid.UUID, taskID uuid.UUID) error {
+	args := m.Called(id, taskID)
+	return args.Error(0)
+}
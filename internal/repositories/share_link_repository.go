@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"fmt"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShareLinkRepository interface {
+	Create(link *models.TaskShareLink) error
+	GetByTokenHash(tokenHash string) (*models.TaskShareLink, error)
+	GetByTaskID(taskID uuid.UUID) ([]models.TaskShareLink, error)
+	Delete(id uuid.UUID, taskID uuid.UUID) error
+}
+
+type shareLinkRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewShareLinkRepository(db *gorm.DB, logger *logrus.Logger) ShareLinkRepository {
+	return &shareLinkRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *shareLinkRepository) Create(link *models.TaskShareLink) error {
+	if err := r.db.Omit(clause.Associations).Create(link).Error; err != nil {
+		r.logger.WithError(err).WithField("task_id", link.TaskID).Error("Failed to create share link")
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"share_link_id": link.ID,
+		"task_id":       link.TaskID,
+	}).Info("Share link created successfully")
+	return nil
+}
+
+func (r *shareLinkRepository) GetByTokenHash(tokenHash string) (*models.TaskShareLink, error) {
+	var link models.TaskShareLink
+	err := r.db.Where("token_hash = ?", tokenHash).First(&link).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("share link not found")
+		}
+		r.logger.WithError(err).Error("Failed to get share link")
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return &link, nil
+}
+
+func (r *shareLinkRepository) GetByTaskID(taskID uuid.UUID) ([]models.TaskShareLink, error) {
+	var links []models.TaskShareLink
+	err := r.db.Where("task_id = ?", taskID).Order("created_at ASC").Order("id ASC").Find(&links).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get share links")
+		return nil, fmt.Errorf("failed to get share links: %w", err)
+	}
+
+	return links, nil
+}
+
+func (r *shareLinkRepository) Delete(id uuid.UUID, taskID uuid.UUID) error {
+	result := r.db.Where("id = ? AND task_id = ?", id, taskID).Delete(&models.TaskShareLink{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("share_link_id", id).Error("Failed to delete share link")
+		return fmt.Errorf("failed to delete share link: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("share_link_id", id).Warn("Share link not found for deletion")
+		return fmt.Errorf("share link not found")
+	}
+
+	r.logger.WithField("share_link_id", id).Info("Share link deleted successfully")
+	return nil
+}
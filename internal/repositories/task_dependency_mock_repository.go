@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskDependencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskDependencyRepository) Create(dependency *models.TaskDependency) error {
+	args := m.Called(dependency)
+	return args.Error(0)
+}
+
+func (m *MockTaskDependencyRepository) Delete(taskID uuid.UUID, blockedByID uuid.UUID) error {
+	args := m.Called(taskID, blockedByID)
+	return args.Error(0)
+}
+
+func (m *MockTaskDependencyRepository) GetBlockers(taskID uuid.UUID) ([]models.Task, error) {
+	args := m.Called(taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Task), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTaskDependencyRepository) GetUnfinishedBlockers(taskID uuid.UUID) ([]models.Task, error) {
+	args := m.Called(taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Task), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDependencyCycle is returned when a new dependency would close a cycle.
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+// maxDependencyAttempts bounds how often adding a dependency is tried when
+// concurrent changes to the graph keep failing its transaction.
+const maxDependencyAttempts = 3
+
+type TaskDependencyRepository interface {
+	Create(dependency *models.TaskDependency) error
+	Delete(taskID uuid.UUID, blockedByID uuid.UUID) error
+	GetBlockers(taskID uuid.UUID) ([]models.Task, error)
+	GetUnfinishedBlockers(taskID uuid.UUID) ([]models.Task, error)
+}
+
+type taskDependencyRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewTaskDependencyRepository(db *gorm.DB, logger *logrus.Logger) TaskDependencyRepository {
+	return &taskDependencyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create adds the dependency unless the blocker already waits on the task,
+// directly or through other tasks, which returns ErrDependencyCycle. The
+// check and the insert run in one serializable transaction, so concurrent
+// additions cannot close a cycle between them; a transaction that loses such
+// a race is retried.
+func (r *taskDependencyRepository) Create(dependency *models.TaskDependency) error {
+	var err error
+	for attempt := 1; attempt <= maxDependencyAttempts; attempt++ {
+		err = r.db.Transaction(func(tx *gorm.DB) error {
+			cycle, err := isBlockedTransitively(tx, dependency.BlockedByID, dependency.TaskID)
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return ErrDependencyCycle
+			}
+			return tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(dependency).Error
+		}, &sql.TxOptions{Isolation: sql.LevelSerializable})
+		if !isSerializationFailure(err) {
+			break
+		}
+		r.logger.WithField("task_id", dependency.TaskID).WithField("attempt", attempt).Warn("Task dependency conflicted with a concurrent change")
+	}
+	if errors.Is(err, ErrDependencyCycle) {
+		return err
+	}
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", dependency.TaskID).Error("Failed to create task dependency")
+		return fmt.Errorf("failed to create task dependency: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"task_id":       dependency.TaskID,
+		"blocked_by_id": dependency.BlockedByID,
+	}).Info("Task dependency created successfully")
+	return nil
+}
+
+func (r *taskDependencyRepository) Delete(taskID uuid.UUID, blockedByID uuid.UUID) error {
+	result := r.db.Where("task_id = ? AND blocked_by_id = ?", taskID, blockedByID).Delete(&models.TaskDependency{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("task_id", taskID).Error("Failed to delete task dependency")
+		return fmt.Errorf("failed to delete task dependency: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("task_id", taskID).Warn("Task dependency not found for deletion")
+		return fmt.Errorf("task dependency not found")
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"task_id":       taskID,
+		"blocked_by_id": blockedByID,
+	}).Info("Task dependency deleted successfully")
+	return nil
+}
+
+func (r *taskDependencyRepository) GetBlockers(taskID uuid.UUID) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Joins("JOIN task_dependencies ON task_dependencies.blocked_by_id = tasks.id").
+		Where("task_dependencies.task_id = ?", taskID).
+		Order("tasks.created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get task blockers")
+		return nil, fmt.Errorf("failed to get task blockers: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *taskDependencyRepository) GetUnfinishedBlockers(taskID uuid.UUID) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Joins("JOIN task_dependencies ON task_dependencies.blocked_by_id = tasks.id").
+		Where("task_dependencies.task_id = ? AND tasks.status NOT IN ("+doneStatuses+")", taskID).
+		Order("tasks.created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get unfinished task blockers")
+		return nil, fmt.Errorf("failed to get unfinished task blockers: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// isBlockedTransitively reports whether taskID is blocked by blockerID either
+// directly or through a chain of other dependencies.
+func isBlockedTransitively(db *gorm.DB, taskID uuid.UUID, blockerID uuid.UUID) (bool, error) {
+	var found bool
+	// UNION (not UNION ALL) discards rows already visited, so the walk
+	// terminates even if the graph were to contain a cycle.
+	err := db.Raw(`
+		WITH RECURSIVE blockers AS (
+			SELECT blocked_by_id AS id FROM task_dependencies WHERE task_id = ?
+			UNION
+			SELECT d.blocked_by_id
+			FROM task_dependencies d
+			JOIN blockers b ON d.task_id = b.id
+		)
+		SELECT EXISTS (SELECT 1 FROM blockers WHERE id = ?)`,
+		taskID, blockerID,
+	).Scan(&found).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to walk task dependencies: %w", err)
+	}
+
+	return found, nil
+}
+
+// isSerializationFailure reports whether err is PostgreSQL aborting a
+// serializable transaction that conflicted with a concurrent one.
+func isSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "40001"
+}
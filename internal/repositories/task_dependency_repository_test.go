@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"regexp"
+	"testing"
+
+	"go-corenglish/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const walkDependencies = `WITH RECURSIVE blockers AS`
+
+func newTestTaskDependencyRepository(t *testing.T) (TaskDependencyRepository, sqlmock.Sqlmock) {
+	db, mock := newMockDB(t)
+	return NewTaskDependencyRepository(db, newTestLogger()), mock
+}
+
+func TestTaskDependencyRepositoryCreateChecksForCyclesInTheSameTransaction(t *testing.T) {
+	repo, mock := newTestTaskDependencyRepository(t)
+	dependency := &models.TaskDependency{TaskID: uuid.New(), BlockedByID: uuid.New()}
+
+	// The walk starts at the blocker and looks for the task.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(walkDependencies)).
+		WithArgs(dependency.BlockedByID, dependency.TaskID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "task_dependencies"`)).
+		WithArgs(dependency.TaskID, dependency.BlockedByID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Create(dependency))
+}
+
+func TestTaskDependencyRepositoryCreateRefusesCycles(t *testing.T) {
+	repo, mock := newTestTaskDependencyRepository(t)
+	dependency := &models.TaskDependency{TaskID: uuid.New(), BlockedByID: uuid.New()}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(walkDependencies)).
+		WithArgs(dependency.BlockedByID, dependency.TaskID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	assert.ErrorIs(t, repo.Create(dependency), ErrDependencyCycle)
+}
+
+func TestTaskDependencyRepositoryCreateRetriesSerializationFailures(t *testing.T) {
+	repo, mock := newTestTaskDependencyRepository(t)
+	dependency := &models.TaskDependency{TaskID: uuid.New(), BlockedByID: uuid.New()}
+
+	// A concurrent addition made the first attempt fail, and the second one
+	// sees the edge it added.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(walkDependencies)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "task_dependencies"`)).
+		WillReturnError(&pgconn.PgError{Code: "40001"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(walkDependencies)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	assert.ErrorIs(t, repo.Create(dependency), ErrDependencyCycle)
+}
+
+func TestTaskDependencyRepositoryCreateGivesUpAfterRepeatedConflicts(t *testing.T) {
+	repo, mock := newTestTaskDependencyRepository(t)
+	dependency := &models.TaskDependency{TaskID: uuid.New(), BlockedByID: uuid.New()}
+
+	for i := 0; i < maxDependencyAttempts; i++ {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(walkDependencies)).
+			WillReturnError(&pgconn.PgError{Code: "40001"})
+		mock.ExpectRollback()
+	}
+
+	err := repo.Create(dependency)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrDependencyCycle)
+}
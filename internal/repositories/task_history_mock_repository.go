@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"go-corenglish/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskHistoryRepository) Create(entry *models.TaskStatusHistory) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockTaskHistoryRepository) GetByTaskID(taskID uuid.UUID) ([]models.TaskStatusHistory, error) {
+	args := m.Called(taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TaskStatusHistory), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTaskHistoryRepository) GetCompletionStats(userID uuid.UUID, workspaceID uuid.UUID, from, to time.Time) (*CompletionStats, error) {
+	args := m.Called(userID, workspaceID, from, to)
+	if args.Get(0) != nil {
+		return args.Get(0).(*CompletionStats), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTaskHistoryRepository) GetWeeklyCompletions(userID uuid.UUID, workspaceID uuid.UUID, from, to time.Time) ([]WeeklyCompletion, error) {
+	args := m.Called(userID, workspaceID, from, to)
+	if args.Get(0) != nil {
+		return args.Get(0).([]WeeklyCompletion), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
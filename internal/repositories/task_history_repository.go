@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"fmt"
+	"go-corenglish/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CompletionStats aggregates the tasks of a user in a workspace completed in
+// a period.
+// Durations are in seconds and nil when there are no samples.
+type CompletionStats struct {
+	Completed          int64
+	LeadSamples        int64
+	AvgLeadSeconds     *float64
+	MedianLeadSeconds  *float64
+	CycleSamples       int64
+	AvgCycleSeconds    *float64
+	MedianCycleSeconds *float64
+}
+
+type WeeklyCompletion struct {
+	WeekStart time.Time
+	Completed int64
+}
+
+type TaskHistoryRepository interface {
+	Create(entry *models.TaskStatusHistory) error
+	GetByTaskID(taskID uuid.UUID) ([]models.TaskStatusHistory, error)
+	GetCompletionStats(userID uuid.UUID, workspaceID uuid.UUID, from, to time.Time) (*CompletionStats, error)
+	GetWeeklyCompletions(userID uuid.UUID, workspaceID uuid.UUID, from, to time.Time) ([]WeeklyCompletion, error)
+}
+
+type taskHistoryRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewTaskHistoryRepository(db *gorm.DB, logger *logrus.Logger) TaskHistoryRepository {
+	return &taskHistoryRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *taskHistoryRepository) Create(entry *models.TaskStatusHistory) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		r.logger.WithError(err).WithField("task_id", entry.TaskID).Error("Failed to create task status history")
+		return fmt.Errorf("failed to create task status history: %w", err)
+	}
+
+	return nil
+}
+
+func (r *taskHistoryRepository) GetByTaskID(taskID uuid.UUID) ([]models.TaskStatusHistory, error) {
+	var entries []models.TaskStatusHistory
+	if err := r.db.Where("task_id = ?", taskID).Order("changed_at ASC").Find(&entries).Error; err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get task status history")
+		return nil, fmt.Errorf("failed to get task status history: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *taskHistoryRepository) GetCompletionStats(userID uuid.UUID, workspaceID uuid.UUID, from, to time.Time) (*CompletionStats, error) {
+	var stats CompletionStats
+	err := r.db.Raw(`
+		WITH completed AS (
+			SELECT
+				EXTRACT(EPOCH FROM (completed_at - created_at)) AS lead_seconds,
+				EXTRACT(EPOCH FROM (completed_at - started_at)) AS cycle_seconds
+			FROM tasks
+			WHERE user_id = ? AND workspace_id = ? AND completed_at >= ? AND completed_at < ? AND deleted_at IS NULL
+		)
+		SELECT
+			COUNT(*) AS completed,
+			COUNT(lead_seconds) AS lead_samples,
+			AVG(lead_seconds) AS avg_lead_seconds,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY lead_seconds) AS median_lead_seconds,
+			COUNT(cycle_seconds) AS cycle_samples,
+			AVG(cycle_seconds) AS avg_cycle_seconds,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY cycle_seconds) AS median_cycle_seconds
+		FROM completed`,
+		userID, workspaceID, from, to,
+	).Scan(&stats).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get completion stats")
+		return nil, fmt.Errorf("failed to get completion stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+func (r *taskHistoryRepository) GetWeeklyCompletions(userID uuid.UUID, workspaceID uuid.UUID, from, to time.Time) ([]WeeklyCompletion, error) {
+	var weeks []WeeklyCompletion
+	err := r.db.Raw(`
+		SELECT DATE_TRUNC('week', completed_at AT TIME ZONE 'UTC') AS week_start, COUNT(*) AS completed
+		FROM tasks
+		WHERE user_id = ? AND workspace_id = ? AND completed_at >= ? AND completed_at < ? AND deleted_at IS NULL
+		GROUP BY 1
+		ORDER BY 1`,
+		userID, workspaceID, from, to,
+	).Scan(&weeks).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get weekly completions")
+		return nil, fmt.Errorf("failed to get weekly completions: %w", err)
+	}
+
+	return weeks, nil
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskHistoryRepositoryStatsAreScopedToTheWorkspace(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewTaskHistoryRepository(db, newTestLogger())
+	userID, workspaceID := uuid.New(), uuid.New()
+	to := time.Now()
+	from := to.AddDate(0, 0, -7)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE user_id = $1 AND workspace_id = $2 AND completed_at >= $3 AND completed_at < $4`)).
+		WithArgs(userID, workspaceID, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"completed"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE user_id = $1 AND workspace_id = $2 AND completed_at >= $3 AND completed_at < $4`)).
+		WithArgs(userID, workspaceID, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"week_start", "completed"}))
+
+	stats, err := repo.GetCompletionStats(userID, workspaceID, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Completed)
+
+	weeks, err := repo.GetWeeklyCompletions(userID, workspaceID, from, to)
+	require.NoError(t, err)
+	assert.Empty(t, weeks)
+}
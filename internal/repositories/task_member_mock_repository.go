@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskMemberRepository) Upsert(member *models.TaskMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *MockTaskMemberRepository) GetRole(taskID uuid.UUID, userID uuid.UUID) (enum.TaskRole, error) {
+	args := m.Called(taskID, userID)
+	return args.Get(0).(enum.TaskRole), args.Error(1)
+}
+
+func (m *MockTaskMemberRepository) GetByTaskID(taskID uuid.UUID) ([]models.TaskMember, error) {
+	args := m.Called(taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TaskMember), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTaskMemberRepository) GetUserIDs(taskID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(taskID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]uuid.UUID), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTaskMemberRepository) Delete(taskID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(taskID, userID)
+	return args.Error(0)
+}
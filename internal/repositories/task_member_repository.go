@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"fmt"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskMemberRepository interface {
+	Upsert(member *models.TaskMember) error
+	GetRole(taskID uuid.UUID, userID uuid.UUID) (enum.TaskRole, error)
+	GetByTaskID(taskID uuid.UUID) ([]models.TaskMember, error)
+	GetUserIDs(taskID uuid.UUID) ([]uuid.UUID, error)
+	Delete(taskID uuid.UUID, userID uuid.UUID) error
+}
+
+type taskMemberRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewTaskMemberRepository(db *gorm.DB, logger *logrus.Logger) TaskMemberRepository {
+	return &taskMemberRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Upsert adds a member to a task, or changes the role of an existing one.
+func (r *taskMemberRepository) Upsert(member *models.TaskMember) error {
+	err := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "task_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "invited_by", "updated_at"}),
+	}).Create(member).Error
+	if err != nil {
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": member.TaskID,
+			"user_id": member.UserID,
+		}).Error("Failed to save task member")
+		return fmt.Errorf("failed to save task member: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"task_id": member.TaskID,
+		"user_id": member.UserID,
+		"role":    member.Role,
+	}).Info("Task member saved successfully")
+	return nil
+}
+
+// GetRole returns the role of a member of the task, or "" when the user is
+// not a member.
+func (r *taskMemberRepository) GetRole(taskID uuid.UUID, userID uuid.UUID) (enum.TaskRole, error) {
+	var members []models.TaskMember
+	err := r.db.Where("task_id = ? AND user_id = ?", taskID, userID).Limit(1).Find(&members).Error
+	if err != nil {
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task member role")
+		return "", fmt.Errorf("failed to get task member role: %w", err)
+	}
+
+	if len(members) == 0 {
+		return "", nil
+	}
+	return members[0].Role, nil
+}
+
+func (r *taskMemberRepository) GetByTaskID(taskID uuid.UUID) ([]models.TaskMember, error) {
+	var members []models.TaskMember
+	err := r.db.Preload("User").Where("task_id = ?", taskID).
+		Order("created_at ASC").Order("user_id ASC").
+		Find(&members).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get task members")
+		return nil, fmt.Errorf("failed to get task members: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *taskMemberRepository) GetUserIDs(taskID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.Model(&models.TaskMember{}).Where("task_id = ?", taskID).Pluck("user_id", &userIDs).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get task member IDs")
+		return nil, fmt.Errorf("failed to get task member ids: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+func (r *taskMemberRepository) Delete(taskID uuid.UUID, userID uuid.UUID) error {
+	result := r.db.Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskMember{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to delete task member")
+		return fmt.Errorf("failed to delete task member: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("task_id", taskID).Warn("Task member not found for deletion")
+		return fmt.Errorf("task member not found")
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"task_id": taskID,
+		"user_id": userID,
+	}).Info("Task member deleted successfully")
+	return nil
+}
@@ -1,7 +1,10 @@
 package repositories
 
 import (
+	"go-corenglish/internal/enum"
 	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -16,28 +19,172 @@ func (m *MockBookRepository) Create(task *models.Task) error {
 	return args.Error(0)
 }
 
-func (m *MockBookRepository) GetByID(id uuid.UUID, userID uuid.UUID) (*models.Task, error) {
+func (m *MockBookRepository) CreateAll(tasks []*models.Task) error {
+	args := m.Called(tasks)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) GetByID(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Task, error) {
+	args := m.Called(id, userID, workspaceID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Task), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBookRepository) GetAccessible(id uuid.UUID, userID uuid.UUID) (*models.Task, error) {
 	args := m.Called(id, userID)
-	if args.Get(0) != nil || args.Get(1) != nil {
+	if args.Get(0) != nil {
 		return args.Get(0).(*models.Task), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBookRepository) GetAll(userID uuid.UUID, status string, page, limit int) ([]models.Task, int64, error) {
-	args := m.Called(userID, status, page, limit)
+func (m *MockBookRepository) GetAll(userID uuid.UUID, filter *params.TaskFilter) ([]models.Task, int64, error) {
+	args := m.Called(userID, filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Task), args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockBookRepository) GetSubtasks(parentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]models.Task, error) {
+	args := m.Called(parentID, userID, workspaceID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.Task), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBookRepository) GetAncestorIDs(id uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).([]uuid.UUID), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBookRepository) GetSubtreeHeight(id uuid.UUID) (int, error) {
+	args := m.Called(id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBookRepository) GetSubtaskProgress(parentIDs []uuid.UUID) (map[uuid.UUID]SubtaskProgress, error) {
+	args := m.Called(parentIDs)
+	if args.Get(0) != nil {
+		return args.Get(0).(map[uuid.UUID]SubtaskProgress), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBookRepository) Update(task *models.Task, columns ...string) error {
+	args := m.Called(task, columns)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) UpdatePosition(task *models.Task, columns ...string) error {
+	args := m.Called(task, columns)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) Edit(task *models.Task, edit TaskEdit) error {
+	args := m.Called(task, edit)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) Delete(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) error {
+	args := m.Called(id, userID, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) GetTrash(userID uuid.UUID, workspaceID uuid.UUID, page, limit int) ([]models.Task, int64, error) {
+	args := m.Called(userID, workspaceID, page, limit)
 	if args.Get(0) != nil {
 		return args.Get(0).([]models.Task), args.Get(1).(int64), args.Error(2)
 	}
 	return nil, 0, args.Error(2)
 }
 
-func (m *MockBookRepository) Update(task *models.Task) error {
+func (m *MockBookRepository) CountTrashedByStatus(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (map[enum.TaskStatus]int64, error) {
+	args := m.Called(id, userID, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[enum.TaskStatus]int64), args.Error(1)
+}
+
+func (m *MockBookRepository) Restore(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) error {
+	args := m.Called(id, userID, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) PurgeDeleted(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBookRepository) SetArchived(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, archivedAt *time.Time) error {
+	args := m.Called(id, userID, workspaceID, archivedAt)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) ArchiveFinishedTasks() ([]uuid.UUID, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).([]uuid.UUID), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBookRepository) GetLastPosition(userID uuid.UUID, workspaceID uuid.UUID) (string, error) {
+	args := m.Called(userID, workspaceID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockBookRepository) GetAdjacentPosition(userID uuid.UUID, workspaceID uuid.UUID, position string, excludeID uuid.UUID, before bool) (string, error) {
+	args := m.Called(userID, workspaceID, position, excludeID, before)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockBookRepository) CountByStatus(userID uuid.UUID, workspaceID uuid.UUID, status enum.TaskStatus) (int64, error) {
+	args := m.Called(userID, workspaceID, status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBookRepository) CreateOccurrence(task *models.Task) (bool, error) {
 	args := m.Called(task)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBookRepository) GetRecurringSeriesHeads() ([]models.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockBookRepository) EndRecurrenceSeries(seriesID uuid.UUID, afterIndex int) error {
+	args := m.Called(seriesID, afterIndex)
 	return args.Error(0)
 }
 
-func (m *MockBookRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
-	args := m.Called(id, userID)
+func (m *MockBookRepository) ClaimReminder(id uuid.UUID) (*models.Task, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
+func (m *MockBookRepository) ReleaseReminder(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) GetPendingReminders() ([]models.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockBookRepository) CreateClones(clones []TaskClone) error {
+	args := m.Called(clones)
 	return args.Error(0)
 }
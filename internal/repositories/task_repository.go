@@ -1,20 +1,119 @@
 package repositories
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"go-corenglish/internal/enum"
 	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepository interface {
 	Create(task *models.Task) error
-	GetByID(id uuid.UUID, userID uuid.UUID) (*models.Task, error)
-	GetAll(userID uuid.UUID, status string, page, limit int) ([]models.Task, int64, error)
-	Update(task *models.Task) error
-	Delete(id uuid.UUID, userID uuid.UUID) error
+	CreateAll(tasks []*models.Task) error
+	GetByID(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Task, error)
+	GetAccessible(id uuid.UUID, userID uuid.UUID) (*models.Task, error)
+	GetAll(userID uuid.UUID, filter *params.TaskFilter) ([]models.Task, int64, error)
+	GetSubtasks(parentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]models.Task, error)
+	GetAncestorIDs(id uuid.UUID) ([]uuid.UUID, error)
+	GetSubtreeHeight(id uuid.UUID) (int, error)
+	GetSubtaskProgress(parentIDs []uuid.UUID) (map[uuid.UUID]SubtaskProgress, error)
+	Update(task *models.Task, columns ...string) error
+	UpdatePosition(task *models.Task, columns ...string) error
+	Edit(task *models.Task, edit TaskEdit) error
+	Delete(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) error
+	GetTrash(userID uuid.UUID, workspaceID uuid.UUID, page, limit int) ([]models.Task, int64, error)
+	CountTrashedByStatus(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (map[enum.TaskStatus]int64, error)
+	Restore(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) error
+	PurgeDeleted(before time.Time) (int64, error)
+	SetArchived(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, archivedAt *time.Time) error
+	ArchiveFinishedTasks() ([]uuid.UUID, error)
+	GetLastPosition(userID uuid.UUID, workspaceID uuid.UUID) (string, error)
+	CountByStatus(userID uuid.UUID, workspaceID uuid.UUID, status enum.TaskStatus) (int64, error)
+	GetAdjacentPosition(userID uuid.UUID, workspaceID uuid.UUID, position string, excludeID uuid.UUID, before bool) (string, error)
+	CreateOccurrence(task *models.Task) (bool, error)
+	GetRecurringSeriesHeads() ([]models.Task, error)
+	EndRecurrenceSeries(seriesID uuid.UUID, afterIndex int) error
+	ClaimReminder(id uuid.UUID) (*models.Task, error)
+	ReleaseReminder(id uuid.UUID) error
+	GetPendingReminders() ([]models.Task, error)
+	CreateClones(clones []TaskClone) error
+}
+
+// ErrTaskNotFound is returned when a task does not exist or is not visible
+// to the user in the given workspace.
+var ErrTaskNotFound = errors.New("task not found")
+
+// taskSortColumns whitelists the keys accepted by sort= and maps them to
+// the columns they order by. Only these columns may ever reach ORDER BY.
+var taskSortColumns = map[string]string{
+	"title":      "title",
+	"status":     "(SELECT position FROM task_statuses WHERE task_statuses.name = tasks.status)",
+	"priority":   "priority",
+	"due_at":     "due_at",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"position":   "position",
+}
+
+// IsValidTaskSortField reports whether field may be used as a sort key.
+func IsValidTaskSortField(field string) bool {
+	_, ok := taskSortColumns[field]
+	return ok
+}
+
+// doneStatuses selects every status whose category is DONE.
+const doneStatuses = "SELECT name FROM task_statuses WHERE category = 'DONE'"
+
+// toDoStatuses selects every status whose category is TO_DO.
+const toDoStatuses = "SELECT name FROM task_statuses WHERE category = 'TO_DO'"
+
+// memberWorkspace matches the tasks in a workspace the user belongs to, so
+// that leaving a workspace takes away access to everything inside it. It
+// takes the user ID once.
+const memberWorkspace = "tasks.workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = ?)"
+
+// accessibleTask matches the tasks a user owns or is a member of, within the
+// workspaces they belong to. It takes the user ID three times.
+const accessibleTask = "(tasks.user_id = ? OR tasks.id IN (SELECT task_id FROM task_members WHERE user_id = ?)) AND " + memberWorkspace
+
+// editableTask matches the tasks a user owns or is an editor of, within the
+// workspaces they belong to. It takes the user ID three times.
+const editableTask = "(tasks.user_id = ? OR tasks.id IN (SELECT task_id FROM task_members WHERE user_id = ? AND role = 'EDITOR')) AND " + memberWorkspace
+
+// maxHierarchyWalk bounds the recursive hierarchy queries so that corrupted
+// data can never make them loop forever.
+const maxHierarchyWalk = 100
+
+// SubtaskProgress counts the direct subtasks of a parent task.
+type SubtaskProgress struct {
+	ParentID uuid.UUID
+	Total    int64
+	Done     int64
+}
+
+// TaskEdit lists what Edit writes.
+type TaskEdit struct {
+	// Columns are the user-editable columns to write.
+	Columns []string
+	// Recurrence also writes the recurrence fields, for edits that start or
+	// leave a series.
+	Recurrence bool
+	// Labels replace the labels of the task when not nil.
+	Labels *[]models.Label
+}
+
+// TaskClone is a new task that copies SourceID.
+type TaskClone struct {
+	SourceID uuid.UUID
+	Task     *models.Task
 }
 
 type taskRepository struct {
@@ -39,13 +138,45 @@ func (r *taskRepository) Create(task *models.Task) error {
 	return nil
 }
 
-func (r *taskRepository) GetByID(id uuid.UUID, userID uuid.UUID) (*models.Task, error) {
+// CreateAll creates the tasks, with their labels, in a single transaction.
+func (r *taskRepository) CreateAll(tasks []*models.Task) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, task := range tasks {
+			if err := tx.Create(task).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to create tasks")
+		return fmt.Errorf("failed to create tasks: %w", err)
+	}
+
+	r.logger.WithField("tasks", len(tasks)).Info("Tasks created successfully")
+	return nil
+}
+
+// GetByID returns a task of the given workspace that the user owns or is a
+// member of. A task of another workspace is reported as not found.
+func (r *taskRepository) GetByID(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Task, error) {
+	return r.getTask(r.db.Where("id = ? AND tasks.workspace_id = ? AND "+accessibleTask, id, workspaceID, userID, userID, userID), id)
+}
+
+// GetAccessible returns a task the user owns or is a member of, in any of
+// their workspaces. Requests made in a workspace use GetByID; this is for
+// lookups made on the user's behalf, such as opening a share link.
+func (r *taskRepository) GetAccessible(id uuid.UUID, userID uuid.UUID) (*models.Task, error) {
+	return r.getTask(r.db.Where("id = ? AND "+accessibleTask, id, userID, userID, userID), id)
+}
+
+func (r *taskRepository) getTask(query *gorm.DB, id uuid.UUID) (*models.Task, error) {
 	var task models.Task
-	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&task).Error
+	err := query.Preload("Labels", orderLabelsByName).First(&task).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			r.logger.WithField("task_id", id).Warn("Task not found")
-			return nil, fmt.Errorf("task not found")
+			return nil, ErrTaskNotFound
 		}
 		r.logger.WithError(err).WithField("task_id", id).Error("Failed to get task")
 		return nil, fmt.Errorf("failed to get task: %w", err)
@@ -54,16 +185,64 @@ func (r *taskRepository) GetByID(id uuid.UUID, userID uuid.UUID) (*models.Task,
 	return &task, nil
 }
 
-func (r *taskRepository) GetAll(userID uuid.UUID, status string, page, limit int) ([]models.Task, int64, error) {
+// GetAll lists the tasks the user owns together with the tasks shared with
+// them, unless filter.Shared narrows it down to one of the two.
+func (r *taskRepository) GetAll(userID uuid.UUID, filter *params.TaskFilter) ([]models.Task, int64, error) {
 	var tasks []models.Task
 	var total int64
 
-	offset := (page - 1) * limit
+	offset := (filter.Page - 1) * filter.Limit
 
-	query := r.db.Where("user_id = ?", userID)
+	var query *gorm.DB
+	switch {
+	case filter.Shared == nil:
+		query = r.db.Where(accessibleTask, userID, userID, userID)
+	case *filter.Shared:
+		query = r.db.Where("tasks.user_id <> ? AND "+accessibleTask, userID, userID, userID, userID)
+	default:
+		query = r.db.Where("user_id = ? AND "+memberWorkspace, userID, userID)
+	}
+	query = query.Where("tasks.workspace_id = ?", filter.WorkspaceID)
+	if filter.ProjectID != nil {
+		query = query.Where("tasks.project_id = ?", *filter.ProjectID)
+	}
 
-	if status != "" {
-		query = query.Where("status = ?", status)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Priority != "" {
+		query = query.Where("priority = ?", filter.Priority)
+	}
+	if !filter.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	if !filter.IncludeSnoozed {
+		query = query.Where("(snoozed_until IS NULL OR snoozed_until <= NOW())")
+	}
+	if filter.FocusDate != nil {
+		query = query.Where("focus_date = ?", filter.FocusDate.Format("2006-01-02"))
+	}
+	if len(filter.Labels) > 0 {
+		// Label names are only unique per user, so only the user's own
+		// labels and those of the task's owner count.
+		labelled := r.db.Table("task_labels").
+			Select("task_labels.task_id").
+			Joins("JOIN labels ON labels.id = task_labels.label_id").
+			Where("labels.name IN ? AND (labels.user_id = ? OR labels.user_id = tasks.user_id)", filter.Labels, userID)
+		if filter.LabelMatch == params.LabelMatchAll {
+			labelled = labelled.Group("task_labels.task_id").
+				Having("COUNT(DISTINCT labels.name) = ?", len(filter.Labels))
+		}
+		query = query.Where("id IN (?)", labelled)
+	}
+	if filter.DueBefore != nil {
+		query = query.Where("due_at < ?", *filter.DueBefore)
+	}
+	if filter.DueAfter != nil {
+		query = query.Where("due_at > ?", *filter.DueAfter)
+	}
+	if filter.Overdue {
+		query = query.Where("due_at < NOW() AND status NOT IN (" + doneStatuses + ")")
 	}
 
 	if err := query.Model(&models.Task{}).Count(&total).Error; err != nil {
@@ -71,16 +250,20 @@ func (r *taskRepository) GetAll(userID uuid.UUID, status string, page, limit int
 		return nil, 0, fmt.Errorf("failed to count tasks: %w", err)
 	}
 
-	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+	for _, order := range taskOrderBy(filter.Sort) {
+		query = query.Order(order)
+	}
+
+	if err := query.Preload("Labels", orderLabelsByName).Offset(offset).Limit(filter.Limit).Find(&tasks).Error; err != nil {
 		r.logger.WithError(err).Error("Failed to get tasks")
 		return nil, 0, fmt.Errorf("failed to get tasks: %w", err)
 	}
 
 	r.logger.WithFields(logrus.Fields{
 		"user_id": userID,
-		"status":  status,
-		"page":    page,
-		"limit":   limit,
+		"status":  filter.Status,
+		"page":    filter.Page,
+		"limit":   filter.Limit,
 		"total":   total,
 		"count":   len(tasks),
 	}).Info("Tasks retrieved successfully")
@@ -88,24 +271,243 @@ func (r *taskRepository) GetAll(userID uuid.UUID, status string, page, limit int
 	return tasks, total, nil
 }
 
-func (r *taskRepository) Update(task *models.Task) error {
-	result := r.db.Model(task).Where("id = ? AND user_id = ?", task.ID, task.UserID).Updates(task)
-	if result.Error != nil {
-		r.logger.WithError(result.Error).WithField("task_id", task.ID).Error("Failed to update task")
-		return fmt.Errorf("failed to update task: %w", result.Error)
+// taskOrderBy translates sort keys into ORDER BY clauses. Only whitelisted
+// column names are interpolated; unknown keys are skipped. created_at DESC
+// and id are always appended so pagination stays stable when keys tie.
+func taskOrderBy(sort []params.SortField) []string {
+	orders := make([]string, 0, len(sort)+2)
+	for _, field := range sort {
+		column, ok := taskSortColumns[field.Field]
+		if !ok {
+			continue
+		}
+
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		orders = append(orders, fmt.Sprintf("%s %s NULLS LAST", column, direction))
 	}
 
-	if result.RowsAffected == 0 {
-		r.logger.WithField("task_id", task.ID).Warn("Task not found for update")
-		return fmt.Errorf("task not found")
+	return append(orders, "created_at DESC", "id ASC")
+}
+
+func (r *taskRepository) GetSubtasks(parentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Preload("Labels", orderLabelsByName).
+		Where("parent_id = ? AND tasks.workspace_id = ? AND "+accessibleTask, parentID, workspaceID, userID, userID, userID).
+		Order("position ASC").
+		Order("created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("parent_id", parentID).Error("Failed to get subtasks")
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
 	}
 
+	return tasks, nil
+}
+
+// GetAncestorIDs returns the IDs of every ancestor of the task, nearest first.
+func (r *taskRepository) GetAncestorIDs(id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, 0 AS depth FROM tasks WHERE id = ?
+			UNION ALL
+			SELECT t.id, t.parent_id, a.depth + 1
+			FROM tasks t
+			JOIN ancestors a ON t.id = a.parent_id
+			WHERE a.depth < ?
+		)
+		SELECT id FROM ancestors WHERE depth > 0 ORDER BY depth ASC`,
+		id, maxHierarchyWalk,
+	).Scan(&ids).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", id).Error("Failed to get task ancestors")
+		return nil, fmt.Errorf("failed to get task ancestors: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetSubtreeHeight returns how many levels of subtasks exist below the task.
+func (r *taskRepository) GetSubtreeHeight(id uuid.UUID) (int, error) {
+	var height int
+	err := r.db.Raw(`
+		WITH RECURSIVE descendants AS (
+			SELECT id, 0 AS depth FROM tasks WHERE id = ?
+			UNION ALL
+			SELECT t.id, d.depth + 1
+			FROM tasks t
+			JOIN descendants d ON t.parent_id = d.id
+			WHERE d.depth < ? AND t.deleted_at IS NULL
+		)
+		SELECT COALESCE(MAX(depth), 0) FROM descendants`,
+		id, maxHierarchyWalk,
+	).Scan(&height).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", id).Error("Failed to get subtree height")
+		return 0, fmt.Errorf("failed to get subtree height: %w", err)
+	}
+
+	return height, nil
+}
+
+func (r *taskRepository) GetSubtaskProgress(parentIDs []uuid.UUID) (map[uuid.UUID]SubtaskProgress, error) {
+	progress := make(map[uuid.UUID]SubtaskProgress)
+	if len(parentIDs) == 0 {
+		return progress, nil
+	}
+
+	var rows []SubtaskProgress
+	err := r.db.Model(&models.Task{}).
+		Select("parent_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE status IN ("+doneStatuses+")) AS done").
+		Where("parent_id IN ?", parentIDs).
+		Group("parent_id").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get subtask progress")
+		return nil, fmt.Errorf("failed to get subtask progress: %w", err)
+	}
+
+	for _, row := range rows {
+		progress[row.ParentID] = row
+	}
+	return progress, nil
+}
+
+// editableTaskColumns are the columns an update may write. Everything else
+// is owned by a dedicated method: reminder_sent_at by the reminder methods,
+// archived_at by SetArchived and the archive job, position by UpdatePosition
+// and the recurrence fields by Edit and EndRecurrenceSeries.
+var editableTaskColumns = map[string]bool{
+	"title":            true,
+	"description":      true,
+	"status":           true,
+	"priority":         true,
+	"due_at":           true,
+	"due_all_day":      true,
+	"started_at":       true,
+	"completed_at":     true,
+	"parent_id":        true,
+	"project_id":       true,
+	"remind_at":        true,
+	"reminder_channel": true,
+	"snoozed_until":    true,
+	"focus_date":       true,
+}
+
+// recurrenceColumns are the columns that describe a task's recurring series.
+var recurrenceColumns = []string{"recurrence_rule", "recurrence_series_id", "recurrence_start", "recurrence_index"}
+
+// Update writes the given columns of a task and nothing else, so that
+// changes made in the meantime by others, such as a reminder being sent,
+// are kept. Writing remind_at re-arms the reminder. The caller has already
+// checked that the user may edit the task; the row is matched by its
+// owner, which never changes.
+func (r *taskRepository) Update(task *models.Task, columns ...string) error {
+	if err := r.updateColumns(r.db, task, columns); err != nil {
+		return r.updateError(task, err)
+	}
+
+	r.logger.WithField("task_id", task.ID).Info("Task updated successfully")
+	return nil
+}
+
+// UpdatePosition writes the task's position together with the given
+// columns.
+func (r *taskRepository) UpdatePosition(task *models.Task, columns ...string) error {
+	if err := r.updateColumns(r.db, task, columns, "position"); err != nil {
+		return r.updateError(task, err)
+	}
+
+	r.logger.WithField("task_id", task.ID).Info("Task position updated successfully")
+	return nil
+}
+
+// Edit writes the columns of an edit like Update, together with the
+// recurrence fields and labels when asked to, in a single transaction.
+func (r *taskRepository) Edit(task *models.Task, edit TaskEdit) error {
+	var owned []string
+	if edit.Recurrence {
+		owned = recurrenceColumns
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.updateColumns(tx, task, edit.Columns, owned...); err != nil {
+			return err
+		}
+		if edit.Labels == nil {
+			return nil
+		}
+		return tx.Model(task).Omit("Labels.*").Association("Labels").Replace(*edit.Labels)
+	})
+	if err != nil {
+		return r.updateError(task, err)
+	}
+
+	if edit.Labels != nil {
+		task.Labels = *edit.Labels
+	}
 	r.logger.WithField("task_id", task.ID).Info("Task updated successfully")
 	return nil
 }
 
-func (r *taskRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
-	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Task{})
+// updateColumns writes the given editable columns together with the owned
+// ones. It returns gorm.ErrRecordNotFound when the task does not exist.
+func (r *taskRepository) updateColumns(db *gorm.DB, task *models.Task, columns []string, owned ...string) error {
+	selected := append([]string{}, owned...)
+	for _, column := range columns {
+		if !editableTaskColumns[column] {
+			return fmt.Errorf("column %s cannot be updated", column)
+		}
+		selected = append(selected, column)
+		if column == "remind_at" {
+			task.ReminderSentAt = nil
+			selected = append(selected, "reminder_sent_at")
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	result := db.Model(task).Select(selected).
+		Where("id = ? AND user_id = ?", task.ID, task.UserID).Updates(task)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *taskRepository) updateError(task *models.Task, err error) error {
+	if err == gorm.ErrRecordNotFound {
+		r.logger.WithField("task_id", task.ID).Warn("Task not found for update")
+		return ErrTaskNotFound
+	}
+
+	r.logger.WithError(err).WithField("task_id", task.ID).Error("Failed to update task")
+	return fmt.Errorf("failed to update task: %w", err)
+}
+
+// Delete moves the task and its whole subtree to the trash of the task's
+// owner; editors may delete it too. All moved rows share the same
+// deleted_at so that Restore can bring them back together.
+func (r *taskRepository) Delete(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) error {
+	result := r.db.Exec(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id = ? AND workspace_id = ? AND `+editableTask+` AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id
+			FROM tasks t
+			JOIN subtree s ON t.parent_id = s.id
+			WHERE t.deleted_at IS NULL
+		)
+		UPDATE tasks SET deleted_at = ? WHERE id IN (SELECT id FROM subtree)`,
+		id, workspaceID, userID, userID, userID, time.Now().UTC(),
+	)
 	if result.Error != nil {
 		r.logger.WithError(result.Error).WithField("task_id", id).Error("Failed to delete task")
 		return fmt.Errorf("failed to delete task: %w", result.Error)
@@ -113,9 +515,454 @@ func (r *taskRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
 
 	if result.RowsAffected == 0 {
 		r.logger.WithField("task_id", id).Warn("Task not found for deletion")
-		return fmt.Errorf("task not found")
+		return ErrTaskNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"task_id": id,
+		"count":   result.RowsAffected,
+	}).Info("Task moved to trash successfully")
+	return nil
+}
+
+func (r *taskRepository) GetTrash(userID uuid.UUID, workspaceID uuid.UUID, page, limit int) ([]models.Task, int64, error) {
+	var tasks []models.Task
+	var total int64
+
+	query := r.db.Unscoped().Model(&models.Task{}).Where("user_id = ? AND workspace_id = ? AND deleted_at IS NOT NULL", userID, workspaceID)
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.WithError(err).Error("Failed to count trashed tasks")
+		return nil, 0, fmt.Errorf("failed to count trashed tasks: %w", err)
+	}
+
+	err := query.Preload("Labels", orderLabelsByName).
+		Order("deleted_at DESC, id ASC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&tasks).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get trashed tasks")
+		return nil, 0, fmt.Errorf("failed to get trashed tasks: %w", err)
+	}
+
+	return tasks, total, nil
+}
+
+// trashedSubtree selects, as subtree, a trashed task of the owner and the
+// subtasks trashed along with it. It takes the task, user, workspace and user
+// IDs.
+const trashedSubtree = `
+	WITH RECURSIVE subtree AS (
+		SELECT id, deleted_at FROM tasks WHERE id = ? AND user_id = ? AND workspace_id = ? AND ` + memberWorkspace + ` AND deleted_at IS NOT NULL
+		UNION ALL
+		SELECT t.id, t.deleted_at
+		FROM tasks t
+		JOIN subtree s ON t.parent_id = s.id
+		WHERE t.deleted_at = s.deleted_at
+	)`
+
+// CountTrashedByStatus counts, per status, the active (not archived) tasks
+// that Restore would bring back.
+func (r *taskRepository) CountTrashedByStatus(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (map[enum.TaskStatus]int64, error) {
+	var rows []struct {
+		Status enum.TaskStatus
+		Count  int64
+	}
+	err := r.db.Raw(trashedSubtree+`
+		SELECT status, COUNT(*) AS count
+		FROM tasks
+		WHERE id IN (SELECT id FROM subtree) AND archived_at IS NULL
+		GROUP BY status`,
+		id, userID, workspaceID, userID,
+	).Scan(&rows).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", id).Error("Failed to count trashed tasks")
+		return nil, fmt.Errorf("failed to count trashed tasks: %w", err)
+	}
+
+	counts := make(map[enum.TaskStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// Restore brings a trashed task back together with the subtasks that were
+// trashed along with it. If its parent is still in the trash the task is
+// restored at the top level.
+func (r *taskRepository) Restore(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(trashedSubtree+`
+			UPDATE tasks SET deleted_at = NULL WHERE id IN (SELECT id FROM subtree)`,
+			id, userID, workspaceID, userID,
+		)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Exec(`
+			UPDATE tasks SET parent_id = NULL
+			WHERE id = ? AND parent_id IN (SELECT id FROM tasks WHERE deleted_at IS NOT NULL)`,
+			id,
+		).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("task_id", id).Warn("Task not found in trash")
+			return fmt.Errorf("%w in trash", ErrTaskNotFound)
+		}
+		r.logger.WithError(err).WithField("task_id", id).Error("Failed to restore task")
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	r.logger.WithField("task_id", id).Info("Task restored successfully")
+	return nil
+}
+
+// PurgeDeleted permanently removes tasks trashed before the given time.
+func (r *taskRepository) PurgeDeleted(before time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&models.Task{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).Error("Failed to purge trashed tasks")
+		return 0, fmt.Errorf("failed to purge trashed tasks: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// SetArchived archives the task at archivedAt, or unarchives it when nil.
+// The owner and editors of the task may do so.
+func (r *taskRepository) SetArchived(id uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, archivedAt *time.Time) error {
+	result := r.db.Model(&models.Task{}).
+		Where("id = ? AND tasks.workspace_id = ? AND "+editableTask, id, workspaceID, userID, userID, userID).
+		Update("archived_at", archivedAt)
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("task_id", id).Error("Failed to update task archive state")
+		return fmt.Errorf("failed to update task archive state: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("task_id", id).Warn("Task not found for archiving")
+		return ErrTaskNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"task_id":  id,
+		"archived": archivedAt != nil,
+	}).Info("Task archive state updated successfully")
+	return nil
+}
+
+// ArchiveFinishedTasks applies every user's auto-archive policy: finished
+// tasks completed more than auto_archive_days ago are archived. It returns
+// the IDs of the users whose tasks changed.
+func (r *taskRepository) ArchiveFinishedTasks() ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.Raw(`
+		WITH archived AS (
+			UPDATE tasks t SET archived_at = NOW()
+			FROM users u
+			WHERE t.user_id = u.id
+				AND u.auto_archive_days > 0
+				AND t.archived_at IS NULL
+				AND t.deleted_at IS NULL
+				AND t.status IN (` + doneStatuses + `)
+				AND COALESCE(t.completed_at, t.updated_at) < NOW() - MAKE_INTERVAL(days => u.auto_archive_days)
+			RETURNING t.user_id
+		)
+		SELECT DISTINCT user_id FROM archived`,
+	).Scan(&userIDs).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to archive finished tasks")
+		return nil, fmt.Errorf("failed to archive finished tasks: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+func orderLabelsByName(db *gorm.DB) *gorm.DB {
+	return db.Order("labels.name ASC")
+}
+
+// GetLastPosition returns the highest position of the user's tasks in the
+// workspace, trashed ones included so that restored tasks never share a
+// position. It returns an empty string when there are no such tasks. Every
+// workspace has its own manual order, like its own board.
+func (r *taskRepository) GetLastPosition(userID uuid.UUID, workspaceID uuid.UUID) (string, error) {
+	var position sql.NullString
+	err := r.db.Unscoped().Model(&models.Task{}).
+		Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
+		Select("MAX(position)").
+		Row().Scan(&position)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get last task position")
+		return "", fmt.Errorf("failed to get last task position: %w", err)
+	}
+
+	return position.String, nil
+}
+
+// GetAdjacentPosition returns the position of the user's task in the
+// workspace directly before (or after) the given position, ignoring
+// excludeID. It returns an empty string when there is no such task.
+func (r *taskRepository) GetAdjacentPosition(userID uuid.UUID, workspaceID uuid.UUID, position string, excludeID uuid.UUID, before bool) (string, error) {
+	query := r.db.Model(&models.Task{}).Where("user_id = ? AND workspace_id = ? AND id <> ?", userID, workspaceID, excludeID)
+	if before {
+		query = query.Where("position < ?", position).Select("MAX(position)")
+	} else {
+		query = query.Where("position > ?", position).Select("MIN(position)")
+	}
+
+	var adjacent sql.NullString
+	if err := query.Row().Scan(&adjacent); err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get adjacent task position")
+		return "", fmt.Errorf("failed to get adjacent task position: %w", err)
+	}
+
+	return adjacent.String, nil
+}
+
+// CountByStatus counts the user's active (not archived) tasks in a status
+// within the workspace, which is what its board shows against a WIP limit.
+func (r *taskRepository) CountByStatus(userID uuid.UUID, workspaceID uuid.UUID, status enum.TaskStatus) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Task{}).
+		Where("user_id = ? AND workspace_id = ? AND status = ? AND archived_at IS NULL", userID, workspaceID, status).
+		Count(&count).Error
+	if err != nil {
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"user_id": userID,
+			"status":  status,
+		}).Error("Failed to count tasks by status")
+		return 0, fmt.Errorf("failed to count tasks by status: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateOccurrence inserts an occurrence of a recurring series together with
+// its labels. It returns false without error when the occurrence already
+// exists, so concurrent callers never create it twice.
+func (r *taskRepository) CreateOccurrence(task *models.Task) (bool, error) {
+	labels := task.Labels
+	created := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "recurrence_series_id"}, {Name: "recurrence_index"}},
+			DoNothing: true,
+		}).Create(task)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		created = true
+		if len(labels) == 0 {
+			return nil
+		}
+		return tx.Model(task).Omit("Labels.*").Association("Labels").Replace(labels)
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("series_id", task.RecurrenceSeriesID).Error("Failed to create task occurrence")
+		return false, fmt.Errorf("failed to create task occurrence: %w", err)
+	}
+
+	if created {
+		r.logger.WithFields(logrus.Fields{
+			"task_id":   task.ID,
+			"series_id": task.RecurrenceSeriesID,
+			"index":     task.RecurrenceIndex,
+		}).Info("Task occurrence created successfully")
+	}
+	return created, nil
+}
+
+// GetRecurringSeriesHeads returns the latest occurrence of every recurring
+// series. A series whose latest occurrence is in the trash or no longer has
+// a rule has ended and is left out.
+func (r *taskRepository) GetRecurringSeriesHeads() ([]models.Task, error) {
+	latest := r.db.Unscoped().Model(&models.Task{}).
+		Select("DISTINCT ON (recurrence_series_id) id").
+		Where("recurrence_series_id IS NOT NULL").
+		Order("recurrence_series_id, recurrence_index DESC")
+
+	var tasks []models.Task
+	err := r.db.Preload("Labels", orderLabelsByName).
+		Where("id IN (?) AND recurrence_rule IS NOT NULL", latest).
+		Find(&tasks).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get recurring series")
+		return nil, fmt.Errorf("failed to get recurring series: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// EndRecurrenceSeries stops a series after the given occurrence: later
+// occurrences that have not been started yet are moved to the trash, and the
+// others lose their rule so that they no longer spawn new occurrences.
+func (r *taskRepository) EndRecurrenceSeries(seriesID uuid.UUID, afterIndex int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("recurrence_series_id = ? AND recurrence_index > ? AND status IN ("+toDoStatuses+")", seriesID, afterIndex).
+			Delete(&models.Task{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Task{}).
+			Where("recurrence_series_id = ? AND recurrence_index > ?", seriesID, afterIndex).
+			Update("recurrence_rule", nil).Error
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("series_id", seriesID).Error("Failed to end recurring series")
+		return fmt.Errorf("failed to end recurring series: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"series_id":   seriesID,
+		"after_index": afterIndex,
+	}).Info("Recurring series ended successfully")
+	return nil
+}
+
+// ClaimReminder marks the task's reminder as sent if it is due and still
+// pending, and returns the task. It returns nil when there is nothing to
+// send, e.g. because another worker claimed it first or the task was
+// finished, deleted or rescheduled in the meantime.
+func (r *taskRepository) ClaimReminder(id uuid.UUID) (*models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Raw(`
+		UPDATE tasks SET reminder_sent_at = NOW()
+		WHERE id = ?
+			AND remind_at <= NOW()
+			AND reminder_sent_at IS NULL
+			AND deleted_at IS NULL
+			AND status NOT IN (`+doneStatuses+`)
+		RETURNING *`,
+		id,
+	).Scan(&tasks).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", id).Error("Failed to claim task reminder")
+		return nil, fmt.Errorf("failed to claim task reminder: %w", err)
+	}
+
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	return &tasks[0], nil
+}
+
+// ReleaseReminder marks a claimed reminder as pending again so that it can
+// be retried.
+func (r *taskRepository) ReleaseReminder(id uuid.UUID) error {
+	err := r.db.Model(&models.Task{}).Where("id = ?", id).UpdateColumn("reminder_sent_at", nil).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("task_id", id).Error("Failed to release task reminder")
+		return fmt.Errorf("failed to release task reminder: %w", err)
+	}
+
+	return nil
+}
+
+// GetPendingReminders returns the ID and reminder time of every task whose
+// reminder has not been sent yet.
+func (r *taskRepository) GetPendingReminders() ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Select("id", "remind_at").
+		Where("remind_at IS NOT NULL AND reminder_sent_at IS NULL AND status NOT IN (" + doneStatuses + ")").
+		Find(&tasks).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get pending task reminders")
+		return nil, fmt.Errorf("failed to get pending task reminders: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// CreateClones creates the given copies with their labels and copies the
+// checklist items and blockers of every source onto its copy, all in a
+// single transaction. Parents must come before their subtasks. A blocker
+// that is cloned in the same call is replaced by its copy.
+func (r *taskRepository) CreateClones(clones []TaskClone) error {
+	if len(clones) == 0 {
+		return nil
+	}
+
+	cloneIDs := make(map[uuid.UUID]uuid.UUID, len(clones))
+	sourceIDs := make([]uuid.UUID, len(clones))
+	for i, clone := range clones {
+		if clone.Task.ID == uuid.Nil {
+			clone.Task.ID = uuid.New()
+		}
+		cloneIDs[clone.SourceID] = clone.Task.ID
+		sourceIDs[i] = clone.SourceID
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, clone := range clones {
+			if err := tx.Omit(clause.Associations).Create(clone.Task).Error; err != nil {
+				return err
+			}
+			if len(clone.Task.Labels) > 0 {
+				if err := tx.Model(clone.Task).Omit("Labels.*").Association("Labels").Replace(clone.Task.Labels); err != nil {
+					return err
+				}
+			}
+		}
+
+		var items []models.ChecklistItem
+		if err := tx.Where("task_id IN ?", sourceIDs).Order("position ASC").Find(&items).Error; err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			copies := make([]models.ChecklistItem, len(items))
+			for i, item := range items {
+				copies[i] = models.ChecklistItem{
+					TaskID:   cloneIDs[item.TaskID],
+					Text:     item.Text,
+					Position: item.Position,
+				}
+			}
+			if err := tx.Omit(clause.Associations).Create(&copies).Error; err != nil {
+				return err
+			}
+		}
+
+		var dependencies []models.TaskDependency
+		if err := tx.Where("task_id IN ?", sourceIDs).Find(&dependencies).Error; err != nil {
+			return err
+		}
+		if len(dependencies) > 0 {
+			copies := make([]models.TaskDependency, len(dependencies))
+			for i, dependency := range dependencies {
+				blockedByID := dependency.BlockedByID
+				if cloneID, ok := cloneIDs[blockedByID]; ok {
+					blockedByID = cloneID
+				}
+				copies[i] = models.TaskDependency{
+					TaskID:      cloneIDs[dependency.TaskID],
+					BlockedByID: blockedByID,
+				}
+			}
+			if err := tx.Omit(clause.Associations).Create(&copies).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("source_id", clones[0].SourceID).Error("Failed to clone tasks")
+		return fmt.Errorf("failed to clone tasks: %w", err)
 	}
 
-	r.logger.WithField("task_id", id).Info("Task deleted successfully")
+	r.logger.WithField("tasks", len(clones)).Info("Tasks cloned successfully")
 	return nil
 }
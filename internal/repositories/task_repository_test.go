@@ -0,0 +1,311 @@
+package repositories
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newMockDB opens a GORM connection backed by sqlmock. Every expected
+// statement must be declared on the returned mock.
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		sqlDB.Close()
+	})
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func newTestTaskRepository(t *testing.T) (TaskRepository, sqlmock.Sqlmock) {
+	db, mock := newMockDB(t)
+	return NewTaskRepository(db, newTestLogger()), mock
+}
+
+func testTask() *models.Task {
+	sentAt := time.Now().Add(-time.Minute)
+	return &models.Task{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Title:          "Read chapter three",
+		Status:         "TO_DO",
+		Position:       "m",
+		ReminderSentAt: &sentAt,
+	}
+}
+
+func TestTaskRepositoryUpdateWritesOnlyTheGivenColumns(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	task := testTask()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "title"=$1,"updated_at"=$2 WHERE (id = $3 AND user_id = $4) AND "tasks"."deleted_at" IS NULL AND "id" = $5`)).
+		WithArgs(task.Title, sqlmock.AnyArg(), task.ID, task.UserID, task.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Update(task, "title"))
+}
+
+func TestTaskRepositoryUpdateRearmsReminder(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	task := testTask()
+	remindAt := time.Now().Add(time.Hour)
+	task.RemindAt = &remindAt
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "remind_at"=$1,"reminder_sent_at"=$2,"updated_at"=$3 WHERE`)).
+		WithArgs(remindAt, nil, sqlmock.AnyArg(), task.ID, task.UserID, task.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Update(task, "remind_at"))
+	assert.Nil(t, task.ReminderSentAt)
+}
+
+func TestTaskRepositoryUpdateRejectsSystemColumns(t *testing.T) {
+	for _, column := range []string{"reminder_sent_at", "archived_at", "position", "recurrence_rule", "recurrence_series_id", "user_id", "workspace_id"} {
+		t.Run(column, func(t *testing.T) {
+			repo, _ := newTestTaskRepository(t)
+
+			assert.Error(t, repo.Update(testTask(), "title", column))
+		})
+	}
+}
+
+func TestTaskRepositoryUpdatePositionWritesPosition(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	task := testTask()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "status"=$1,"position"=$2,"updated_at"=$3 WHERE`)).
+		WithArgs(task.Status, task.Position, sqlmock.AnyArg(), task.ID, task.UserID, task.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.UpdatePosition(task, "status"))
+}
+
+func TestTaskRepositoryUpdateReportsMissingTask(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "title"=$1`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	assert.EqualError(t, repo.Update(testTask(), "title"), "task not found")
+}
+
+func TestTaskRepositoryEditAfterClaimKeepsReminderSent(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	task := testTask()
+	task.ReminderSentAt = nil
+
+	claim := regexp.QuoteMeta(`UPDATE tasks SET reminder_sent_at = NOW()`)
+	mock.ExpectQuery(claim).WithArgs(task.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "title", "reminder_sent_at"}).
+			AddRow(task.ID, task.UserID, task.Title, time.Now()))
+
+	// The edit was loaded before the claim, but writes the title alone.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "title"=$1,"updated_at"=$2 WHERE`)).
+		WithArgs("Read chapter four", sqlmock.AnyArg(), task.ID, task.UserID, task.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// The reminder is still marked as sent, so a second claim finds nothing.
+	mock.ExpectQuery(claim).WithArgs(task.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	claimed, err := repo.ClaimReminder(task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+
+	task.Title = "Read chapter four"
+	require.NoError(t, repo.Update(task, "title"))
+
+	claimed, err = repo.ClaimReminder(task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, claimed)
+}
+
+func TestTaskRepositoryEditRollsBackWhenLabelsFail(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	task := testTask()
+	labels := []models.Label{{ID: uuid.New(), UserID: task.UserID, Name: "reading"}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "title"=$1,"updated_at"=$2 WHERE`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "updated_at"=$1 WHERE`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "task_labels"`)).
+		WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	assert.Error(t, repo.Edit(task, TaskEdit{Columns: []string{"title"}, Labels: &labels}))
+}
+
+func TestTaskRepositoryEditWritesRecurrenceOnlyWhenAsked(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	task := testTask()
+	rule := "FREQ=DAILY"
+	task.RecurrenceRule = &rule
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "title"=$1,"updated_at"=$2 WHERE`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "title"=$1,"recurrence_rule"=$2,"recurrence_series_id"=$3,"recurrence_start"=$4,"recurrence_index"=$5,"updated_at"=$6 WHERE`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Edit(task, TaskEdit{Columns: []string{"title"}}))
+	require.NoError(t, repo.Edit(task, TaskEdit{Columns: []string{"title"}, Recurrence: true}))
+}
+
+func TestTaskRepositoryGetByIDIsScopedToTheWorkspace(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	id, userID, workspaceID := uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tasks" WHERE (id = $1 AND tasks.workspace_id = $2 AND`)).
+		WithArgs(id, workspaceID, userID, userID, userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.GetByID(id, userID, workspaceID)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
+func TestTaskRepositoryPositionsAreScopedToTheWorkspace(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	userID, workspaceID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT MAX(position) FROM "tasks" WHERE user_id = $1 AND workspace_id = $2`)).
+		WithArgs(userID, workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow("m"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "tasks" WHERE (user_id = $1 AND workspace_id = $2 AND status = $3 AND archived_at IS NULL)`)).
+		WithArgs(userID, workspaceID, "TO_DO").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	last, err := repo.GetLastPosition(userID, workspaceID)
+	require.NoError(t, err)
+	assert.Equal(t, "m", last)
+
+	count, err := repo.CountByStatus(userID, workspaceID, "TO_DO")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestTaskRepositoryLabelFilterOnlyMatchesLabelsOfTheUserOrOwner(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	userID, workspaceID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE labels.name IN ($5) AND (labels.user_id = $6 OR labels.user_id = tasks.user_id))`)).
+		WithArgs(userID, userID, userID, workspaceID, "reading", userID).
+		WillReturnError(errors.New("stop"))
+
+	_, _, err := repo.GetAll(userID, &params.TaskFilter{WorkspaceID: workspaceID, Labels: []string{"reading"}, Page: 1, Limit: 10})
+	assert.Error(t, err)
+}
+
+// expectCloneInserts expects the copies of a task and of its subtask, where
+// only the task has labels.
+func expectCloneInserts(mock sqlmock.Sqlmock, task *models.Task, subtask *models.Task) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tasks"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(task.ID))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "updated_at"=$1`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "task_labels" ("task_id","label_id") VALUES ($1,$2) ON CONFLICT DO NOTHING`)).
+		WithArgs(task.ID, task.Labels[0].ID).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "label_id"}).AddRow(task.ID, task.Labels[0].ID))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "task_labels"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tasks"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(subtask.ID))
+}
+
+func TestTaskRepositoryCreateClonesCopiesLabelsChecklistAndBlockers(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	sourceID, subtaskSourceID, outsideBlockerID := uuid.New(), uuid.New(), uuid.New()
+	task := testTask()
+	task.Labels = []models.Label{{ID: uuid.New(), UserID: task.UserID, Name: "reading"}}
+	subtask := testTask()
+	subtask.ParentID = &task.ID
+
+	expectCloneInserts(mock, task, subtask)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "task_checklist_items" WHERE task_id IN ($1,$2) ORDER BY position ASC`)).
+		WithArgs(sourceID, subtaskSourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "text", "checked", "position"}).
+			AddRow(uuid.New(), sourceID, "Vocabulary list", true, "a0"))
+	// The items are copied unchecked.
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "task_checklist_items" ("task_id","text","checked","position","created_at","updated_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7)`)).
+		WithArgs(task.ID, "Vocabulary list", false, "a0", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	// A blocker outside the clone is kept, one inside it becomes its copy.
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "task_dependencies" WHERE task_id IN ($1,$2)`)).
+		WithArgs(sourceID, subtaskSourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "blocked_by_id"}).
+			AddRow(sourceID, outsideBlockerID).
+			AddRow(subtaskSourceID, sourceID))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "task_dependencies" ("task_id","blocked_by_id","created_at") VALUES ($1,$2,$3),($4,$5,$6)`)).
+		WithArgs(task.ID, outsideBlockerID, sqlmock.AnyArg(), subtask.ID, task.ID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.CreateClones([]TaskClone{
+		{SourceID: sourceID, Task: task},
+		{SourceID: subtaskSourceID, Task: subtask},
+	}))
+}
+
+func TestTaskRepositoryCreateClonesRollsBackOnFailure(t *testing.T) {
+	repo, mock := newTestTaskRepository(t)
+	sourceID, subtaskSourceID := uuid.New(), uuid.New()
+	task := testTask()
+	task.Labels = []models.Label{{ID: uuid.New(), UserID: task.UserID, Name: "reading"}}
+	subtask := testTask()
+
+	// Both tasks are written before the last statement fails.
+	expectCloneInserts(mock, task, subtask)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "task_checklist_items"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "task_dependencies"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "blocked_by_id"}).AddRow(sourceID, uuid.New()))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "task_dependencies"`)).
+		WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	assert.Error(t, repo.CreateClones([]TaskClone{
+		{SourceID: sourceID, Task: task},
+		{SourceID: subtaskSourceID, Task: subtask},
+	}))
+}
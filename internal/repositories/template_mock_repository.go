@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTemplateRepository) Create(template *models.TaskTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) GetByID(id uuid.UUID, userID uuid.UUID) (*models.TaskTemplate, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TaskTemplate), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTemplateRepository) GetByName(name string, userID uuid.UUID) (*models.TaskTemplate, error) {
+	args := m.Called(name, userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TaskTemplate), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTemplateRepository) GetAll(userID uuid.UUID) ([]models.TaskTemplate, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TaskTemplate), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTemplateRepository) Update(template *models.TaskTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
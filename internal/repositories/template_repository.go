@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"fmt"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TemplateRepository interface {
+	Create(template *models.TaskTemplate) error
+	GetByID(id uuid.UUID, userID uuid.UUID) (*models.TaskTemplate, error)
+	GetByName(name string, userID uuid.UUID) (*models.TaskTemplate, error)
+	GetAll(userID uuid.UUID) ([]models.TaskTemplate, error)
+	Update(template *models.TaskTemplate) error
+	Delete(id uuid.UUID, userID uuid.UUID) error
+}
+
+type templateRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewTemplateRepository(db *gorm.DB, logger *logrus.Logger) TemplateRepository {
+	return &templateRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *templateRepository) Create(template *models.TaskTemplate) error {
+	if err := r.db.Create(template).Error; err != nil {
+		r.logger.WithError(err).Error("Failed to create task template")
+		return fmt.Errorf("failed to create task template: %w", err)
+	}
+
+	r.logger.WithField("template_id", template.ID).Info("Task template created successfully")
+	return nil
+}
+
+func (r *templateRepository) GetByID(id uuid.UUID, userID uuid.UUID) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("template_id", id).Warn("Task template not found")
+			return nil, fmt.Errorf("task template not found")
+		}
+		r.logger.WithError(err).WithField("template_id", id).Error("Failed to get task template")
+		return nil, fmt.Errorf("failed to get task template: %w", err)
+	}
+
+	return &template, nil
+}
+
+func (r *templateRepository) GetByName(name string, userID uuid.UUID) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	err := r.db.Where("name = ? AND user_id = ?", name, userID).First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("task template not found")
+		}
+		r.logger.WithError(err).WithField("name", name).Error("Failed to get task template by name")
+		return nil, fmt.Errorf("failed to get task template: %w", err)
+	}
+
+	return &template, nil
+}
+
+func (r *templateRepository) GetAll(userID uuid.UUID) ([]models.TaskTemplate, error) {
+	var templates []models.TaskTemplate
+	if err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&templates).Error; err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get task templates")
+		return nil, fmt.Errorf("failed to get task templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Update saves every column so that a cleared description is written too.
+func (r *templateRepository) Update(template *models.TaskTemplate) error {
+	result := r.db.Model(template).
+		Where("id = ? AND user_id = ?", template.ID, template.UserID).
+		Select("name", "title", "description", "status", "priority").
+		Updates(template)
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("template_id", template.ID).Error("Failed to update task template")
+		return fmt.Errorf("failed to update task template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("template_id", template.ID).Warn("Task template not found for update")
+		return fmt.Errorf("task template not found")
+	}
+
+	r.logger.WithField("template_id", template.ID).Info("Task template updated successfully")
+	return nil
+}
+
+func (r *templateRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.TaskTemplate{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("template_id", id).Error("Failed to delete task template")
+		return fmt.Errorf("failed to delete task template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("template_id", id).Warn("Task template not found for deletion")
+		return fmt.Errorf("task template not found")
+	}
+
+	r.logger.WithField("template_id", id).Info("Task template deleted successfully")
+	return nil
+}
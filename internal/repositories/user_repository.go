@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository interface {
@@ -14,6 +15,7 @@ type UserRepository interface {
 	GetByEmail(email string) (*models.User, error)
 	GetByID(id uuid.UUID) (*models.User, error)
 	GetByUsername(username string) (*models.User, error)
+	Update(user *models.User) error
 }
 
 type userRepository struct {
@@ -84,3 +86,19 @@ func (r *userRepository) GetByUsername(username string) (*models.User, error) {
 
 	return &user, nil
 }
+
+func (r *userRepository) Update(user *models.User) error {
+	result := r.db.Model(user).Select("*").Omit(clause.Associations, "created_at").Where("id = ?", user.ID).Updates(user)
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("user_id", user.ID).Error("Failed to update user")
+		return fmt.Errorf("failed to update user: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("user_id", user.ID).Warn("User not found for update")
+		return fmt.Errorf("user not found")
+	}
+
+	r.logger.WithField("user_id", user.ID).Info("User updated successfully")
+	return nil
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWIPLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockWIPLimitRepository) Upsert(limit *models.WIPLimit) error {
+	args := m.Called(limit)
+	return args.Error(0)
+}
+
+func (m *MockWIPLimitRepository) GetLimit(userID uuid.UUID, status enum.TaskStatus) (int, error) {
+	args := m.Called(userID, status)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWIPLimitRepository) GetAll(userID uuid.UUID) ([]models.WIPLimit, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.WIPLimit), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWIPLimitRepository) Delete(userID uuid.UUID, status enum.TaskStatus) error {
+	args := m.Called(userID, status)
+	return args.Error(0)
+}
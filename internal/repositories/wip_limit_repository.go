@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"fmt"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WIPLimitRepository interface {
+	Upsert(limit *models.WIPLimit) error
+	GetLimit(userID uuid.UUID, status enum.TaskStatus) (int, error)
+	GetAll(userID uuid.UUID) ([]models.WIPLimit, error)
+	Delete(userID uuid.UUID, status enum.TaskStatus) error
+}
+
+type wipLimitRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewWIPLimitRepository(db *gorm.DB, logger *logrus.Logger) WIPLimitRepository {
+	return &wipLimitRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *wipLimitRepository) Upsert(limit *models.WIPLimit) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "status"}},
+		DoUpdates: clause.AssignmentColumns([]string{"wip_limit"}),
+	}).Create(limit).Error
+	if err != nil {
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"user_id": limit.UserID,
+			"status":  limit.Status,
+		}).Error("Failed to save WIP limit")
+		return fmt.Errorf("failed to save wip limit: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"user_id":   limit.UserID,
+		"status":    limit.Status,
+		"wip_limit": limit.Limit,
+	}).Info("WIP limit saved successfully")
+	return nil
+}
+
+// GetLimit returns the WIP limit of a status, or 0 when it is unlimited.
+func (r *wipLimitRepository) GetLimit(userID uuid.UUID, status enum.TaskStatus) (int, error) {
+	var limits []models.WIPLimit
+	err := r.db.Where("user_id = ? AND status = ?", userID, status).Limit(1).Find(&limits).Error
+	if err != nil {
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"user_id": userID,
+			"status":  status,
+		}).Error("Failed to get WIP limit")
+		return 0, fmt.Errorf("failed to get wip limit: %w", err)
+	}
+
+	if len(limits) == 0 {
+		return 0, nil
+	}
+	return limits[0].Limit, nil
+}
+
+func (r *wipLimitRepository) GetAll(userID uuid.UUID) ([]models.WIPLimit, error) {
+	var limits []models.WIPLimit
+	if err := r.db.Where("user_id = ?", userID).Find(&limits).Error; err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get WIP limits")
+		return nil, fmt.Errorf("failed to get wip limits: %w", err)
+	}
+
+	return limits, nil
+}
+
+func (r *wipLimitRepository) Delete(userID uuid.UUID, status enum.TaskStatus) error {
+	result := r.db.Where("user_id = ? AND status = ?", userID, status).Delete(&models.WIPLimit{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithFields(logrus.Fields{
+			"user_id": userID,
+			"status":  status,
+		}).Error("Failed to delete WIP limit")
+		return fmt.Errorf("failed to delete wip limit: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("status", status).Warn("WIP limit not found for deletion")
+		return fmt.Errorf("wip limit not found")
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"status":  status,
+	}).Info("WIP limit deleted successfully")
+	return nil
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"go-corenglish/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWorkflowRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkflowRepository) GetStatuses() ([]models.TaskStatusDefinition, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TaskStatusDefinition), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWorkflowRepository) GetTransitions() ([]models.TaskStatusTransition, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TaskStatusTransition), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
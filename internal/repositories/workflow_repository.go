@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"fmt"
+	"go-corenglish/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type WorkflowRepository interface {
+	GetStatuses() ([]models.TaskStatusDefinition, error)
+	GetTransitions() ([]models.TaskStatusTransition, error)
+}
+
+type workflowRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewWorkflowRepository(db *gorm.DB, logger *logrus.Logger) WorkflowRepository {
+	return &workflowRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *workflowRepository) GetStatuses() ([]models.TaskStatusDefinition, error) {
+	var statuses []models.TaskStatusDefinition
+	if err := r.db.Order("position ASC, name ASC").Find(&statuses).Error; err != nil {
+		r.logger.WithError(err).Error("Failed to get task statuses")
+		return nil, fmt.Errorf("failed to get task statuses: %w", err)
+	}
+
+	return statuses, nil
+}
+
+func (r *workflowRepository) GetTransitions() ([]models.TaskStatusTransition, error) {
+	var transitions []models.TaskStatusTransition
+	if err := r.db.Order("from_status ASC, to_status ASC").Find(&transitions).Error; err != nil {
+		r.logger.WithError(err).Error("Failed to get task status transitions")
+		return nil, fmt.Errorf("failed to get task status transitions: %w", err)
+	}
+
+	return transitions, nil
+}
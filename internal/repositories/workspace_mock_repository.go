@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWorkspaceRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkspaceRepository) Create(workspace *models.Workspace) error {
+	args := m.Called(workspace)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) GetByID(id uuid.UUID) (*models.Workspace, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Workspace), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetPersonal(userID uuid.UUID) (*models.Workspace, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.Workspace), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetPersonalIDs(userIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) != nil {
+		return args.Get(0).(map[uuid.UUID]uuid.UUID), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetMemberships(userID uuid.UUID) ([]models.WorkspaceMember, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.WorkspaceMember), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) Update(workspace *models.Workspace) error {
+	args := m.Called(workspace)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) GetRole(workspaceID uuid.UUID, userID uuid.UUID) (enum.WorkspaceRole, error) {
+	args := m.Called(workspaceID, userID)
+	return args.Get(0).(enum.WorkspaceRole), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetMembers(workspaceID uuid.UUID) ([]models.WorkspaceMember, error) {
+	args := m.Called(workspaceID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.WorkspaceMember), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) UpsertMember(member *models.WorkspaceMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) DeleteMember(workspaceID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(workspaceID, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"fmt"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkspaceRepository interface {
+	Create(workspace *models.Workspace) error
+	GetByID(id uuid.UUID) (*models.Workspace, error)
+	GetPersonal(userID uuid.UUID) (*models.Workspace, error)
+	GetPersonalIDs(userIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error)
+	GetMemberships(userID uuid.UUID) ([]models.WorkspaceMember, error)
+	Update(workspace *models.Workspace) error
+	Delete(id uuid.UUID) error
+	GetRole(workspaceID uuid.UUID, userID uuid.UUID) (enum.WorkspaceRole, error)
+	GetMembers(workspaceID uuid.UUID) ([]models.WorkspaceMember, error)
+	UpsertMember(member *models.WorkspaceMember) error
+	DeleteMember(workspaceID uuid.UUID, userID uuid.UUID) error
+}
+
+type workspaceRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewWorkspaceRepository(db *gorm.DB, logger *logrus.Logger) WorkspaceRepository {
+	return &workspaceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create inserts the workspace together with its initial members.
+func (r *workspaceRepository) Create(workspace *models.Workspace) error {
+	if err := r.db.Create(workspace).Error; err != nil {
+		r.logger.WithError(err).WithField("created_by", workspace.CreatedBy).Error("Failed to create workspace")
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"workspace_id": workspace.ID,
+		"created_by":   workspace.CreatedBy,
+	}).Info("Workspace created successfully")
+	return nil
+}
+
+func (r *workspaceRepository) GetByID(id uuid.UUID) (*models.Workspace, error) {
+	var workspace models.Workspace
+	if err := r.db.Where("id = ?", id).First(&workspace).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("workspace_id", id).Warn("Workspace not found")
+			return nil, fmt.Errorf("workspace not found")
+		}
+		r.logger.WithError(err).WithField("workspace_id", id).Error("Failed to get workspace")
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) GetPersonal(userID uuid.UUID) (*models.Workspace, error) {
+	var workspace models.Workspace
+	if err := r.db.Where("created_by = ? AND personal", userID).First(&workspace).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("user_id", userID).Warn("Personal workspace not found")
+			return nil, fmt.Errorf("workspace not found")
+		}
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get personal workspace")
+		return nil, fmt.Errorf("failed to get personal workspace: %w", err)
+	}
+
+	return &workspace, nil
+}
+
+// GetPersonalIDs maps each of the given users to the ID of their personal
+// workspace.
+func (r *workspaceRepository) GetPersonalIDs(userIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	ids := make(map[uuid.UUID]uuid.UUID, len(userIDs))
+	if len(userIDs) == 0 {
+		return ids, nil
+	}
+
+	var workspaces []models.Workspace
+	if err := r.db.Where("created_by IN ? AND personal", userIDs).Find(&workspaces).Error; err != nil {
+		r.logger.WithError(err).Error("Failed to get personal workspaces")
+		return nil, fmt.Errorf("failed to get personal workspaces: %w", err)
+	}
+
+	for _, workspace := range workspaces {
+		ids[workspace.CreatedBy] = workspace.ID
+	}
+	return ids, nil
+}
+
+// GetMemberships lists the workspaces a user belongs to, personal workspace
+// first.
+func (r *workspaceRepository) GetMemberships(userID uuid.UUID) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	err := r.db.Joins("Workspace").Where("workspace_members.user_id = ?", userID).
+		Order(`"Workspace".personal DESC`).Order(`"Workspace".name ASC`).Order("workspace_members.workspace_id ASC").
+		Find(&members).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID).Error("Failed to get workspace memberships")
+		return nil, fmt.Errorf("failed to get workspace memberships: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *workspaceRepository) Update(workspace *models.Workspace) error {
+	if err := r.db.Omit(clause.Associations).Save(workspace).Error; err != nil {
+		r.logger.WithError(err).WithField("workspace_id", workspace.ID).Error("Failed to update workspace")
+		return fmt.Errorf("failed to update workspace: %w", err)
+	}
+
+	r.logger.WithField("workspace_id", workspace.ID).Info("Workspace updated successfully")
+	return nil
+}
+
+// Delete removes the workspace. Its members and tasks go with it.
+func (r *workspaceRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ? AND NOT personal", id).Delete(&models.Workspace{})
+	if result.Error != nil {
+		r.logger.WithError(result.Error).WithField("workspace_id", id).Error("Failed to delete workspace")
+		return fmt.Errorf("failed to delete workspace: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithField("workspace_id", id).Warn("Workspace not found for deletion")
+		return fmt.Errorf("workspace not found")
+	}
+
+	r.logger.WithField("workspace_id", id).Info("Workspace deleted successfully")
+	return nil
+}
+
+// GetRole returns the role of a member of the workspace, or "" when the user
+// is not a member.
+func (r *workspaceRepository) GetRole(workspaceID uuid.UUID, userID uuid.UUID) (enum.WorkspaceRole, error) {
+	var members []models.WorkspaceMember
+	err := r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Limit(1).Find(&members).Error
+	if err != nil {
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"workspace_id": workspaceID,
+			"user_id":      userID,
+		}).Error("Failed to get workspace member role")
+		return "", fmt.Errorf("failed to get workspace member role: %w", err)
+	}
+
+	if len(members) == 0 {
+		return "", nil
+	}
+	return members[0].Role, nil
+}
+
+func (r *workspaceRepository) GetMembers(workspaceID uuid.UUID) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	err := r.db.Preload("User").Where("workspace_id = ?", workspaceID).
+		Order("created_at ASC").Order("user_id ASC").
+		Find(&members).Error
+	if err != nil {
+		r.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to get workspace members")
+		return nil, fmt.Errorf("failed to get workspace members: %w", err)
+	}
+
+	return members, nil
+}
+
+// UpsertMember adds a member to a workspace, or changes the role of an
+// existing one.
+func (r *workspaceRepository) UpsertMember(member *models.WorkspaceMember) error {
+	err := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}).Create(member).Error
+	if err != nil {
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"workspace_id": member.WorkspaceID,
+			"user_id":      member.UserID,
+		}).Error("Failed to save workspace member")
+		return fmt.Errorf("failed to save workspace member: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"workspace_id": member.WorkspaceID,
+		"user_id":      member.UserID,
+		"role":         member.Role,
+	}).Info("Workspace member saved successfully")
+	return nil
+}
+
+// DeleteMember removes a user from a workspace together with the task
+// shares they were given inside it. Tasks they own stay in the workspace.
+func (r *workspaceRepository) DeleteMember(workspaceID uuid.UUID, userID uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&models.WorkspaceMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Where("user_id = ? AND task_id IN (SELECT id FROM tasks WHERE workspace_id = ?)", userID, workspaceID).
+			Delete(&models.TaskMember{}).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.WithField("workspace_id", workspaceID).Warn("Workspace member not found for deletion")
+			return fmt.Errorf("workspace member not found")
+		}
+		r.logger.WithError(err).WithFields(logrus.Fields{
+			"workspace_id": workspaceID,
+			"user_id":      userID,
+		}).Error("Failed to delete workspace member")
+		return fmt.Errorf("failed to delete workspace member: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"workspace_id": workspaceID,
+		"user_id":      userID,
+	}).Info("Workspace member deleted successfully")
+	return nil
+}
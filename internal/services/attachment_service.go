@@ -0,0 +1,320 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/config"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"go-corenglish/pkg/storage"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// sniffLength is the number of leading bytes http.DetectContentType looks at.
+const sniffLength = 512
+
+// allowedAttachmentTypes lists the sniffed content types accepted for
+// upload. Entries ending in "/" match a whole family.
+var allowedAttachmentTypes = []string{
+	"image/",
+	"audio/",
+	"video/mp4",
+	"video/webm",
+	"application/ogg",
+	"application/pdf",
+	"application/zip", // docx, xlsx, pptx and odt are zip containers
+	"text/plain",
+}
+
+type AttachmentService interface {
+	UploadAttachment(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, fileName string, size int64, body io.Reader) (*params.AttachmentResponse, *response.CustomError)
+	GetAttachments(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]params.AttachmentResponse, *response.CustomError)
+	GetAttachment(taskID uuid.UUID, attachmentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.AttachmentResponse, *response.CustomError)
+	DeleteAttachment(taskID uuid.UUID, attachmentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError
+	OpenDownload(attachmentID uuid.UUID, expires string, signature string) (*models.TaskAttachment, io.ReadCloser, *response.CustomError)
+}
+
+type attachmentService struct {
+	taskRepo       repositories.TaskRepository
+	attachmentRepo repositories.AttachmentRepository
+	memberRepo     repositories.TaskMemberRepository
+	storage        storage.Storage
+	maxSize        int64
+	urlSecret      []byte
+	urlTTL         time.Duration
+	logger         *logrus.Logger
+}
+
+func NewAttachmentService(taskRepo repositories.TaskRepository, attachmentRepo repositories.AttachmentRepository, memberRepo repositories.TaskMemberRepository, store storage.Storage, cfg *config.Config, logger *logrus.Logger) AttachmentService {
+	return &attachmentService{
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		memberRepo:     memberRepo,
+		storage:        store,
+		maxSize:        int64(cfg.AttachmentMaxSizeMB) << 20,
+		urlSecret:      []byte(cfg.AttachmentURLSecret),
+		urlTTL:         time.Duration(cfg.AttachmentURLTTL) * time.Minute,
+		logger:         logger,
+	}
+}
+
+// UploadAttachment stores the file and records it on the task. The content
+// type is sniffed from the data rather than trusted from the client, and a
+// SHA-256 checksum is computed while the file is streamed to the storage.
+func (s *attachmentService) UploadAttachment(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, fileName string, size int64, body io.Reader) (*params.AttachmentResponse, *response.CustomError) {
+	if size <= 0 {
+		return nil, response.BadRequestError("file is empty")
+	}
+	if size > s.maxSize {
+		return nil, response.BadRequestError(fmt.Sprintf("file exceeds the maximum size of %d bytes", s.maxSize))
+	}
+
+	fileName = sanitizeFileName(fileName)
+	if fileName == "" {
+		return nil, response.BadRequestError("file name is required")
+	}
+
+	if custErr := s.ensureTaskAccess(taskID, userID, workspaceID, enum.RoleEditor); custErr != nil {
+		return nil, custErr
+	}
+
+	reader := bufio.NewReaderSize(body, sniffLength)
+	head, err := reader.Peek(sniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to read uploaded file")
+		return nil, response.BadRequestError("failed to read file")
+	}
+
+	contentType := http.DetectContentType(head)
+	if !isAllowedAttachmentType(contentType) {
+		return nil, response.BadRequestError(fmt.Sprintf("file type %s is not allowed", contentType))
+	}
+
+	attachment := &models.TaskAttachment{
+		ID:          uuid.New(),
+		TaskID:      taskID,
+		UserID:      userID,
+		FileName:    fileName,
+		ContentType: contentType,
+		SizeBytes:   size,
+	}
+	attachment.StorageKey = fmt.Sprintf("tasks/%s/%s", taskID, attachment.ID)
+
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	content := io.TeeReader(io.LimitReader(reader, size+1), io.MultiWriter(hasher, counter))
+
+	ctx := context.Background()
+	if err := s.storage.Put(ctx, attachment.StorageKey, content, size, contentType); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to store attachment")
+		return nil, response.GeneralError("failed to store attachment")
+	}
+
+	if counter.n != size {
+		s.deleteBlob(attachment.StorageKey)
+		return nil, response.BadRequestError("file size does not match the uploaded data")
+	}
+	attachment.ChecksumSHA256 = hex.EncodeToString(hasher.Sum(nil))
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		s.deleteBlob(attachment.StorageKey)
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to create attachment")
+		return nil, response.RepositoryError("failed to create attachment")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"attachment_id": attachment.ID,
+		"task_id":       taskID,
+		"user_id":       userID,
+		"content_type":  contentType,
+		"size":          size,
+	}).Info("Attachment uploaded successfully")
+
+	return s.toAttachmentResponse(attachment), nil
+}
+
+func (s *attachmentService) GetAttachments(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]params.AttachmentResponse, *response.CustomError) {
+	if custErr := s.ensureTaskAccess(taskID, userID, workspaceID, enum.RoleViewer); custErr != nil {
+		return nil, custErr
+	}
+
+	attachments, err := s.attachmentRepo.GetByTaskID(taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get attachments")
+		return nil, response.RepositoryError("failed to get attachments")
+	}
+
+	responses := make([]params.AttachmentResponse, len(attachments))
+	for i := range attachments {
+		responses[i] = *s.toAttachmentResponse(&attachments[i])
+	}
+	return responses, nil
+}
+
+func (s *attachmentService) GetAttachment(taskID uuid.UUID, attachmentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.AttachmentResponse, *response.CustomError) {
+	if custErr := s.ensureTaskAccess(taskID, userID, workspaceID, enum.RoleViewer); custErr != nil {
+		return nil, custErr
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(attachmentID, taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("attachment_id", attachmentID).Error("Failed to get attachment")
+		return nil, attachmentLookupError(err, "failed to get attachment")
+	}
+
+	return s.toAttachmentResponse(attachment), nil
+}
+
+// DeleteAttachment removes the attachment. The blob is removed from the
+// storage by the worker once the deletion is committed.
+func (s *attachmentService) DeleteAttachment(taskID uuid.UUID, attachmentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	if custErr := s.ensureTaskAccess(taskID, userID, workspaceID, enum.RoleEditor); custErr != nil {
+		return custErr
+	}
+
+	if err := s.attachmentRepo.Delete(attachmentID, taskID); err != nil {
+		s.logger.WithError(err).WithField("attachment_id", attachmentID).Error("Failed to delete attachment")
+		return attachmentLookupError(err, "failed to delete attachment")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"attachment_id": attachmentID,
+		"task_id":       taskID,
+		"user_id":       userID,
+	}).Info("Attachment deleted successfully")
+
+	return nil
+}
+
+// OpenDownload checks a signed download link and opens the attachment's
+// content. The caller must close the returned reader.
+func (s *attachmentService) OpenDownload(attachmentID uuid.UUID, expires string, signature string) (*models.TaskAttachment, io.ReadCloser, *response.CustomError) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !s.validSignature(attachmentID, expiresAt, signature) {
+		return nil, nil, response.UnauthorizedError("invalid download link")
+	}
+	if time.Now().Unix() > expiresAt {
+		return nil, nil, response.UnauthorizedError("download link has expired")
+	}
+
+	attachment, err := s.attachmentRepo.GetDownloadable(attachmentID)
+	if err != nil {
+		s.logger.WithError(err).WithField("attachment_id", attachmentID).Error("Failed to get attachment for download")
+		return nil, nil, attachmentLookupError(err, "failed to get attachment")
+	}
+
+	content, err := s.storage.Get(context.Background(), attachment.StorageKey)
+	if err != nil {
+		s.logger.WithError(err).WithField("attachment_id", attachmentID).Error("Failed to open attachment content")
+		return nil, nil, response.GeneralError("failed to open attachment")
+	}
+
+	return attachment, content, nil
+}
+
+func (s *attachmentService) ensureTaskAccess(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, required enum.TaskRole) *response.CustomError {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for attachments")
+		return taskLookupError(err, "failed to get task")
+	}
+	return requireTaskRole(s.memberRepo, s.logger, task, userID, required)
+}
+
+// attachmentLookupError answers a failed attachment lookup. An attachment
+// that does not exist, or whose task is in the trash, is not found; any other
+// failure is reported with message.
+func attachmentLookupError(err error, message string) *response.CustomError {
+	if errors.Is(err, repositories.ErrAttachmentNotFound) {
+		return response.NotFoundError("attachment not found")
+	}
+	return response.RepositoryError(message)
+}
+
+// deleteBlob removes a blob that never got an attachment row.
+func (s *attachmentService) deleteBlob(key string) {
+	if err := s.storage.Delete(context.Background(), key); err != nil {
+		s.logger.WithError(err).WithField("storage_key", key).Warn("Failed to delete orphaned attachment blob")
+	}
+}
+
+// sign returns the signature of a download link for the attachment that is
+// valid until expiresAt (Unix seconds).
+func (s *attachmentService) sign(attachmentID uuid.UUID, expiresAt int64) string {
+	mac := hmac.New(sha256.New, s.urlSecret)
+	mac.Write([]byte(attachmentID.String() + ":" + strconv.FormatInt(expiresAt, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *attachmentService) validSignature(attachmentID uuid.UUID, expiresAt int64, signature string) bool {
+	expected := s.sign(attachmentID, expiresAt)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func (s *attachmentService) toAttachmentResponse(attachment *models.TaskAttachment) *params.AttachmentResponse {
+	expiresAt := time.Now().Add(s.urlTTL).Truncate(time.Second)
+
+	return &params.AttachmentResponse{
+		ID:             attachment.ID,
+		FileName:       attachment.FileName,
+		ContentType:    attachment.ContentType,
+		SizeBytes:      attachment.SizeBytes,
+		ChecksumSHA256: attachment.ChecksumSHA256,
+		DownloadURL: fmt.Sprintf("/api/v1/attachments/%s/download?expires=%d&signature=%s",
+			attachment.ID, expiresAt.Unix(), s.sign(attachment.ID, expiresAt.Unix())),
+		DownloadURLExpiresAt: expiresAt,
+		CreatedAt:            attachment.CreatedAt,
+	}
+}
+
+func isAllowedAttachmentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	for _, allowed := range allowedAttachmentTypes {
+		if mediaType == allowed || (strings.HasSuffix(allowed, "/") && strings.HasPrefix(mediaType, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeFileName keeps the base name of a client supplied file name.
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" {
+		return ""
+	}
+	if len(name) > 255 {
+		// Keep the end so that the extension survives.
+		name = strings.ToValidUTF8(name[len(name)-255:], "")
+	}
+	return name
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-corenglish/internal/config"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/repositories"
+	"go-corenglish/pkg/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStorage keeps objects in memory.
+type memoryStorage struct {
+	objects map[string][]byte
+}
+
+func (s *memoryStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) error {
+	delete(s.objects, key)
+	return nil
+}
+
+// attachmentServiceMocks holds what an attachmentService under test uses.
+type attachmentServiceMocks struct {
+	tasks       *repositories.MockBookRepository
+	attachments *repositories.MockAttachmentRepository
+	storage     *memoryStorage
+}
+
+func newTestAttachmentService(t *testing.T) (*attachmentService, *attachmentServiceMocks) {
+	m := &attachmentServiceMocks{
+		tasks:       new(repositories.MockBookRepository),
+		attachments: new(repositories.MockAttachmentRepository),
+		storage:     &memoryStorage{objects: map[string][]byte{}},
+	}
+	t.Cleanup(func() {
+		m.tasks.AssertExpectations(t)
+		m.attachments.AssertExpectations(t)
+	})
+
+	cfg := &config.Config{AttachmentMaxSizeMB: 1, AttachmentURLSecret: "test-secret", AttachmentURLTTL: 15}
+	service := NewAttachmentService(m.tasks, m.attachments, new(repositories.MockTaskMemberRepository), m.storage, cfg, newTestLogger())
+	return service.(*attachmentService), m
+}
+
+func TestGetAttachmentThatIsGoneIsNotFound(t *testing.T) {
+	service, m := newTestAttachmentService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	attachmentID := uuid.New()
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+	m.attachments.On("GetByID", attachmentID, task.ID).Return(nil, repositories.ErrAttachmentNotFound)
+
+	_, custErr := service.GetAttachment(task.ID, attachmentID, userID, task.WorkspaceID)
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusNotFound, custErr.StatusCode)
+}
+
+func TestDownloadOfAnAttachmentThatIsGoneIsNotFound(t *testing.T) {
+	service, m := newTestAttachmentService(t)
+	attachmentID := uuid.New()
+	expiresAt := time.Now().Add(time.Minute).Unix()
+
+	// The attachment was deleted, or its task trashed, after the link was made.
+	m.attachments.On("GetDownloadable", attachmentID).Return(nil, repositories.ErrAttachmentNotFound)
+
+	_, _, custErr := service.OpenDownload(attachmentID, strconv.FormatInt(expiresAt, 10), service.sign(attachmentID, expiresAt))
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusNotFound, custErr.StatusCode)
+}
+
+func TestUploadAttachmentRecordsTheChecksum(t *testing.T) {
+	service, m := newTestAttachmentService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	content := "Vocabulary for chapter three"
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+	m.attachments.On("Create", mock.AnythingOfType("*models.TaskAttachment")).Return(nil)
+
+	resp, custErr := service.UploadAttachment(task.ID, userID, task.WorkspaceID, "../notes.txt", int64(len(content)), strings.NewReader(content))
+
+	require.Nil(t, custErr)
+	sum := sha256.Sum256([]byte(content))
+	assert.Equal(t, hex.EncodeToString(sum[:]), resp.ChecksumSHA256)
+	assert.Equal(t, "notes.txt", resp.FileName)
+	assert.Equal(t, "text/plain; charset=utf-8", resp.ContentType)
+	assert.Equal(t, content, string(m.storage.objects[fmt.Sprintf("tasks/%s/%s", task.ID, resp.ID)]))
+}
+
+func TestUploadAttachmentRejectsOversizeFiles(t *testing.T) {
+	service, m := newTestAttachmentService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	size := int64(1<<20) + 1
+
+	_, custErr := service.UploadAttachment(task.ID, userID, task.WorkspaceID, "essay.pdf", size, bytes.NewReader(make([]byte, size)))
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusBadRequest, custErr.StatusCode)
+	assert.Empty(t, m.storage.objects)
+}
+
+func TestUploadAttachmentRejectsASizeThatDoesNotMatchTheData(t *testing.T) {
+	service, m := newTestAttachmentService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	content := "Vocabulary for chapter three"
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+
+	_, custErr := service.UploadAttachment(task.ID, userID, task.WorkspaceID, "notes.txt", int64(len(content))+10, strings.NewReader(content))
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusBadRequest, custErr.StatusCode)
+	assert.Empty(t, m.storage.objects, "the stored blob is removed again")
+	m.attachments.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUploadAttachmentRejectsDisallowedTypes(t *testing.T) {
+	service, m := newTestAttachmentService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	// A Windows executable, whatever its name claims.
+	content := append([]byte("MZ\x90\x00\x03\x00\x00\x00"), make([]byte, 64)...)
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+
+	_, custErr := service.UploadAttachment(task.ID, userID, task.WorkspaceID, "essay.pdf", int64(len(content)), bytes.NewReader(content))
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusBadRequest, custErr.StatusCode)
+	assert.Empty(t, m.storage.objects)
+	m.attachments.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestOpenDownloadChecksTheLink(t *testing.T) {
+	attachmentID := uuid.New()
+	valid := time.Now().Add(time.Minute).Unix()
+	expired := time.Now().Add(-time.Minute).Unix()
+
+	tests := []struct {
+		name     string
+		expires  string
+		sign     func(s *attachmentService) string
+		wantCode int
+	}{
+		{
+			name:    "valid",
+			expires: strconv.FormatInt(valid, 10),
+			sign:    func(s *attachmentService) string { return s.sign(attachmentID, valid) },
+		},
+		{
+			name:     "tampered signature",
+			expires:  strconv.FormatInt(valid, 10),
+			sign:     func(s *attachmentService) string { return strings.Repeat("0", 64) },
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "extended expiry",
+			expires:  strconv.FormatInt(valid+3600, 10),
+			sign:     func(s *attachmentService) string { return s.sign(attachmentID, valid) },
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "expired",
+			expires:  strconv.FormatInt(expired, 10),
+			sign:     func(s *attachmentService) string { return s.sign(attachmentID, expired) },
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "malformed expiry",
+			expires:  "tomorrow",
+			sign:     func(s *attachmentService) string { return s.sign(attachmentID, valid) },
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestAttachmentService(t)
+			attachment := &models.TaskAttachment{ID: attachmentID, StorageKey: "tasks/1/" + attachmentID.String()}
+			m.storage.objects[attachment.StorageKey] = []byte("hello")
+			if tt.wantCode == 0 {
+				m.attachments.On("GetDownloadable", attachmentID).Return(attachment, nil)
+			}
+
+			_, content, custErr := service.OpenDownload(attachmentID, tt.expires, tt.sign(service))
+
+			if tt.wantCode != 0 {
+				require.NotNil(t, custErr)
+				assert.Equal(t, tt.wantCode, custErr.StatusCode)
+				m.attachments.AssertNotCalled(t, "GetDownloadable", mock.Anything)
+				return
+			}
+			require.Nil(t, custErr)
+			defer content.Close()
+			data, err := io.ReadAll(content)
+			require.NoError(t, err)
+			assert.Equal(t, "hello", string(data))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type BoardService interface {
+	GetBoard(userID uuid.UUID, filter *params.TaskFilter, pages map[enum.TaskStatus]int) (*params.BoardResponse, *response.CustomError)
+	SetWIPLimit(userID uuid.UUID, status enum.TaskStatus, req *params.SetWIPLimitRequest) (*params.WIPLimitResponse, *response.CustomError)
+	DeleteWIPLimit(userID uuid.UUID, status enum.TaskStatus) *response.CustomError
+}
+
+type boardService struct {
+	taskService  TaskService
+	wipLimitRepo repositories.WIPLimitRepository
+	workflow     WorkflowService
+	logger       *logrus.Logger
+}
+
+func NewBoardService(taskService TaskService, wipLimitRepo repositories.WIPLimitRepository, workflow WorkflowService, logger *logrus.Logger) BoardService {
+	return &boardService{
+		taskService:  taskService,
+		wipLimitRepo: wipLimitRepo,
+		workflow:     workflow,
+		logger:       logger,
+	}
+}
+
+// GetBoard returns one column per workflow status. Every column is a page of
+// GetTasks restricted to its status, so filters, sorting and caching behave
+// exactly like the task list. pages selects the page of each column and
+// defaults to filter.Page.
+func (s *boardService) GetBoard(userID uuid.UUID, filter *params.TaskFilter, pages map[enum.TaskStatus]int) (*params.BoardResponse, *response.CustomError) {
+	statuses := s.workflow.GetStatuses()
+
+	for status := range pages {
+		if !status.IsValid() {
+			return nil, response.BadRequestError(fmt.Sprintf("invalid status: %s", status))
+		}
+	}
+
+	limits, err := s.wipLimitRepo.GetAll(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get WIP limits")
+		return nil, response.RepositoryError("failed to get wip limits")
+	}
+	limitByStatus := make(map[enum.TaskStatus]int, len(limits))
+	for _, limit := range limits {
+		limitByStatus[limit.Status] = limit.Limit
+	}
+
+	board := &params.BoardResponse{
+		Columns: make([]params.BoardColumnResponse, 0, len(statuses)),
+	}
+	for _, status := range statuses {
+		columnFilter := *filter
+		columnFilter.Status = string(status.Name)
+		if page, ok := pages[status.Name]; ok {
+			columnFilter.Page = page
+		}
+
+		tasks, custErr := s.taskService.GetTasks(userID, &columnFilter)
+		if custErr != nil {
+			return nil, custErr
+		}
+
+		column := params.BoardColumnResponse{
+			Status:        status.Name,
+			Category:      status.Category,
+			TasksResponse: *tasks,
+		}
+		if limit, ok := limitByStatus[status.Name]; ok {
+			column.WIPLimit = &limit
+		}
+		board.Columns = append(board.Columns, column)
+	}
+
+	return board, nil
+}
+
+func (s *boardService) SetWIPLimit(userID uuid.UUID, status enum.TaskStatus, req *params.SetWIPLimitRequest) (*params.WIPLimitResponse, *response.CustomError) {
+	if !status.IsValid() {
+		return nil, response.BadRequestError(fmt.Sprintf("invalid status: %s", status))
+	}
+
+	limit := &models.WIPLimit{
+		UserID: userID,
+		Status: status,
+		Limit:  req.WIPLimit,
+	}
+	if err := s.wipLimitRepo.Upsert(limit); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"user_id": userID,
+			"status":  status,
+		}).Error("Failed to save WIP limit")
+		return nil, response.RepositoryError("failed to save wip limit")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"status":    status,
+		"wip_limit": req.WIPLimit,
+	}).Info("WIP limit set successfully")
+
+	return &params.WIPLimitResponse{
+		Status:   status,
+		WIPLimit: limit.Limit,
+	}, nil
+}
+
+func (s *boardService) DeleteWIPLimit(userID uuid.UUID, status enum.TaskStatus) *response.CustomError {
+	if err := s.wipLimitRepo.Delete(userID, status); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"user_id": userID,
+			"status":  status,
+		}).Error("Failed to delete WIP limit")
+		return response.RepositoryError("failed to delete wip limit")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"status":  status,
+	}).Info("WIP limit deleted successfully")
+
+	return nil
+}
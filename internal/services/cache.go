@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// InvalidateScopeTask marks an invalidation caused by changes to tasks: only
+// the user's general task lists and the lists of the named projects are
+// dropped, so the lists of their other projects stay cached.
+const InvalidateScopeTask = "task"
+
+// cacheInvalidation is published on "tasks:invalidate". Without a scope every
+// cached tasks list page of the user goes.
+type cacheInvalidation struct {
+	UserID     string   `json:"user_id"`
+	Scope      string   `json:"scope,omitempty"`
+	ProjectIDs []string `json:"project_ids,omitempty"`
+}
+
+// publishInvalidateUserTasksCache asks the worker to drop every cached tasks
+// list page of the given user, project lists included.
+func publishInvalidateUserTasksCache(cache *redis.Client, logger *logrus.Logger, userID uuid.UUID) {
+	publishCacheInvalidation(cache, logger, cacheInvalidation{UserID: userID.String()})
+}
+
+// publishInvalidateTaskListsCache asks the worker to drop the general cached
+// tasks lists of the given user and the lists of the given projects.
+func publishInvalidateTaskListsCache(cache *redis.Client, logger *logrus.Logger, userID uuid.UUID, projectIDs ...*uuid.UUID) {
+	msg := cacheInvalidation{UserID: userID.String(), Scope: InvalidateScopeTask}
+	for _, projectID := range projectIDs {
+		if projectID != nil {
+			msg.ProjectIDs = append(msg.ProjectIDs, projectID.String())
+		}
+	}
+	publishCacheInvalidation(cache, logger, msg)
+}
+
+func publishCacheInvalidation(cache *redis.Client, logger *logrus.Logger, msg cacheInvalidation) {
+	ctx := context.Background()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal cache invalidation message")
+		return
+	}
+
+	if err := cache.Publish(ctx, "tasks:invalidate", data).Err(); err != nil {
+		logger.WithError(err).Error("Failed to publish cache invalidation event")
+	}
+}
+
+// publishInvalidateTaskCaches drops the cached tasks lists of everyone who
+// can see the task: its owner, with the lists of the task's project and of
+// any project it was just moved out of, and every member it is shared with.
+// A subtask also shows up in the progress of its parent, which may sit in
+// another project, so its owner loses every list.
+func publishInvalidateTaskCaches(cache *redis.Client, logger *logrus.Logger, memberRepo repositories.TaskMemberRepository, task *models.Task, previousProjectIDs ...*uuid.UUID) {
+	if task.ParentID != nil {
+		publishInvalidateUserTasksCache(cache, logger, task.UserID)
+	} else {
+		publishInvalidateTaskListsCache(cache, logger, task.UserID, append(previousProjectIDs, task.ProjectID)...)
+	}
+
+	memberIDs, err := memberRepo.GetUserIDs(task.ID)
+	if err != nil {
+		logger.WithError(err).WithField("task_id", task.ID).Warn("Failed to get task members for cache invalidation")
+		return
+	}
+	for _, memberID := range memberIDs {
+		publishInvalidateTaskListsCache(cache, logger, memberID)
+	}
+}
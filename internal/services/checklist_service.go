@@ -0,0 +1,265 @@
+package services
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"go-corenglish/pkg/rank"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type ChecklistService interface {
+	GetChecklist(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.ChecklistResponse, *response.CustomError)
+	AddItem(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateChecklistItemRequest) (*params.ChecklistItemResponse, *response.CustomError)
+	UpdateItem(taskID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.UpdateChecklistItemRequest) (*params.ChecklistItemResponse, *response.CustomError)
+	ToggleItem(taskID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.ChecklistItemResponse, *response.CustomError)
+	MoveItem(taskID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.MoveChecklistItemRequest) (*params.ChecklistItemResponse, *response.CustomError)
+	DeleteItem(taskID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError
+}
+
+type checklistService struct {
+	taskRepo      repositories.TaskRepository
+	checklistRepo repositories.ChecklistRepository
+	memberRepo    repositories.TaskMemberRepository
+	logger        *logrus.Logger
+	cache         *redis.Client
+}
+
+func NewChecklistService(taskRepo repositories.TaskRepository, checklistRepo repositories.ChecklistRepository, memberRepo repositories.TaskMemberRepository, logger *logrus.Logger, cache *redis.Client) ChecklistService {
+	return &checklistService{
+		taskRepo:      taskRepo,
+		checklistRepo: checklistRepo,
+		memberRepo:    memberRepo,
+		logger:        logger,
+		cache:         cache,
+	}
+}
+
+func (s *checklistService) GetChecklist(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.ChecklistResponse, *response.CustomError) {
+	if _, custErr := s.ensureTaskAccess(taskID, userID, workspaceID, enum.RoleViewer); custErr != nil {
+		return nil, custErr
+	}
+
+	items, err := s.checklistRepo.GetByTaskID(taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get checklist items")
+		return nil, response.RepositoryError("failed to get checklist")
+	}
+
+	checklist := &params.ChecklistResponse{
+		Items: make([]params.ChecklistItemResponse, len(items)),
+		Total: int64(len(items)),
+	}
+	for i := range items {
+		checklist.Items[i] = *toChecklistItemResponse(&items[i])
+		if items[i].Checked {
+			checklist.Checked++
+		}
+	}
+
+	return checklist, nil
+}
+
+func (s *checklistService) AddItem(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateChecklistItemRequest) (*params.ChecklistItemResponse, *response.CustomError) {
+	task, custErr := s.ensureTaskAccess(taskID, userID, workspaceID, enum.RoleEditor)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	last, err := s.checklistRepo.GetLastPosition(taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get last checklist position")
+		return nil, response.RepositoryError("failed to add checklist item")
+	}
+
+	position, err := rank.Between(last, "")
+	if err != nil {
+		s.logger.WithError(err).WithField("last", last).Error("Failed to compute checklist position")
+		return nil, response.GeneralError("failed to add checklist item")
+	}
+
+	item := &models.ChecklistItem{
+		TaskID:   taskID,
+		Text:     req.Text,
+		Checked:  req.Checked,
+		Position: position,
+	}
+	if err := s.checklistRepo.Create(item); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to create checklist item")
+		return nil, response.RepositoryError("failed to add checklist item")
+	}
+
+	publishInvalidateTaskCaches(s.cache, s.logger, s.memberRepo, task)
+
+	s.logger.WithFields(logrus.Fields{
+		"item_id": item.ID,
+		"task_id": taskID,
+		"user_id": userID,
+	}).Info("Checklist item added successfully")
+
+	return toChecklistItemResponse(item), nil
+}
+
+func (s *checklistService) UpdateItem(taskID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.UpdateChecklistItemRequest) (*params.ChecklistItemResponse, *response.CustomError) {
+	task, item, custErr := s.getItem(taskID, itemID, userID, workspaceID)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	if req.Text != nil {
+		item.Text = *req.Text
+	}
+	if req.Checked != nil {
+		item.Checked = *req.Checked
+	}
+
+	return s.saveItem(task, item)
+}
+
+func (s *checklistService) ToggleItem(taskID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.ChecklistItemResponse, *response.CustomError) {
+	task, item, custErr := s.getItem(taskID, itemID, userID, workspaceID)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	item.Checked = !item.Checked
+
+	return s.saveItem(task, item)
+}
+
+func (s *checklistService) MoveItem(taskID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.MoveChecklistItemRequest) (*params.ChecklistItemResponse, *response.CustomError) {
+	if (req.BeforeID == nil) == (req.AfterID == nil) {
+		return nil, response.BadRequestError("exactly one of before_id or after_id is required")
+	}
+
+	anchorID, before := req.AfterID, false
+	if req.BeforeID != nil {
+		anchorID, before = req.BeforeID, true
+	}
+	if *anchorID == itemID {
+		return nil, response.BadRequestError("an item cannot be moved relative to itself")
+	}
+
+	task, item, custErr := s.getItem(taskID, itemID, userID, workspaceID)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	anchor, err := s.checklistRepo.GetByID(*anchorID, taskID)
+	if err != nil {
+		return nil, response.BadRequestError("anchor checklist item not found")
+	}
+
+	adjacent, err := s.checklistRepo.GetAdjacentPosition(taskID, anchor.Position, item.ID, before)
+	if err != nil {
+		s.logger.WithError(err).WithField("item_id", itemID).Error("Failed to get adjacent checklist position")
+		return nil, response.RepositoryError("failed to move checklist item")
+	}
+
+	lower, upper := anchor.Position, adjacent
+	if before {
+		lower, upper = adjacent, anchor.Position
+	}
+	position, err := rank.Between(lower, upper)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"item_id": itemID,
+			"lower":   lower,
+			"upper":   upper,
+		}).Error("Failed to compute checklist position")
+		return nil, response.GeneralError("failed to move checklist item")
+	}
+	item.Position = position
+
+	return s.saveItem(task, item)
+}
+
+func (s *checklistService) DeleteItem(taskID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	task, custErr := s.ensureTaskAccess(taskID, userID, workspaceID, enum.RoleEditor)
+	if custErr != nil {
+		return custErr
+	}
+
+	if err := s.checklistRepo.Delete(itemID, taskID); err != nil {
+		s.logger.WithError(err).WithField("item_id", itemID).Error("Failed to delete checklist item")
+		return response.RepositoryError("failed to delete checklist item")
+	}
+
+	publishInvalidateTaskCaches(s.cache, s.logger, s.memberRepo, task)
+
+	s.logger.WithFields(logrus.Fields{
+		"item_id": itemID,
+		"task_id": taskID,
+		"user_id": userID,
+	}).Info("Checklist item deleted successfully")
+
+	return nil
+}
+
+// ensureTaskAccess checks that the task exists and that the user's role on
+// it allows the action.
+func (s *checklistService) ensureTaskAccess(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, required enum.TaskRole) (*models.Task, *response.CustomError) {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for checklist")
+		return nil, taskLookupError(err, "failed to get task")
+	}
+	if custErr := requireTaskRole(s.memberRepo, s.logger, task, userID, required); custErr != nil {
+		return nil, custErr
+	}
+	return task, nil
+}
+
+// getItem loads an item of a task the user may edit.
+func (s *checklistService) getItem(taskID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Task, *models.ChecklistItem, *response.CustomError) {
+	task, custErr := s.ensureTaskAccess(taskID, userID, workspaceID, enum.RoleEditor)
+	if custErr != nil {
+		return nil, nil, custErr
+	}
+
+	item, err := s.checklistRepo.GetByID(itemID, taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("item_id", itemID).Error("Failed to get checklist item")
+		return nil, nil, response.RepositoryError("failed to get checklist item")
+	}
+
+	return task, item, nil
+}
+
+// saveItem persists a changed item and drops the cached task lists, whose
+// checklist counts may now be stale.
+func (s *checklistService) saveItem(task *models.Task, item *models.ChecklistItem) (*params.ChecklistItemResponse, *response.CustomError) {
+	if err := s.checklistRepo.Update(item); err != nil {
+		s.logger.WithError(err).WithField("item_id", item.ID).Error("Failed to update checklist item")
+		return nil, response.RepositoryError("failed to update checklist item")
+	}
+
+	publishInvalidateTaskCaches(s.cache, s.logger, s.memberRepo, task)
+
+	s.logger.WithFields(logrus.Fields{
+		"item_id": item.ID,
+		"task_id": item.TaskID,
+		"checked": item.Checked,
+	}).Info("Checklist item updated successfully")
+
+	return toChecklistItemResponse(item), nil
+}
+
+func toChecklistItemResponse(item *models.ChecklistItem) *params.ChecklistItemResponse {
+	return &params.ChecklistItemResponse{
+		ID:        item.ID,
+		Text:      item.Text,
+		Checked:   item.Checked,
+		Position:  item.Position,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
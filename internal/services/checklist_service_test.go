@@ -0,0 +1,29 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecklistOfAnotherWorkspaceIsNotFound(t *testing.T) {
+	tasks := new(repositories.MockBookRepository)
+	checklists := new(repositories.MockChecklistRepository)
+	service := NewChecklistService(tasks, checklists, new(repositories.MockTaskMemberRepository), newTestLogger(), newTestCache(t))
+	userID, taskID, otherWorkspaceID := uuid.New(), uuid.New(), uuid.New()
+
+	tasks.On("GetByID", taskID, userID, otherWorkspaceID).Return(nil, repositories.ErrTaskNotFound)
+
+	_, custErr := service.AddItem(taskID, userID, otherWorkspaceID, &params.CreateChecklistItemRequest{Text: "Vocabulary list"})
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusNotFound, custErr.StatusCode)
+	checklists.AssertNotCalled(t, "Create", mock.Anything)
+}
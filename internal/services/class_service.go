@@ -0,0 +1,529 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"go-corenglish/pkg/rank"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// ClassService manages classes and the assignments a teacher hands out to
+// their students. Users outside a class are told it does not exist. Classes
+// are not scoped to a workspace: students need not share one with their
+// teacher, so assignments land in each student's personal workspace.
+type ClassService interface {
+	CreateClass(userID uuid.UUID, req *params.CreateClassRequest) (*params.ClassResponse, *response.CustomError)
+	GetClasses(userID uuid.UUID) ([]params.ClassResponse, *response.CustomError)
+	GetClass(classID uuid.UUID, userID uuid.UUID) (*params.ClassResponse, *response.CustomError)
+	DeleteClass(classID uuid.UUID, userID uuid.UUID) *response.CustomError
+	GetStudents(classID uuid.UUID, userID uuid.UUID) (*params.ClassStudentsResponse, *response.CustomError)
+	AddStudent(classID uuid.UUID, userID uuid.UUID, req *params.AddClassStudentRequest) (*params.ClassStudentResponse, *response.CustomError)
+	RemoveStudent(classID uuid.UUID, userID uuid.UUID, student string) *response.CustomError
+	CreateAssignment(classID uuid.UUID, userID uuid.UUID, req *params.CreateAssignmentRequest) (*params.AssignmentProgressResponse, *response.CustomError)
+	GetAssignments(classID uuid.UUID, userID uuid.UUID) ([]params.AssignmentResponse, *response.CustomError)
+	GetAssignmentProgress(classID uuid.UUID, assignmentID uuid.UUID, userID uuid.UUID) (*params.AssignmentProgressResponse, *response.CustomError)
+}
+
+type classService struct {
+	classRepo     repositories.ClassRepository
+	taskRepo      repositories.TaskRepository
+	historyRepo   repositories.TaskHistoryRepository
+	userRepo      repositories.UserRepository
+	workspaceRepo repositories.WorkspaceRepository
+	logger        *logrus.Logger
+	cache         *redis.Client
+}
+
+func NewClassService(classRepo repositories.ClassRepository, taskRepo repositories.TaskRepository, historyRepo repositories.TaskHistoryRepository, userRepo repositories.UserRepository, workspaceRepo repositories.WorkspaceRepository, logger *logrus.Logger, cache *redis.Client) ClassService {
+	return &classService{
+		classRepo:     classRepo,
+		taskRepo:      taskRepo,
+		historyRepo:   historyRepo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		logger:        logger,
+		cache:         cache,
+	}
+}
+
+// CreateClass creates a class taught by the user.
+func (s *classService) CreateClass(userID uuid.UUID, req *params.CreateClassRequest) (*params.ClassResponse, *response.CustomError) {
+	class := &models.Class{
+		TeacherID: userID,
+		Name:      req.Name,
+	}
+	if err := s.classRepo.Create(class); err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to create class")
+		return nil, response.RepositoryError("failed to create class")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"class_id": class.ID,
+		"user_id":  userID,
+	}).Info("Class created successfully")
+
+	return toClassResponse(class, enum.ClassTeacher), nil
+}
+
+// GetClasses lists the classes the user teaches or attends.
+func (s *classService) GetClasses(userID uuid.UUID) ([]params.ClassResponse, *response.CustomError) {
+	classes, err := s.classRepo.GetAll(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get classes")
+		return nil, response.RepositoryError("failed to get classes")
+	}
+
+	result := make([]params.ClassResponse, len(classes))
+	for i := range classes {
+		role := enum.ClassStudent
+		if classes[i].TeacherID == userID {
+			role = enum.ClassTeacher
+		}
+		result[i] = *toClassResponse(&classes[i], role)
+	}
+	return result, nil
+}
+
+func (s *classService) GetClass(classID uuid.UUID, userID uuid.UUID) (*params.ClassResponse, *response.CustomError) {
+	class, role, custErr := s.getClass(classID, userID, enum.ClassStudent)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	return toClassResponse(class, role), nil
+}
+
+// DeleteClass removes a class with its assignments. The students keep the
+// tasks they were given, outside of any assignment.
+func (s *classService) DeleteClass(classID uuid.UUID, userID uuid.UUID) *response.CustomError {
+	if _, _, custErr := s.getClass(classID, userID, enum.ClassTeacher); custErr != nil {
+		return custErr
+	}
+
+	assigneeIDs, err := s.classRepo.GetAssigneeIDs(classID)
+	if err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to get class assignees")
+		return response.RepositoryError("failed to delete class")
+	}
+
+	if err := s.classRepo.Delete(classID, userID); err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to delete class")
+		return response.RepositoryError("failed to delete class")
+	}
+
+	// The students' tasks lost their assignment_id in every cached list.
+	for _, assigneeID := range assigneeIDs {
+		publishInvalidateUserTasksCache(s.cache, s.logger, assigneeID)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"class_id": classID,
+		"user_id":  userID,
+	}).Info("Class deleted successfully")
+
+	return nil
+}
+
+func (s *classService) GetStudents(classID uuid.UUID, userID uuid.UUID) (*params.ClassStudentsResponse, *response.CustomError) {
+	if _, _, custErr := s.getClass(classID, userID, enum.ClassTeacher); custErr != nil {
+		return nil, custErr
+	}
+
+	students, err := s.classRepo.GetStudents(classID)
+	if err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to get class students")
+		return nil, response.RepositoryError("failed to get class students")
+	}
+
+	result := &params.ClassStudentsResponse{
+		ClassID:  classID,
+		Students: make([]params.ClassStudentResponse, len(students)),
+	}
+	for i := range students {
+		result.Students[i] = *toClassStudentResponse(&students[i])
+	}
+	return result, nil
+}
+
+// AddStudent enrols a user, given by username or email, in a class. Only
+// the teacher may enrol students. Assignments handed out before are not
+// given to the new student.
+func (s *classService) AddStudent(classID uuid.UUID, userID uuid.UUID, req *params.AddClassStudentRequest) (*params.ClassStudentResponse, *response.CustomError) {
+	if (req.Username == "") == (req.Email == "") {
+		return nil, response.BadRequestError("exactly one of username or email is required")
+	}
+
+	class, _, custErr := s.getClass(classID, userID, enum.ClassTeacher)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	identifier := req.Username
+	if req.Email != "" {
+		identifier = req.Email
+	}
+	user, custErr := findUser(s.userRepo, identifier)
+	if custErr != nil {
+		return nil, custErr
+	}
+	if user.ID == class.TeacherID {
+		return nil, response.BadRequestError("the teacher cannot be enrolled in their own class")
+	}
+
+	student := &models.ClassStudent{
+		ClassID:   classID,
+		UserID:    user.ID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.classRepo.AddStudent(student); err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to add class student")
+		return nil, response.RepositoryError("failed to add class student")
+	}
+	student.User = *user
+
+	s.logger.WithFields(logrus.Fields{
+		"class_id":   classID,
+		"user_id":    userID,
+		"student_id": user.ID,
+	}).Info("Class student added successfully")
+
+	return toClassStudentResponse(student), nil
+}
+
+// RemoveStudent ends the enrolment of a student, given by username or
+// email. The teacher may remove anyone and a student may leave on their own.
+// Tasks already handed out stay with the student.
+func (s *classService) RemoveStudent(classID uuid.UUID, userID uuid.UUID, student string) *response.CustomError {
+	_, role, custErr := s.getClass(classID, userID, enum.ClassStudent)
+	if custErr != nil {
+		return custErr
+	}
+
+	user, custErr := findUser(s.userRepo, student)
+	if custErr != nil {
+		return custErr
+	}
+	if user.ID != userID && role != enum.ClassTeacher {
+		return response.ForbiddenError("teacher access to the class is required")
+	}
+
+	current, custErr := s.getRole(classID, user.ID)
+	if custErr != nil {
+		return custErr
+	}
+	if current != enum.ClassStudent {
+		return response.NotFoundError("class student not found")
+	}
+
+	if err := s.classRepo.RemoveStudent(classID, user.ID); err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to remove class student")
+		return response.RepositoryError("failed to remove class student")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"class_id":   classID,
+		"user_id":    userID,
+		"student_id": user.ID,
+	}).Info("Class student removed successfully")
+
+	return nil
+}
+
+// CreateAssignment hands a task out to every student of the class. Each
+// student gets their own task in their personal workspace, and all of them
+// are created in a single transaction. The students' WIP limits are not
+// checked: an assignment reaches the whole class even when a student's TO_DO
+// column is full.
+func (s *classService) CreateAssignment(classID uuid.UUID, userID uuid.UUID, req *params.CreateAssignmentRequest) (*params.AssignmentProgressResponse, *response.CustomError) {
+	assignment := &models.ClassAssignment{
+		ClassID:     classID,
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    enum.PriorityMedium,
+		DueAt:       req.DueAt,
+	}
+	if req.Priority != nil {
+		if !req.Priority.IsValid() {
+			return nil, response.BadRequestError(fmt.Sprintf("invalid priority: %s", *req.Priority))
+		}
+		assignment.Priority = *req.Priority
+	}
+
+	if _, _, custErr := s.getClass(classID, userID, enum.ClassTeacher); custErr != nil {
+		return nil, custErr
+	}
+
+	students, err := s.classRepo.GetStudents(classID)
+	if err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to get class students")
+		return nil, response.RepositoryError("failed to get class students")
+	}
+	if len(students) == 0 {
+		return nil, response.BadRequestError("the class has no students")
+	}
+
+	tasks, custErr := s.assignmentTasks(assignment, students)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	if err := s.classRepo.CreateAssignment(assignment, tasks); err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to create assignment")
+		return nil, response.RepositoryError("failed to create assignment")
+	}
+
+	for i := range tasks {
+		s.recordCreated(&tasks[i])
+		publishInvalidateTaskListsCache(s.cache, s.logger, tasks[i].UserID)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"assignment_id": assignment.ID,
+		"class_id":      classID,
+		"user_id":       userID,
+		"students":      len(tasks),
+	}).Info("Assignment handed out successfully")
+
+	return s.assignmentProgress(assignment, students)
+}
+
+// GetAssignments lists the assignments of a class, newest first. Students
+// see them too; their own task for each one is in their task list.
+func (s *classService) GetAssignments(classID uuid.UUID, userID uuid.UUID) ([]params.AssignmentResponse, *response.CustomError) {
+	if _, _, custErr := s.getClass(classID, userID, enum.ClassStudent); custErr != nil {
+		return nil, custErr
+	}
+
+	assignments, err := s.classRepo.GetAssignments(classID)
+	if err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to get assignments")
+		return nil, response.RepositoryError("failed to get assignments")
+	}
+
+	result := make([]params.AssignmentResponse, len(assignments))
+	for i := range assignments {
+		result[i] = *toAssignmentResponse(&assignments[i])
+	}
+	return result, nil
+}
+
+// GetAssignmentProgress shows the teacher the status of every student's
+// task for an assignment.
+func (s *classService) GetAssignmentProgress(classID uuid.UUID, assignmentID uuid.UUID, userID uuid.UUID) (*params.AssignmentProgressResponse, *response.CustomError) {
+	if _, _, custErr := s.getClass(classID, userID, enum.ClassTeacher); custErr != nil {
+		return nil, custErr
+	}
+
+	assignment, err := s.classRepo.GetAssignment(assignmentID, classID)
+	if err != nil {
+		s.logger.WithError(err).WithField("assignment_id", assignmentID).Error("Failed to get assignment")
+		return nil, response.RepositoryError("failed to get assignment")
+	}
+
+	students, err := s.classRepo.GetStudents(classID)
+	if err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to get class students")
+		return nil, response.RepositoryError("failed to get class students")
+	}
+
+	return s.assignmentProgress(assignment, students)
+}
+
+// assignmentTasks builds the task of every student for an assignment, each
+// placed at the end of the student's own task order.
+func (s *classService) assignmentTasks(assignment *models.ClassAssignment, students []models.ClassStudent) ([]models.Task, *response.CustomError) {
+	studentIDs := make([]uuid.UUID, len(students))
+	for i := range students {
+		studentIDs[i] = students[i].UserID
+	}
+
+	workspaceIDs, err := s.workspaceRepo.GetPersonalIDs(studentIDs)
+	if err != nil {
+		s.logger.WithError(err).WithField("class_id", assignment.ClassID).Error("Failed to get personal workspaces of students")
+		return nil, response.RepositoryError("failed to create assignment")
+	}
+
+	tasks := make([]models.Task, 0, len(students))
+	for _, studentID := range studentIDs {
+		workspaceID, ok := workspaceIDs[studentID]
+		if !ok {
+			s.logger.WithField("user_id", studentID).Error("Student has no personal workspace")
+			return nil, response.GeneralError("failed to create assignment")
+		}
+
+		last, err := s.taskRepo.GetLastPosition(studentID, workspaceID)
+		if err != nil {
+			s.logger.WithError(err).WithField("user_id", studentID).Error("Failed to get last task position")
+			return nil, response.RepositoryError("failed to get task position")
+		}
+		position, err := rank.Between(last, "")
+		if err != nil {
+			s.logger.WithError(err).WithField("last", last).Error("Failed to compute task position")
+			return nil, response.GeneralError("failed to compute task position")
+		}
+
+		tasks = append(tasks, models.Task{
+			ID:              uuid.New(),
+			Title:           assignment.Title,
+			Description:     assignment.Description,
+			Status:          enum.StatusToDo,
+			Priority:        assignment.Priority,
+			UserID:          studentID,
+			WorkspaceID:     workspaceID,
+			DueAt:           assignment.DueAt,
+			Position:        position,
+			ReminderChannel: enum.ReminderChannelEmail,
+		})
+	}
+	return tasks, nil
+}
+
+// recordCreated starts the status history of a task handed out to a
+// student. The task is already saved, so a failure is only logged.
+func (s *classService) recordCreated(task *models.Task) {
+	entry := &models.TaskStatusHistory{
+		TaskID:    task.ID,
+		UserID:    task.UserID,
+		ToStatus:  task.Status,
+		ChangedAt: time.Now(),
+	}
+
+	if err := s.historyRepo.Create(entry); err != nil {
+		s.logger.WithError(err).WithField("task_id", task.ID).Warn("Failed to record task status change")
+	}
+}
+
+// assignmentProgress lists every student who holds a task for the
+// assignment, followed by the enrolled students who do not, by username.
+func (s *classService) assignmentProgress(assignment *models.ClassAssignment, students []models.ClassStudent) (*params.AssignmentProgressResponse, *response.CustomError) {
+	tasks, err := s.classRepo.GetAssignmentTasks(assignment.ID)
+	if err != nil {
+		s.logger.WithError(err).WithField("assignment_id", assignment.ID).Error("Failed to get assignment tasks")
+		return nil, response.RepositoryError("failed to get assignment progress")
+	}
+
+	enrolled := make(map[uuid.UUID]bool, len(students))
+	for _, student := range students {
+		enrolled[student.UserID] = true
+	}
+
+	result := &params.AssignmentProgressResponse{
+		Assignment: *toAssignmentResponse(assignment),
+		Students:   make([]params.AssignmentStudentStatus, 0, len(students)),
+	}
+	assigned := make(map[uuid.UUID]bool, len(tasks))
+	for i := range tasks {
+		task := &tasks[i]
+		assigned[task.UserID] = true
+
+		status := params.AssignmentStudentStatus{
+			UserID:      task.UserID,
+			Username:    task.Username,
+			Enrolled:    enrolled[task.UserID],
+			TaskID:      &task.TaskID,
+			Status:      &task.Status,
+			Completed:   task.Status.IsDone(),
+			CompletedAt: task.CompletedAt,
+			Deleted:     task.DeletedAt != nil,
+		}
+		result.Students = append(result.Students, status)
+		result.Total++
+		if status.Completed {
+			result.Completed++
+		}
+	}
+
+	var unassigned []params.AssignmentStudentStatus
+	for i := range students {
+		if assigned[students[i].UserID] {
+			continue
+		}
+		unassigned = append(unassigned, params.AssignmentStudentStatus{
+			UserID:   students[i].UserID,
+			Username: students[i].User.Username,
+			Enrolled: true,
+		})
+	}
+	sort.Slice(unassigned, func(i, j int) bool {
+		return unassigned[i].Username < unassigned[j].Username
+	})
+	result.Students = append(result.Students, unassigned...)
+
+	return result, nil
+}
+
+// getClass loads a class the user teaches or attends and checks that their
+// role allows what is required. Everyone else is told the class does not
+// exist.
+func (s *classService) getClass(classID uuid.UUID, userID uuid.UUID, required enum.ClassRole) (*models.Class, enum.ClassRole, *response.CustomError) {
+	role, custErr := s.getRole(classID, userID)
+	if custErr != nil {
+		return nil, "", custErr
+	}
+	if role == "" {
+		return nil, "", response.NotFoundError("class not found")
+	}
+	if required == enum.ClassTeacher && role != enum.ClassTeacher {
+		return nil, "", response.ForbiddenError("teacher access to the class is required")
+	}
+
+	class, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		s.logger.WithError(err).WithField("class_id", classID).Error("Failed to get class")
+		return nil, "", response.RepositoryError("failed to get class")
+	}
+	return class, role, nil
+}
+
+func (s *classService) getRole(classID uuid.UUID, userID uuid.UUID) (enum.ClassRole, *response.CustomError) {
+	role, err := s.classRepo.GetRole(classID, userID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"class_id": classID,
+			"user_id":  userID,
+		}).Error("Failed to get class role")
+		return "", response.RepositoryError("failed to get class role")
+	}
+	return role, nil
+}
+
+func toClassResponse(class *models.Class, role enum.ClassRole) *params.ClassResponse {
+	return &params.ClassResponse{
+		ID:        class.ID,
+		Name:      class.Name,
+		TeacherID: class.TeacherID,
+		Role:      role,
+		CreatedAt: class.CreatedAt,
+		UpdatedAt: class.UpdatedAt,
+	}
+}
+
+func toClassStudentResponse(student *models.ClassStudent) *params.ClassStudentResponse {
+	return &params.ClassStudentResponse{
+		UserID:    student.UserID,
+		Username:  student.User.Username,
+		CreatedAt: student.CreatedAt,
+	}
+}
+
+func toAssignmentResponse(assignment *models.ClassAssignment) *params.AssignmentResponse {
+	return &params.AssignmentResponse{
+		ID:          assignment.ID,
+		ClassID:     assignment.ClassID,
+		Title:       assignment.Title,
+		Description: assignment.Description,
+		Priority:    assignment.Priority,
+		DueAt:       assignment.DueAt,
+		CreatedAt:   assignment.CreatedAt,
+		UpdatedAt:   assignment.UpdatedAt,
+	}
+}
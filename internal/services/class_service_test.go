@@ -0,0 +1,141 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// classServiceMocks holds the repositories behind a classService under test.
+type classServiceMocks struct {
+	classes    *repositories.MockClassRepository
+	tasks      *repositories.MockBookRepository
+	workspaces *repositories.MockWorkspaceRepository
+}
+
+// newTestClassService returns a service where teacherID teaches classID.
+func newTestClassService(t *testing.T, classID uuid.UUID, teacherID uuid.UUID) (ClassService, *classServiceMocks) {
+	m := &classServiceMocks{
+		classes:    new(repositories.MockClassRepository),
+		tasks:      new(repositories.MockBookRepository),
+		workspaces: new(repositories.MockWorkspaceRepository),
+	}
+	t.Cleanup(func() {
+		m.classes.AssertExpectations(t)
+		m.tasks.AssertExpectations(t)
+		m.workspaces.AssertExpectations(t)
+	})
+
+	// The progress counts depend on the status categories.
+	newTestWorkflow(t)
+
+	m.classes.On("GetRole", classID, teacherID).Return(enum.ClassTeacher, nil)
+	m.classes.On("GetByID", classID).Return(&models.Class{ID: classID, Name: "Grammar 101", TeacherID: teacherID}, nil)
+
+	history := new(repositories.MockTaskHistoryRepository)
+	history.On("Create", mock.Anything).Return(nil).Maybe()
+
+	service := NewClassService(m.classes, m.tasks, history, new(repositories.MockUserRepository), m.workspaces, newTestLogger(), newTestCache(t))
+	return service, m
+}
+
+func student(classID uuid.UUID, username string) models.ClassStudent {
+	return models.ClassStudent{ClassID: classID, UserID: uuid.New(), User: models.User{Username: username}}
+}
+
+func TestCreateAssignmentGivesEveryStudentATaskInTheirPersonalWorkspace(t *testing.T) {
+	classID, teacherID := uuid.New(), uuid.New()
+	service, m := newTestClassService(t, classID, teacherID)
+	students := []models.ClassStudent{student(classID, "alice"), student(classID, "bob")}
+	personal := map[uuid.UUID]uuid.UUID{
+		students[0].UserID: uuid.New(),
+		students[1].UserID: uuid.New(),
+	}
+
+	var tasks []models.Task
+	m.classes.On("GetStudents", classID).Return(students, nil)
+	m.workspaces.On("GetPersonalIDs", []uuid.UUID{students[0].UserID, students[1].UserID}).Return(personal, nil)
+	m.tasks.On("GetLastPosition", students[0].UserID, personal[students[0].UserID]).Return("a1", nil)
+	m.tasks.On("GetLastPosition", students[1].UserID, personal[students[1].UserID]).Return("", nil)
+	m.classes.On("CreateAssignment", mock.AnythingOfType("*models.ClassAssignment"), mock.Anything).Run(func(args mock.Arguments) {
+		tasks = args.Get(1).([]models.Task)
+	}).Return(nil)
+	m.classes.On("GetAssignmentTasks", mock.Anything).Return([]repositories.AssignmentTaskStatus{
+		{TaskID: uuid.New(), UserID: students[0].UserID, Username: "alice", Status: enum.StatusToDo},
+		{TaskID: uuid.New(), UserID: students[1].UserID, Username: "bob", Status: enum.StatusToDo},
+	}, nil)
+
+	dueAt := time.Now().Add(48 * time.Hour)
+	resp, custErr := service.CreateAssignment(classID, teacherID, &params.CreateAssignmentRequest{Title: "Essay 3", DueAt: &dueAt})
+
+	require.Nil(t, custErr)
+	require.Len(t, tasks, 2)
+	for i, task := range tasks {
+		assert.Equal(t, students[i].UserID, task.UserID)
+		assert.Equal(t, personal[students[i].UserID], task.WorkspaceID)
+		assert.Equal(t, "Essay 3", task.Title)
+		assert.Equal(t, enum.StatusToDo, task.Status)
+		assert.Equal(t, &dueAt, task.DueAt)
+	}
+	assert.Greater(t, tasks[0].Position, "a1")
+	assert.NotEmpty(t, tasks[1].Position)
+
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, 0, resp.Completed)
+}
+
+func TestCreateAssignmentNeedsEveryPersonalWorkspace(t *testing.T) {
+	classID, teacherID := uuid.New(), uuid.New()
+	service, m := newTestClassService(t, classID, teacherID)
+	students := []models.ClassStudent{student(classID, "alice")}
+
+	m.classes.On("GetStudents", classID).Return(students, nil)
+	m.workspaces.On("GetPersonalIDs", []uuid.UUID{students[0].UserID}).Return(map[uuid.UUID]uuid.UUID{}, nil)
+
+	_, custErr := service.CreateAssignment(classID, teacherID, &params.CreateAssignmentRequest{Title: "Essay 3"})
+
+	require.NotNil(t, custErr)
+	m.classes.AssertNotCalled(t, "CreateAssignment", mock.Anything, mock.Anything)
+}
+
+func TestGetAssignmentProgressCountsTheStudentsTasks(t *testing.T) {
+	classID, teacherID := uuid.New(), uuid.New()
+	service, m := newTestClassService(t, classID, teacherID)
+	assignment := &models.ClassAssignment{ID: uuid.New(), ClassID: classID, Title: "Essay 3"}
+	alice, bob, dave := student(classID, "alice"), student(classID, "bob"), student(classID, "dave")
+	carolID := uuid.New()
+	completedAt := time.Now().Add(-time.Hour)
+
+	m.classes.On("GetAssignment", assignment.ID, classID).Return(assignment, nil)
+	// Dave enrolled after the assignment was handed out.
+	m.classes.On("GetStudents", classID).Return([]models.ClassStudent{dave, bob, alice}, nil)
+	// Carol finished her task and then left the class.
+	m.classes.On("GetAssignmentTasks", assignment.ID).Return([]repositories.AssignmentTaskStatus{
+		{TaskID: uuid.New(), UserID: alice.UserID, Username: "alice", Status: enum.StatusDone, CompletedAt: &completedAt},
+		{TaskID: uuid.New(), UserID: bob.UserID, Username: "bob", Status: enum.StatusInProgress},
+		{TaskID: uuid.New(), UserID: carolID, Username: "carol", Status: enum.StatusDone, CompletedAt: &completedAt},
+	}, nil)
+
+	resp, custErr := service.GetAssignmentProgress(classID, assignment.ID, teacherID)
+
+	require.Nil(t, custErr)
+	assert.Equal(t, 3, resp.Total)
+	assert.Equal(t, 2, resp.Completed)
+	require.Len(t, resp.Students, 4)
+
+	assert.True(t, resp.Students[0].Completed)
+	assert.False(t, resp.Students[1].Completed)
+	assert.False(t, resp.Students[2].Enrolled)
+	assert.Equal(t, "dave", resp.Students[3].Username)
+	assert.True(t, resp.Students[3].Enrolled)
+	assert.Nil(t, resp.Students[3].TaskID)
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type CommentService interface {
+	CreateComment(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateCommentRequest) (*params.CommentResponse, *response.CustomError)
+	GetComments(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, cursor string, limit int) (*params.CommentsResponse, *response.CustomError)
+	UpdateComment(taskID uuid.UUID, commentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.UpdateCommentRequest) (*params.CommentResponse, *response.CustomError)
+	DeleteComment(taskID uuid.UUID, commentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError
+}
+
+type commentService struct {
+	taskRepo    repositories.TaskRepository
+	commentRepo repositories.CommentRepository
+	memberRepo  repositories.TaskMemberRepository
+	logger      *logrus.Logger
+	cache       *redis.Client
+}
+
+func NewCommentService(taskRepo repositories.TaskRepository, commentRepo repositories.CommentRepository, memberRepo repositories.TaskMemberRepository, logger *logrus.Logger, cache *redis.Client) CommentService {
+	return &commentService{
+		taskRepo:    taskRepo,
+		commentRepo: commentRepo,
+		memberRepo:  memberRepo,
+		logger:      logger,
+		cache:       cache,
+	}
+}
+
+func (s *commentService) CreateComment(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateCommentRequest) (*params.CommentResponse, *response.CustomError) {
+	task, custErr := s.ensureTaskAccess(taskID, userID, workspaceID)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	comment := &models.TaskComment{
+		TaskID: taskID,
+		UserID: userID,
+		Body:   req.Body,
+	}
+	if err := s.commentRepo.Create(comment); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to create comment")
+		return nil, response.RepositoryError("failed to create comment")
+	}
+
+	// Reload to return the author together with the comment.
+	created, err := s.commentRepo.GetByID(comment.ID, taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("comment_id", comment.ID).Error("Failed to get created comment")
+		return nil, response.RepositoryError("failed to get comment")
+	}
+
+	publishInvalidateTaskCaches(s.cache, s.logger, s.memberRepo, task)
+
+	s.logger.WithFields(logrus.Fields{
+		"comment_id": comment.ID,
+		"task_id":    taskID,
+		"user_id":    userID,
+	}).Info("Comment created successfully")
+
+	return toCommentResponse(created), nil
+}
+
+// GetComments returns a page of the task's comments, oldest first. cursor is
+// the next_cursor of the previous page, or empty for the first page.
+func (s *commentService) GetComments(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, cursor string, limit int) (*params.CommentsResponse, *response.CustomError) {
+	var after *repositories.CommentCursor
+	if cursor != "" {
+		decoded, err := decodeCommentCursor(cursor)
+		if err != nil {
+			return nil, response.BadRequestError("invalid cursor")
+		}
+		after = decoded
+	}
+
+	if _, custErr := s.ensureTaskAccess(taskID, userID, workspaceID); custErr != nil {
+		return nil, custErr
+	}
+
+	// Fetch one extra comment to learn whether another page follows.
+	comments, err := s.commentRepo.GetByTaskID(taskID, after, limit+1)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get comments")
+		return nil, response.RepositoryError("failed to get comments")
+	}
+
+	page := &params.CommentsResponse{
+		Limit: limit,
+	}
+	if len(comments) > limit {
+		comments = comments[:limit]
+		last := comments[len(comments)-1]
+		next := encodeCommentCursor(&repositories.CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		page.NextCursor = &next
+	}
+
+	page.Comments = make([]params.CommentResponse, len(comments))
+	for i := range comments {
+		page.Comments[i] = *toCommentResponse(&comments[i])
+	}
+
+	return page, nil
+}
+
+func (s *commentService) UpdateComment(taskID uuid.UUID, commentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.UpdateCommentRequest) (*params.CommentResponse, *response.CustomError) {
+	if _, custErr := s.ensureTaskAccess(taskID, userID, workspaceID); custErr != nil {
+		return nil, custErr
+	}
+
+	comment, err := s.commentRepo.GetByID(commentID, taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("comment_id", commentID).Error("Failed to get comment for update")
+		return nil, commentLookupError(err, "failed to get comment")
+	}
+
+	if comment.UserID != userID {
+		return nil, response.ForbiddenError("only the author can edit a comment")
+	}
+
+	if comment.Body != req.Body {
+		now := time.Now()
+		comment.Body = req.Body
+		comment.EditedAt = &now
+
+		if err := s.commentRepo.Update(comment); err != nil {
+			s.logger.WithError(err).WithField("comment_id", commentID).Error("Failed to update comment")
+			return nil, commentLookupError(err, "failed to update comment")
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"comment_id": commentID,
+		"task_id":    taskID,
+		"user_id":    userID,
+	}).Info("Comment updated successfully")
+
+	return toCommentResponse(comment), nil
+}
+
+func (s *commentService) DeleteComment(taskID uuid.UUID, commentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	task, custErr := s.ensureTaskAccess(taskID, userID, workspaceID)
+	if custErr != nil {
+		return custErr
+	}
+
+	comment, err := s.commentRepo.GetByID(commentID, taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("comment_id", commentID).Error("Failed to get comment for deletion")
+		return commentLookupError(err, "failed to get comment")
+	}
+
+	if comment.UserID != userID {
+		return response.ForbiddenError("only the author can delete a comment")
+	}
+
+	if err := s.commentRepo.Delete(commentID, taskID, userID); err != nil {
+		s.logger.WithError(err).WithField("comment_id", commentID).Error("Failed to delete comment")
+		return commentLookupError(err, "failed to delete comment")
+	}
+
+	publishInvalidateTaskCaches(s.cache, s.logger, s.memberRepo, task)
+
+	s.logger.WithFields(logrus.Fields{
+		"comment_id": commentID,
+		"task_id":    taskID,
+		"user_id":    userID,
+	}).Info("Comment deleted successfully")
+
+	return nil
+}
+
+// ensureTaskAccess checks that the user may see the task, and therefore
+// read and write its comments. Viewers may comment too.
+func (s *commentService) ensureTaskAccess(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Task, *response.CustomError) {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for comments")
+		return nil, taskLookupError(err, "failed to get task")
+	}
+	if custErr := requireTaskRole(s.memberRepo, s.logger, task, userID, enum.RoleViewer); custErr != nil {
+		return nil, custErr
+	}
+	return task, nil
+}
+
+// commentLookupError answers a failed comment lookup. A comment that does not
+// exist on the task is not found; any other failure is reported with message.
+func commentLookupError(err error, message string) *response.CustomError {
+	if errors.Is(err, repositories.ErrCommentNotFound) {
+		return response.NotFoundError("comment not found")
+	}
+	return response.RepositoryError(message)
+}
+
+// encodeCommentCursor turns a cursor into the opaque next_cursor string.
+func encodeCommentCursor(cursor *repositories.CommentCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCommentCursor(value string) (*repositories.CommentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), ",")
+	if !found {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	cursor := &repositories.CommentCursor{}
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, err
+	}
+	if cursor.ID, err = uuid.Parse(id); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
+
+func toCommentResponse(comment *models.TaskComment) *params.CommentResponse {
+	return &params.CommentResponse{
+		ID:   comment.ID,
+		Body: comment.Body,
+		Author: params.CommentAuthorResponse{
+			ID:       comment.User.ID,
+			Username: comment.User.Username,
+		},
+		Edited:    comment.EditedAt != nil,
+		EditedAt:  comment.EditedAt,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCommentService(t *testing.T) (CommentService, *repositories.MockBookRepository, *repositories.MockCommentRepository) {
+	tasks := new(repositories.MockBookRepository)
+	comments := new(repositories.MockCommentRepository)
+	t.Cleanup(func() {
+		tasks.AssertExpectations(t)
+		comments.AssertExpectations(t)
+	})
+
+	service := NewCommentService(tasks, comments, new(repositories.MockTaskMemberRepository), newTestLogger(), newTestCache(t))
+	return service, tasks, comments
+}
+
+func TestUpdateCommentOfAnotherAuthorIsForbidden(t *testing.T) {
+	service, tasks, comments := newTestCommentService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	// Even the owner of the task cannot edit what someone else wrote.
+	comment := &models.TaskComment{ID: uuid.New(), TaskID: task.ID, UserID: uuid.New(), Body: "Done?"}
+
+	tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+	comments.On("GetByID", comment.ID, task.ID).Return(comment, nil)
+
+	_, custErr := service.UpdateComment(task.ID, comment.ID, userID, task.WorkspaceID, &params.UpdateCommentRequest{Body: "Not yet"})
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusForbidden, custErr.StatusCode)
+	comments.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestDeleteCommentOfAnotherAuthorIsForbidden(t *testing.T) {
+	service, tasks, comments := newTestCommentService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	comment := &models.TaskComment{ID: uuid.New(), TaskID: task.ID, UserID: uuid.New(), Body: "Done?"}
+
+	tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+	comments.On("GetByID", comment.ID, task.ID).Return(comment, nil)
+
+	custErr := service.DeleteComment(task.ID, comment.ID, userID, task.WorkspaceID)
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusForbidden, custErr.StatusCode)
+	comments.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommentThatDoesNotExistIsNotFound(t *testing.T) {
+	userID := uuid.New()
+	task := ownedTask(userID)
+	commentID := uuid.New()
+
+	tests := []struct {
+		name string
+		call func(service CommentService) *response.CustomError
+	}{
+		{
+			name: "update",
+			call: func(service CommentService) *response.CustomError {
+				_, custErr := service.UpdateComment(task.ID, commentID, userID, task.WorkspaceID, &params.UpdateCommentRequest{Body: "Not yet"})
+				return custErr
+			},
+		},
+		{
+			name: "delete",
+			call: func(service CommentService) *response.CustomError {
+				custErr := service.DeleteComment(task.ID, commentID, userID, task.WorkspaceID)
+				return custErr
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, tasks, comments := newTestCommentService(t)
+			tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+			comments.On("GetByID", commentID, task.ID).Return(nil, repositories.ErrCommentNotFound)
+
+			custErr := tt.call(service)
+
+			require.NotNil(t, custErr)
+			assert.Equal(t, http.StatusNotFound, custErr.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"errors"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type DependencyService interface {
+	AddDependency(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.AddDependencyRequest) (*params.DependenciesResponse, *response.CustomError)
+	GetDependencies(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.DependenciesResponse, *response.CustomError)
+	RemoveDependency(taskID uuid.UUID, blockedByID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError
+}
+
+type dependencyService struct {
+	taskRepo       repositories.TaskRepository
+	dependencyRepo repositories.TaskDependencyRepository
+	memberRepo     repositories.TaskMemberRepository
+	logger         *logrus.Logger
+}
+
+func NewDependencyService(taskRepo repositories.TaskRepository, dependencyRepo repositories.TaskDependencyRepository, memberRepo repositories.TaskMemberRepository, logger *logrus.Logger) DependencyService {
+	return &dependencyService{
+		taskRepo:       taskRepo,
+		dependencyRepo: dependencyRepo,
+		memberRepo:     memberRepo,
+		logger:         logger,
+	}
+}
+
+func (s *dependencyService) AddDependency(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.AddDependencyRequest) (*params.DependenciesResponse, *response.CustomError) {
+	if taskID == req.BlockedByID {
+		return nil, response.BadRequestError("a task cannot be blocked by itself")
+	}
+
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for dependency")
+		return nil, taskLookupError(err, "failed to get task")
+	}
+	if custErr := requireTaskRole(s.memberRepo, s.logger, task, userID, enum.RoleEditor); custErr != nil {
+		return nil, custErr
+	}
+
+	// Only tasks of the same owner in the same workspace can block each other.
+	blocker, err := s.taskRepo.GetByID(req.BlockedByID, userID, workspaceID)
+	if err != nil || blocker.UserID != task.UserID || blocker.WorkspaceID != task.WorkspaceID {
+		return nil, response.BadRequestError("blocking task not found")
+	}
+
+	// The new edge closes a cycle if the blocker already waits on the task.
+	dependency := &models.TaskDependency{
+		TaskID:      taskID,
+		BlockedByID: req.BlockedByID,
+	}
+	if err := s.dependencyRepo.Create(dependency); err != nil {
+		if errors.Is(err, repositories.ErrDependencyCycle) {
+			return nil, response.BadRequestError("dependency would create a cycle")
+		}
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to add dependency")
+		return nil, response.RepositoryError("failed to add dependency")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id":       taskID,
+		"blocked_by_id": req.BlockedByID,
+		"user_id":       userID,
+	}).Info("Task dependency added successfully")
+
+	return s.getDependencies(taskID)
+}
+
+func (s *dependencyService) GetDependencies(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.DependenciesResponse, *response.CustomError) {
+	if _, err := s.taskRepo.GetByID(taskID, userID, workspaceID); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for dependencies")
+		return nil, taskLookupError(err, "failed to get task")
+	}
+
+	return s.getDependencies(taskID)
+}
+
+func (s *dependencyService) RemoveDependency(taskID uuid.UUID, blockedByID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for dependency removal")
+		return taskLookupError(err, "failed to get task")
+	}
+	if custErr := requireTaskRole(s.memberRepo, s.logger, task, userID, enum.RoleEditor); custErr != nil {
+		return custErr
+	}
+
+	if err := s.dependencyRepo.Delete(taskID, blockedByID); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to remove dependency")
+		return response.RepositoryError("failed to remove dependency")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id":       taskID,
+		"blocked_by_id": blockedByID,
+		"user_id":       userID,
+	}).Info("Task dependency removed successfully")
+
+	return nil
+}
+
+func (s *dependencyService) getDependencies(taskID uuid.UUID) (*params.DependenciesResponse, *response.CustomError) {
+	blockers, err := s.dependencyRepo.GetBlockers(taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get dependencies")
+		return nil, response.RepositoryError("failed to get dependencies")
+	}
+
+	return &params.DependenciesResponse{
+		TaskID:    taskID,
+		BlockedBy: toTaskSummaryResponses(blockers),
+	}, nil
+}
+
+func toTaskSummaryResponses(tasks []models.Task) []params.TaskSummaryResponse {
+	responses := make([]params.TaskSummaryResponse, len(tasks))
+	for i, task := range tasks {
+		responses[i] = params.TaskSummaryResponse{
+			ID:     task.ID,
+			Title:  task.Title,
+			Status: task.Status,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDependencyService(t *testing.T) (DependencyService, *repositories.MockBookRepository, *repositories.MockTaskDependencyRepository) {
+	tasks := new(repositories.MockBookRepository)
+	dependencies := new(repositories.MockTaskDependencyRepository)
+	t.Cleanup(func() {
+		tasks.AssertExpectations(t)
+		dependencies.AssertExpectations(t)
+	})
+
+	service := NewDependencyService(tasks, dependencies, new(repositories.MockTaskMemberRepository), newTestLogger())
+	return service, tasks, dependencies
+}
+
+// taskIn returns a task of userID in the given workspace.
+func taskIn(userID uuid.UUID, workspaceID uuid.UUID) *models.Task {
+	task := ownedTask(userID)
+	task.WorkspaceID = workspaceID
+	return task
+}
+
+func TestAddDependencyRefusesCycles(t *testing.T) {
+	userID, workspaceID := uuid.New(), uuid.New()
+	a, b, c := taskIn(userID, workspaceID), taskIn(userID, workspaceID), taskIn(userID, workspaceID)
+
+	tests := []struct {
+		name    string
+		task    *models.Task
+		blocker *models.Task
+		// cycle is whether the blocker already waits on the task, directly
+		// or through other tasks.
+		cycle    bool
+		wantCode int
+	}{
+		// A is blocked by B: B cannot be blocked by A.
+		{name: "direct cycle", task: b, blocker: a, cycle: true, wantCode: http.StatusBadRequest},
+		// A is blocked by B and B by C: C cannot be blocked by A.
+		{name: "indirect cycle", task: c, blocker: a, cycle: true, wantCode: http.StatusBadRequest},
+		// A already waits on C through B, so waiting on it directly is fine.
+		{name: "edge along the chain", task: a, blocker: c},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, tasks, dependencies := newTestDependencyService(t)
+
+			tasks.On("GetByID", tt.task.ID, userID, workspaceID).Return(tt.task, nil)
+			tasks.On("GetByID", tt.blocker.ID, userID, workspaceID).Return(tt.blocker, nil)
+			if tt.cycle {
+				dependencies.On("Create", &models.TaskDependency{TaskID: tt.task.ID, BlockedByID: tt.blocker.ID}).Return(repositories.ErrDependencyCycle)
+			} else {
+				dependencies.On("Create", &models.TaskDependency{TaskID: tt.task.ID, BlockedByID: tt.blocker.ID}).Return(nil)
+				dependencies.On("GetBlockers", tt.task.ID).Return([]models.Task{*tt.blocker}, nil)
+			}
+
+			resp, custErr := service.AddDependency(tt.task.ID, userID, workspaceID, &params.AddDependencyRequest{BlockedByID: tt.blocker.ID})
+
+			if tt.wantCode != 0 {
+				require.NotNil(t, custErr)
+				assert.Equal(t, tt.wantCode, custErr.StatusCode)
+				return
+			}
+			require.Nil(t, custErr)
+			require.Len(t, resp.BlockedBy, 1)
+			assert.Equal(t, tt.blocker.ID, resp.BlockedBy[0].ID)
+		})
+	}
+}
+
+func TestAddDependencyRefusesSelfDependency(t *testing.T) {
+	service, _, dependencies := newTestDependencyService(t)
+	userID, workspaceID := uuid.New(), uuid.New()
+	task := taskIn(userID, workspaceID)
+
+	_, custErr := service.AddDependency(task.ID, userID, workspaceID, &params.AddDependencyRequest{BlockedByID: task.ID})
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusBadRequest, custErr.StatusCode)
+	dependencies.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAddDependencyNeedsABlockerOfTheSameOwnerAndWorkspace(t *testing.T) {
+	userID, workspaceID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name string
+		// blocker is what the lookup of the blocking task returns.
+		blocker *models.Task
+		err     error
+	}{
+		{name: "another workspace", err: repositories.ErrTaskNotFound},
+		{name: "shared by another owner", blocker: taskIn(uuid.New(), workspaceID)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, tasks, dependencies := newTestDependencyService(t)
+			task := taskIn(userID, workspaceID)
+			blockerID := uuid.New()
+			if tt.blocker != nil {
+				blockerID = tt.blocker.ID
+			}
+
+			tasks.On("GetByID", task.ID, userID, workspaceID).Return(task, nil)
+			tasks.On("GetByID", blockerID, userID, workspaceID).Return(tt.blocker, tt.err)
+
+			_, custErr := service.AddDependency(task.ID, userID, workspaceID, &params.AddDependencyRequest{BlockedByID: blockerID})
+
+			require.NotNil(t, custErr)
+			assert.Equal(t, http.StatusBadRequest, custErr.StatusCode)
+			dependencies.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultLabelColor = "#808080"
+
+type LabelService interface {
+	CreateLabel(userID uuid.UUID, req *params.CreateLabelRequest) (*params.LabelResponse, *response.CustomError)
+	GetLabel(labelID uuid.UUID, userID uuid.UUID) (*params.LabelResponse, *response.CustomError)
+	GetLabels(userID uuid.UUID) ([]params.LabelResponse, *response.CustomError)
+	UpdateLabel(labelID uuid.UUID, userID uuid.UUID, req *params.UpdateLabelRequest) (*params.LabelResponse, *response.CustomError)
+	DeleteLabel(labelID uuid.UUID, userID uuid.UUID) *response.CustomError
+}
+
+type labelService struct {
+	labelRepo repositories.LabelRepository
+	logger    *logrus.Logger
+	cache     *redis.Client
+}
+
+func NewLabelService(labelRepo repositories.LabelRepository, logger *logrus.Logger, cache *redis.Client) LabelService {
+	return &labelService{
+		labelRepo: labelRepo,
+		logger:    logger,
+		cache:     cache,
+	}
+}
+
+func (s *labelService) CreateLabel(userID uuid.UUID, req *params.CreateLabelRequest) (*params.LabelResponse, *response.CustomError) {
+	if _, err := s.labelRepo.GetByName(req.Name, userID); err == nil {
+		return nil, response.BadRequestError("label with this name already exists")
+	}
+
+	label := &models.Label{
+		UserID: userID,
+		Name:   req.Name,
+		Color:  req.Color,
+	}
+	if label.Color == "" {
+		label.Color = defaultLabelColor
+	}
+
+	if err := s.labelRepo.Create(label); err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to create label")
+		return nil, response.RepositoryError("failed to create label")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"label_id": label.ID,
+		"user_id":  userID,
+		"name":     label.Name,
+	}).Info("Label created successfully")
+
+	return toLabelResponse(label), nil
+}
+
+func (s *labelService) GetLabel(labelID uuid.UUID, userID uuid.UUID) (*params.LabelResponse, *response.CustomError) {
+	label, err := s.labelRepo.GetByID(labelID, userID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"label_id": labelID,
+			"user_id":  userID,
+		}).Error("Failed to get label")
+		return nil, response.RepositoryError("failed to get label")
+	}
+
+	return toLabelResponse(label), nil
+}
+
+func (s *labelService) GetLabels(userID uuid.UUID) ([]params.LabelResponse, *response.CustomError) {
+	labels, err := s.labelRepo.GetAll(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get labels")
+		return nil, response.RepositoryError("failed to get labels")
+	}
+
+	return toLabelResponses(labels), nil
+}
+
+func (s *labelService) UpdateLabel(labelID uuid.UUID, userID uuid.UUID, req *params.UpdateLabelRequest) (*params.LabelResponse, *response.CustomError) {
+	label, err := s.labelRepo.GetByID(labelID, userID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"label_id": labelID,
+			"user_id":  userID,
+		}).Error("Failed to get label for update")
+		return nil, response.RepositoryError("failed to get label for update")
+	}
+
+	if req.Name != nil && *req.Name != label.Name {
+		if _, err := s.labelRepo.GetByName(*req.Name, userID); err == nil {
+			return nil, response.BadRequestError("label with this name already exists")
+		}
+		label.Name = *req.Name
+	}
+	if req.Color != nil {
+		label.Color = *req.Color
+	}
+
+	if err := s.labelRepo.Update(label); err != nil {
+		s.logger.WithError(err).WithField("label_id", labelID).Error("Failed to update label")
+		return nil, response.RepositoryError("failed to update label")
+	}
+
+	// Labels are embedded in task responses, so cached task lists are stale.
+	publishInvalidateUserTasksCache(s.cache, s.logger, userID)
+
+	s.logger.WithFields(logrus.Fields{
+		"label_id": labelID,
+		"user_id":  userID,
+		"name":     label.Name,
+	}).Info("Label updated successfully")
+
+	return toLabelResponse(label), nil
+}
+
+func (s *labelService) DeleteLabel(labelID uuid.UUID, userID uuid.UUID) *response.CustomError {
+	if err := s.labelRepo.Delete(labelID, userID); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"label_id": labelID,
+			"user_id":  userID,
+		}).Error("Failed to delete label")
+		return response.RepositoryError("failed to delete label")
+	}
+
+	publishInvalidateUserTasksCache(s.cache, s.logger, userID)
+
+	s.logger.WithFields(logrus.Fields{
+		"label_id": labelID,
+		"user_id":  userID,
+	}).Info("Label deleted successfully")
+
+	return nil
+}
+
+func toLabelResponse(label *models.Label) *params.LabelResponse {
+	return &params.LabelResponse{
+		ID:        label.ID,
+		Name:      label.Name,
+		Color:     label.Color,
+		CreatedAt: label.CreatedAt,
+		UpdatedAt: label.UpdatedAt,
+	}
+}
+
+func toLabelResponses(labels []models.Label) []params.LabelResponse {
+	responses := make([]params.LabelResponse, len(labels))
+	for i := range labels {
+		responses[i] = *toLabelResponse(&labels[i])
+	}
+	return responses
+}
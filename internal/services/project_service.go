@@ -0,0 +1,232 @@
+package services
+
+import (
+	"errors"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultProjectColor = "#808080"
+
+// ProjectService manages the projects of a user inside a workspace. Every
+// method works on the projects of the given workspace only.
+type ProjectService interface {
+	CreateProject(userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateProjectRequest) (*params.ProjectResponse, *response.CustomError)
+	GetProject(projectID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.ProjectResponse, *response.CustomError)
+	GetProjects(userID uuid.UUID, workspaceID uuid.UUID, includeArchived bool) ([]params.ProjectResponse, *response.CustomError)
+	UpdateProject(projectID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.UpdateProjectRequest) (*params.ProjectResponse, *response.CustomError)
+	DeleteProject(projectID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError
+	GetProjectTasks(projectID uuid.UUID, userID uuid.UUID, filter *params.TaskFilter) (*params.TasksResponse, *response.CustomError)
+}
+
+type projectService struct {
+	projectRepo repositories.ProjectRepository
+	taskService TaskService
+	logger      *logrus.Logger
+	cache       *redis.Client
+}
+
+func NewProjectService(projectRepo repositories.ProjectRepository, taskService TaskService, logger *logrus.Logger, cache *redis.Client) ProjectService {
+	return &projectService{
+		projectRepo: projectRepo,
+		taskService: taskService,
+		logger:      logger,
+		cache:       cache,
+	}
+}
+
+func (s *projectService) CreateProject(userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateProjectRequest) (*params.ProjectResponse, *response.CustomError) {
+	if _, err := s.projectRepo.GetByName(req.Name, userID, workspaceID); err == nil {
+		return nil, response.BadRequestError("project with this name already exists")
+	}
+
+	project := &models.Project{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Name:        req.Name,
+		Color:       req.Color,
+	}
+	if project.Color == "" {
+		project.Color = defaultProjectColor
+	}
+
+	if err := s.projectRepo.Create(project); err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to create project")
+		return nil, response.RepositoryError("failed to create project")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id":   project.ID,
+		"workspace_id": workspaceID,
+		"user_id":      userID,
+		"name":         project.Name,
+	}).Info("Project created successfully")
+
+	return toProjectResponse(project, nil), nil
+}
+
+func (s *projectService) GetProject(projectID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.ProjectResponse, *response.CustomError) {
+	project, custErr := s.getProject(projectID, userID, workspaceID)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	responses, custErr := s.withTaskCounts([]models.Project{*project})
+	if custErr != nil {
+		return nil, custErr
+	}
+	return &responses[0], nil
+}
+
+func (s *projectService) GetProjects(userID uuid.UUID, workspaceID uuid.UUID, includeArchived bool) ([]params.ProjectResponse, *response.CustomError) {
+	projects, err := s.projectRepo.GetAll(userID, workspaceID, includeArchived)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get projects")
+		return nil, response.RepositoryError("failed to get projects")
+	}
+
+	return s.withTaskCounts(projects)
+}
+
+func (s *projectService) UpdateProject(projectID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.UpdateProjectRequest) (*params.ProjectResponse, *response.CustomError) {
+	project, custErr := s.getProject(projectID, userID, workspaceID)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	if req.Name != nil && *req.Name != project.Name {
+		if _, err := s.projectRepo.GetByName(*req.Name, userID, workspaceID); err == nil {
+			return nil, response.BadRequestError("project with this name already exists")
+		}
+		project.Name = *req.Name
+	}
+	if req.Color != nil {
+		project.Color = *req.Color
+	}
+	if req.Archived != nil && *req.Archived != (project.ArchivedAt != nil) {
+		if *req.Archived {
+			now := time.Now()
+			project.ArchivedAt = &now
+		} else {
+			project.ArchivedAt = nil
+		}
+	}
+
+	if err := s.projectRepo.Update(project); err != nil {
+		s.logger.WithError(err).WithField("project_id", projectID).Error("Failed to update project")
+		return nil, projectLookupError(err, "failed to update project")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"user_id":    userID,
+		"name":       project.Name,
+		"archived":   project.ArchivedAt != nil,
+	}).Info("Project updated successfully")
+
+	responses, custErr := s.withTaskCounts([]models.Project{*project})
+	if custErr != nil {
+		return nil, custErr
+	}
+	return &responses[0], nil
+}
+
+// DeleteProject removes a project. Its tasks are kept outside of any project.
+func (s *projectService) DeleteProject(projectID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	if err := s.projectRepo.Delete(projectID, userID, workspaceID); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"project_id": projectID,
+			"user_id":    userID,
+		}).Error("Failed to delete project")
+		return projectLookupError(err, "failed to delete project")
+	}
+
+	// The project's tasks lost their project_id in every cached list.
+	publishInvalidateUserTasksCache(s.cache, s.logger, userID)
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"user_id":    userID,
+	}).Info("Project deleted successfully")
+
+	return nil
+}
+
+// GetProjectTasks lists the tasks of a project with the filters, sorting and
+// pagination of the task list.
+func (s *projectService) GetProjectTasks(projectID uuid.UUID, userID uuid.UUID, filter *params.TaskFilter) (*params.TasksResponse, *response.CustomError) {
+	if _, custErr := s.getProject(projectID, userID, filter.WorkspaceID); custErr != nil {
+		return nil, custErr
+	}
+
+	filter.ProjectID = &projectID
+	return s.taskService.GetTasks(userID, filter)
+}
+
+func (s *projectService) getProject(projectID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Project, *response.CustomError) {
+	project, err := s.projectRepo.GetByID(projectID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"project_id": projectID,
+			"user_id":    userID,
+		}).Error("Failed to get project")
+		return nil, projectLookupError(err, "failed to get project")
+	}
+	return project, nil
+}
+
+// projectLookupError answers a failed project lookup. A project that does not
+// exist or belongs to another user or workspace is not found; any other
+// failure is reported with message.
+func projectLookupError(err error, message string) *response.CustomError {
+	if errors.Is(err, repositories.ErrProjectNotFound) {
+		return response.NotFoundError("project not found")
+	}
+	return response.RepositoryError(message)
+}
+
+// withTaskCounts builds the responses for the projects with their task counts
+// by status.
+func (s *projectService) withTaskCounts(projects []models.Project) ([]params.ProjectResponse, *response.CustomError) {
+	ids := make([]uuid.UUID, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID
+	}
+
+	counts, err := s.projectRepo.GetStatusCounts(ids)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get project task counts")
+		return nil, response.RepositoryError("failed to get project task counts")
+	}
+
+	responses := make([]params.ProjectResponse, len(projects))
+	for i := range projects {
+		responses[i] = *toProjectResponse(&projects[i], counts[projects[i].ID])
+	}
+	return responses, nil
+}
+
+func toProjectResponse(project *models.Project, counts map[enum.TaskStatus]int64) *params.ProjectResponse {
+	if counts == nil {
+		counts = make(map[enum.TaskStatus]int64)
+	}
+	return &params.ProjectResponse{
+		ID:          project.ID,
+		WorkspaceID: project.WorkspaceID,
+		Name:        project.Name,
+		Color:       project.Color,
+		Archived:    project.ArchivedAt != nil,
+		ArchivedAt:  project.ArchivedAt,
+		TaskCounts:  counts,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
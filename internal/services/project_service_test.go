@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProjectService(t *testing.T) (ProjectService, *repositories.MockProjectRepository) {
+	projects := new(repositories.MockProjectRepository)
+	t.Cleanup(func() { projects.AssertExpectations(t) })
+
+	service := NewProjectService(projects, nil, newTestLogger(), newTestCache(t))
+	return service, projects
+}
+
+func TestProjectOfAnotherUserOrWorkspaceIsNotFound(t *testing.T) {
+	userID, workspaceID, projectID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name string
+		call func(service ProjectService) *response.CustomError
+	}{
+		{
+			name: "get",
+			call: func(service ProjectService) *response.CustomError {
+				_, custErr := service.GetProject(projectID, userID, workspaceID)
+				return custErr
+			},
+		},
+		{
+			name: "update",
+			call: func(service ProjectService) *response.CustomError {
+				name := "Grammar"
+				_, custErr := service.UpdateProject(projectID, userID, workspaceID, &params.UpdateProjectRequest{Name: &name})
+				return custErr
+			},
+		},
+		{
+			name: "list tasks",
+			call: func(service ProjectService) *response.CustomError {
+				_, custErr := service.GetProjectTasks(projectID, userID, &params.TaskFilter{WorkspaceID: workspaceID})
+				return custErr
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, projects := newTestProjectService(t)
+			projects.On("GetByID", projectID, userID, workspaceID).Return(nil, repositories.ErrProjectNotFound)
+
+			custErr := tt.call(service)
+
+			require.NotNil(t, custErr)
+			assert.Equal(t, http.StatusNotFound, custErr.StatusCode)
+		})
+	}
+}
+
+func TestDeleteProjectThatDoesNotExistIsNotFound(t *testing.T) {
+	service, projects := newTestProjectService(t)
+	userID, workspaceID, projectID := uuid.New(), uuid.New(), uuid.New()
+
+	projects.On("Delete", projectID, userID, workspaceID).Return(repositories.ErrProjectNotFound)
+
+	custErr := service.DeleteProject(projectID, userID, workspaceID)
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusNotFound, custErr.StatusCode)
+}
+
+func TestGetProjectReportsRepositoryFailures(t *testing.T) {
+	service, projects := newTestProjectService(t)
+	userID, workspaceID, projectID := uuid.New(), uuid.New(), uuid.New()
+
+	projects.On("GetByID", projectID, userID, workspaceID).Return(nil, errors.New("connection reset"))
+
+	_, custErr := service.GetProject(projectID, userID, workspaceID)
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusInternalServerError, custErr.StatusCode)
+}
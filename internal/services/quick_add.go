@@ -0,0 +1,103 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/pkg/quickadd"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// maxTaskLabels mirrors the label_ids limit of CreateTaskRequest.
+const maxTaskLabels = 20
+
+// QuickAddTask creates a task from a single line such as "Review essay
+// tomorrow 5pm #writing !high". Dates are read in the user's time zone and
+// every #hashtag names a label, which is created if the user has none by
+// that name yet. The task itself is created by CreateTask.
+func (s *taskService) QuickAddTask(userID uuid.UUID, workspaceID uuid.UUID, req *params.QuickAddTaskRequest) (*params.TaskResponse, *response.CustomError) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get user")
+		return nil, response.RepositoryError("failed to get user")
+	}
+
+	parsed, err := quickadd.Parse(req.Text, time.Now().In(user.Location()))
+	if err != nil {
+		return nil, response.BadRequestError(err.Error())
+	}
+	if utf8.RuneCountInString(parsed.Title) > maxTaskTitleLength {
+		return nil, response.BadRequestError(fmt.Sprintf("the title is longer than %d characters", maxTaskTitleLength))
+	}
+	if len(parsed.Labels) > maxTaskLabels {
+		return nil, response.BadRequestError(fmt.Sprintf("a task can have at most %d labels", maxTaskLabels))
+	}
+
+	labelIDs, custErr := s.labelIDsByName(userID, parsed.Labels)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	create := &params.CreateTaskRequest{
+		Title:     parsed.Title,
+		DueAt:     parsed.DueAt,
+		DueAllDay: parsed.AllDay,
+		LabelIDs:  labelIDs,
+	}
+	if parsed.Priority != "" {
+		priority := enum.TaskPriority(parsed.Priority)
+		create.Priority = &priority
+	}
+
+	return s.CreateTask(userID, workspaceID, create)
+}
+
+// labelIDsByName finds the user's labels with the given names, ignoring
+// case, and creates the missing ones.
+func (s *taskService) labelIDsByName(userID uuid.UUID, names []string) ([]uuid.UUID, *response.CustomError) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	existing, err := s.labelRepo.GetAll(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get labels")
+		return nil, response.RepositoryError("failed to get labels")
+	}
+
+	ids := make([]uuid.UUID, 0, len(names))
+	for _, name := range names {
+		id, found := uuid.Nil, false
+		for _, label := range existing {
+			if strings.EqualFold(label.Name, name) {
+				id, found = label.ID, true
+				break
+			}
+		}
+
+		if !found {
+			label := &models.Label{UserID: userID, Name: name, Color: defaultLabelColor}
+			if err := s.labelRepo.Create(label); err != nil {
+				// Another request may have created it in the meantime.
+				created, getErr := s.labelRepo.GetByName(name, userID)
+				if getErr != nil {
+					s.logger.WithError(err).WithField("user_id", userID).Error("Failed to create label")
+					return nil, response.RepositoryError("failed to create label")
+				}
+				label = created
+			}
+			existing = append(existing, *label)
+			id = label.ID
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
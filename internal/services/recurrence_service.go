@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/repositories"
+	"go-corenglish/pkg/rank"
+	"go-corenglish/pkg/rrule"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// maxOccurrencesPerRun bounds how many occurrences of a single series one
+// materialisation run may create.
+const maxOccurrencesPerRun = 50
+
+// RecurrenceService creates the occurrences of recurring tasks, both when an
+// occurrence is finished and ahead of time from the worker. Occurrences are
+// not held back by WIP limits, so a full column never ends a series.
+type RecurrenceService interface {
+	SpawnNext(task *models.Task) (*models.Task, error)
+	MaterializeUpcoming(horizon time.Time) ([]uuid.UUID, error)
+}
+
+type recurrenceService struct {
+	taskRepo    repositories.TaskRepository
+	historyRepo repositories.TaskHistoryRepository
+	userRepo    repositories.UserRepository
+	logger      *logrus.Logger
+}
+
+func NewRecurrenceService(taskRepo repositories.TaskRepository, historyRepo repositories.TaskHistoryRepository, userRepo repositories.UserRepository, logger *logrus.Logger) RecurrenceService {
+	return &recurrenceService{
+		taskRepo:    taskRepo,
+		historyRepo: historyRepo,
+		userRepo:    userRepo,
+		logger:      logger,
+	}
+}
+
+// SpawnNext creates the first occurrence after task that is still in the
+// future, skipping the ones that were missed. It returns nil when the series
+// has ended or the occurrence already exists.
+func (s *recurrenceService) SpawnNext(task *models.Task) (*models.Task, error) {
+	loc, err := s.ownerLocation(task)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.spawn(task, loc, time.Now(), nil)
+}
+
+// MaterializeUpcoming extends every recurring series with the occurrences
+// due before horizon and returns the IDs of the users who got new tasks.
+func (s *recurrenceService) MaterializeUpcoming(horizon time.Time) ([]uuid.UUID, error) {
+	heads, err := s.taskRepo.GetRecurringSeriesHeads()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	created := 0
+	users := make(map[uuid.UUID]bool)
+	for i := range heads {
+		current := &heads[i]
+		loc, err := s.ownerLocation(current)
+		if err != nil {
+			s.logger.WithError(err).WithField("series_id", current.RecurrenceSeriesID).Warn("Failed to materialize task occurrence")
+			continue
+		}
+		for n := 0; n < maxOccurrencesPerRun; n++ {
+			next, err := s.spawn(current, loc, now, &horizon)
+			if err != nil {
+				s.logger.WithError(err).WithField("series_id", current.RecurrenceSeriesID).Warn("Failed to materialize task occurrence")
+				break
+			}
+			if next == nil {
+				break
+			}
+			created++
+			users[next.UserID] = true
+			current = next
+		}
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(users))
+	for userID := range users {
+		userIDs = append(userIDs, userID)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"series":      len(heads),
+		"occurrences": created,
+	}).Info("Upcoming task occurrences materialized")
+
+	return userIDs, nil
+}
+
+// ownerLocation returns the time zone of the task's owner, in which its
+// series repeats: a task due at 09:00 stays due at 09:00 local time across
+// daylight saving time changes.
+func (s *recurrenceService) ownerLocation(task *models.Task) (*time.Location, error) {
+	user, err := s.userRepo.GetByID(task.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Location(), nil
+}
+
+// spawn creates the first occurrence after task that falls after the given
+// time and, when horizon is set, not after horizon. The rule is evaluated in
+// loc.
+func (s *recurrenceService) spawn(task *models.Task, loc *time.Location, after time.Time, horizon *time.Time) (*models.Task, error) {
+	if task.RecurrenceRule == nil || task.RecurrenceSeriesID == nil || task.RecurrenceStart == nil {
+		return nil, nil
+	}
+
+	rule, err := rrule.Parse(*task.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	due, index, ok := rule.After(task.RecurrenceStart.In(loc), after, task.RecurrenceIndex+1)
+	if !ok || (horizon != nil && due.After(*horizon)) {
+		return nil, nil
+	}
+	due = due.UTC()
+
+	last, err := s.taskRepo.GetLastPosition(task.UserID, task.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	position, err := rank.Between(last, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute task position: %w", err)
+	}
+
+	occurrence := &models.Task{
+		Title:              task.Title,
+		Description:        task.Description,
+		Status:             enum.StatusToDo,
+		Priority:           task.Priority,
+		UserID:             task.UserID,
+		WorkspaceID:        task.WorkspaceID,
+		ParentID:           task.ParentID,
+		ProjectID:          task.ProjectID,
+		DueAt:              &due,
+		DueAllDay:          task.DueAllDay,
+		Position:           position,
+		RecurrenceRule:     task.RecurrenceRule,
+		RecurrenceSeriesID: task.RecurrenceSeriesID,
+		RecurrenceStart:    task.RecurrenceStart,
+		RecurrenceIndex:    index,
+		ReminderChannel:    task.ReminderChannel,
+		Labels:             task.Labels,
+	}
+	// The reminder keeps its distance to the due date.
+	if task.RemindAt != nil && task.DueAt != nil {
+		remindAt := due.Add(task.RemindAt.Sub(*task.DueAt))
+		occurrence.RemindAt = &remindAt
+	}
+
+	created, err := s.taskRepo.CreateOccurrence(occurrence)
+	if err != nil || !created {
+		return nil, err
+	}
+
+	entry := &models.TaskStatusHistory{
+		TaskID:    occurrence.ID,
+		UserID:    occurrence.UserID,
+		ToStatus:  occurrence.Status,
+		ChangedAt: time.Now(),
+	}
+	if err := s.historyRepo.Create(entry); err != nil {
+		s.logger.WithError(err).WithField("task_id", occurrence.ID).Warn("Failed to record task status change")
+	}
+
+	return occurrence, nil
+}
+
+// startRecurrence makes task the first occurrence of a new series following
+// the given rule. The task's due date becomes the start of the series.
+func startRecurrence(task *models.Task, value string) *response.CustomError {
+	rule, err := rrule.Parse(value)
+	if err != nil {
+		return response.BadRequestError(err.Error())
+	}
+	if task.DueAt == nil {
+		return response.BadRequestError("a recurring task needs a due_at")
+	}
+
+	normalized := rule.String()
+	seriesID := uuid.New()
+	start := *task.DueAt
+
+	task.RecurrenceRule = &normalized
+	task.RecurrenceSeriesID = &seriesID
+	task.RecurrenceStart = &start
+	task.RecurrenceIndex = 0
+	return nil
+}
+
+// clearRecurrence detaches task from its series.
+func clearRecurrence(task *models.Task) {
+	task.RecurrenceRule = nil
+	task.RecurrenceSeriesID = nil
+	task.RecurrenceStart = nil
+	task.RecurrenceIndex = 0
+}
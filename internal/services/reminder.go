@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+
+	"github.com/google/uuid"
+)
+
+// ReminderQueueKey is the Redis sorted set holding the pending task
+// reminders, scored by the time they are due. The worker polls it.
+const ReminderQueueKey = "reminders:queue"
+
+// applyReminder copies the reminder fields of an update onto the task and
+// returns the columns it changed. Setting a reminder time, even the same one
+// again, re-arms a reminder that was already sent; the repository resets
+// reminder_sent_at whenever remind_at is written.
+func applyReminder(task *models.Task, req *params.UpdateTaskRequest) ([]string, *response.CustomError) {
+	var columns []string
+	if req.ReminderChannel != nil {
+		if !req.ReminderChannel.IsValid() {
+			return nil, response.BadRequestError(fmt.Sprintf("invalid reminder channel: %s", *req.ReminderChannel))
+		}
+		task.ReminderChannel = *req.ReminderChannel
+		columns = append(columns, "reminder_channel")
+	}
+
+	if req.ClearReminder {
+		task.RemindAt = nil
+		columns = append(columns, "remind_at")
+	} else if req.RemindAt != nil {
+		task.RemindAt = req.RemindAt
+		columns = append(columns, "remind_at")
+	}
+	return columns, nil
+}
+
+// syncReminder brings the reminder queue in line with the task: a pending
+// reminder is (re)scheduled at remind_at and anything else is removed. The
+// worker rebuilds the queue from the database periodically, so a failure is
+// only logged.
+func (s *taskService) syncReminder(task *models.Task) {
+	if task.RemindAt == nil || task.ReminderSentAt != nil || task.Status.IsDone() || task.DeletedAt.Valid {
+		s.cancelReminder(task.ID)
+		return
+	}
+
+	if err := s.reminders.Schedule(context.Background(), task.ID.String(), *task.RemindAt); err != nil {
+		s.logger.WithError(err).WithField("task_id", task.ID).Warn("Failed to schedule task reminder")
+	}
+}
+
+func (s *taskService) cancelReminder(taskID uuid.UUID) {
+	if err := s.reminders.Cancel(context.Background(), taskID.String()); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Warn("Failed to cancel task reminder")
+	}
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultStatsPeriod = 12 * 7 * 24 * time.Hour
+	maxStatsPeriod     = 366 * 24 * time.Hour
+)
+
+type StatsService interface {
+	GetTaskStats(userID uuid.UUID, workspaceID uuid.UUID, from, to *time.Time) (*params.TaskStatsResponse, *response.CustomError)
+}
+
+type statsService struct {
+	historyRepo repositories.TaskHistoryRepository
+	logger      *logrus.Logger
+}
+
+func NewStatsService(historyRepo repositories.TaskHistoryRepository, logger *logrus.Logger) StatsService {
+	return &statsService{
+		historyRepo: historyRepo,
+		logger:      logger,
+	}
+}
+
+// GetTaskStats reports lead time, cycle time and weekly throughput of the
+// user's tasks in the workspace completed in [from, to). The period defaults
+// to the last 12 weeks.
+func (s *statsService) GetTaskStats(userID uuid.UUID, workspaceID uuid.UUID, from, to *time.Time) (*params.TaskStatsResponse, *response.CustomError) {
+	periodEnd := time.Now().UTC()
+	if to != nil {
+		periodEnd = to.UTC()
+	}
+	periodStart := periodEnd.Add(-defaultStatsPeriod)
+	if from != nil {
+		periodStart = from.UTC()
+	}
+
+	if !periodStart.Before(periodEnd) {
+		return nil, response.BadRequestError("from must be earlier than to")
+	}
+	if periodEnd.Sub(periodStart) > maxStatsPeriod {
+		return nil, response.BadRequestError("stats period cannot exceed one year")
+	}
+
+	stats, err := s.historyRepo.GetCompletionStats(userID, workspaceID, periodStart, periodEnd)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get completion stats")
+		return nil, response.RepositoryError("failed to get task stats")
+	}
+
+	weeks, err := s.historyRepo.GetWeeklyCompletions(userID, workspaceID, periodStart, periodEnd)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get weekly completions")
+		return nil, response.RepositoryError("failed to get task stats")
+	}
+
+	return &params.TaskStatsResponse{
+		From:      periodStart,
+		To:        periodEnd,
+		Completed: stats.Completed,
+		LeadTime: params.DurationStats{
+			AverageHours: secondsToHours(stats.AvgLeadSeconds),
+			MedianHours:  secondsToHours(stats.MedianLeadSeconds),
+			Samples:      stats.LeadSamples,
+		},
+		CycleTime: params.DurationStats{
+			AverageHours: secondsToHours(stats.AvgCycleSeconds),
+			MedianHours:  secondsToHours(stats.MedianCycleSeconds),
+			Samples:      stats.CycleSamples,
+		},
+		Throughput: fillWeeklyThroughput(weeks, periodStart, periodEnd),
+	}, nil
+}
+
+// fillWeeklyThroughput returns one entry per ISO week (starting Monday, UTC)
+// overlapping the period, including weeks in which nothing was completed.
+func fillWeeklyThroughput(weeks []repositories.WeeklyCompletion, from, to time.Time) []params.WeeklyThroughput {
+	completed := make(map[time.Time]int64, len(weeks))
+	for _, week := range weeks {
+		completed[week.WeekStart.UTC()] = week.Completed
+	}
+
+	throughput := []params.WeeklyThroughput{}
+	for week := startOfWeek(from); week.Before(to); week = week.AddDate(0, 0, 7) {
+		throughput = append(throughput, params.WeeklyThroughput{
+			WeekStart: week,
+			Completed: completed[week],
+		})
+	}
+	return throughput
+}
+
+func startOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
+
+func secondsToHours(seconds *float64) *float64 {
+	if seconds == nil {
+		return nil
+	}
+	hours := math.Round(*seconds/3600*100) / 100
+	return &hours
+}
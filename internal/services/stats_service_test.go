@@ -0,0 +1,39 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTaskStatsOnlyCountsTheWorkspace(t *testing.T) {
+	history := new(repositories.MockTaskHistoryRepository)
+	service := NewStatsService(history, newTestLogger())
+	userID, workspaceID, otherWorkspaceID := uuid.New(), uuid.New(), uuid.New()
+	to := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -7)
+	week := startOfWeek(from.Add(24 * time.Hour))
+
+	// One task was completed in the workspace and two in another one.
+	history.On("GetCompletionStats", userID, workspaceID, from, to).Return(&repositories.CompletionStats{Completed: 1}, nil)
+	history.On("GetWeeklyCompletions", userID, workspaceID, from, to).Return([]repositories.WeeklyCompletion{{WeekStart: week, Completed: 1}}, nil)
+	history.On("GetCompletionStats", userID, otherWorkspaceID, mock.Anything, mock.Anything).Return(&repositories.CompletionStats{Completed: 2}, nil).Maybe()
+	history.On("GetWeeklyCompletions", userID, otherWorkspaceID, mock.Anything, mock.Anything).Return([]repositories.WeeklyCompletion{{WeekStart: week, Completed: 2}}, nil).Maybe()
+
+	resp, custErr := service.GetTaskStats(userID, workspaceID, &from, &to)
+
+	require.Nil(t, custErr)
+	assert.Equal(t, int64(1), resp.Completed)
+	var throughput int64
+	for _, w := range resp.Throughput {
+		throughput += w.Completed
+	}
+	assert.Equal(t, int64(1), throughput)
+	history.AssertNotCalled(t, "GetCompletionStats", userID, otherWorkspaceID, mock.Anything, mock.Anything)
+}
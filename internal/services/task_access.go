@@ -0,0 +1,50 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/repositories"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// requireTaskRole checks that the user may act on a task they can see. The
+// owner may do anything; members only what their role allows.
+func requireTaskRole(memberRepo repositories.TaskMemberRepository, logger *logrus.Logger, task *models.Task, userID uuid.UUID, required enum.TaskRole) *response.CustomError {
+	if task.UserID == userID {
+		return nil
+	}
+	if required == enum.RoleOwner {
+		return response.ForbiddenError("only the owner of the task can do this")
+	}
+
+	role, err := memberRepo.GetRole(task.ID, userID)
+	if err != nil {
+		logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": task.ID,
+			"user_id": userID,
+		}).Error("Failed to get task member role")
+		return response.RepositoryError("failed to check task access")
+	}
+
+	if !role.Allows(required) {
+		return response.ForbiddenError(fmt.Sprintf("%s access to the task is required", strings.ToLower(string(required))))
+	}
+
+	return nil
+}
+
+// taskLookupError answers a failed task lookup. A task that does not exist,
+// is not visible to the user or lives in another workspace is not found;
+// any other failure is reported with message.
+func taskLookupError(err error, message string) *response.CustomError {
+	if errors.Is(err, repositories.ErrTaskNotFound) {
+		return response.NotFoundError("task not found")
+	}
+	return response.RepositoryError(message)
+}
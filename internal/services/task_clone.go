@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"go-corenglish/pkg/rank"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// maxBulkClone bounds how many tasks a single bulk clone may copy.
+const maxBulkClone = 100
+
+// CloneTask copies a task into a new TO_DO task next to it, see cloneTasks.
+func (s *taskService) CloneTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.CloneTaskRequest) (*params.TaskResponse, *response.CustomError) {
+	source, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for cloning")
+		return nil, taskLookupError(err, "failed to get task for cloning")
+	}
+	if custErr := s.requireTaskRole(source, userID, enum.RoleOwner); custErr != nil {
+		return nil, custErr
+	}
+
+	clones, custErr := s.cloneTasks(userID, workspaceID, []models.Task{*source}, req)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	return &clones[0], nil
+}
+
+// CloneTasks copies every task matched by the filter. A matched subtask
+// whose ancestor is matched too is only copied as part of that ancestor when
+// subtasks are included.
+func (s *taskService) CloneTasks(userID uuid.UUID, filter *params.TaskFilter, req *params.CloneTaskRequest) ([]params.TaskResponse, *response.CustomError) {
+	if custErr := validateTaskFilter(filter); custErr != nil {
+		return nil, custErr
+	}
+
+	// Only the user's own tasks are cloned.
+	owned := false
+	query := *filter
+	query.Page = 1
+	query.Limit = maxBulkClone
+	query.Shared = &owned
+	sources, total, err := s.taskRepo.GetAll(userID, &query)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get tasks for cloning")
+		return nil, response.RepositoryError("failed to get tasks for cloning")
+	}
+	if total > maxBulkClone {
+		return nil, response.BadRequestError(fmt.Sprintf("the filter matches %d tasks, at most %d can be cloned at once", total, maxBulkClone))
+	}
+	if len(sources) == 0 {
+		return []params.TaskResponse{}, nil
+	}
+
+	if includeSubtasks(req) {
+		matched := make(map[uuid.UUID]bool, len(sources))
+		for _, source := range sources {
+			matched[source.ID] = true
+		}
+
+		roots := make([]models.Task, 0, len(sources))
+		for _, source := range sources {
+			ancestorIDs, err := s.taskRepo.GetAncestorIDs(source.ID)
+			if err != nil {
+				s.logger.WithError(err).WithField("task_id", source.ID).Error("Failed to get task ancestors")
+				return nil, response.RepositoryError("failed to get tasks for cloning")
+			}
+			if !containsAny(matched, ancestorIDs) {
+				roots = append(roots, source)
+			}
+		}
+		sources = roots
+	}
+
+	return s.cloneTasks(userID, filter.WorkspaceID, sources, req)
+}
+
+// cloneTasks copies the sources, with their labels, checklist items (all
+// unchecked), blockers and, unless disabled, subtasks, into new TO_DO tasks
+// at the end of the workspace's list. Everything is written in a single
+// transaction. Comments, attachments, history, reminders and recurrence are
+// not copied.
+func (s *taskService) cloneTasks(userID uuid.UUID, workspaceID uuid.UUID, sources []models.Task, req *params.CloneTaskRequest) ([]params.TaskResponse, *response.CustomError) {
+	suffix := s.cfg.CloneTitleSuffix
+	if req.TitleSuffix != nil {
+		suffix = *req.TitleSuffix
+	}
+
+	position, err := s.taskRepo.GetLastPosition(userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get last task position")
+		return nil, response.RepositoryError("failed to get task position")
+	}
+
+	var clones []repositories.TaskClone
+	roots := make([]models.Task, 0, len(sources))
+	for i := range sources {
+		source := &sources[i]
+		root, custErr := s.appendClone(&clones, source, source.ParentID, cloneTitle(source.Title, suffix), &position, includeSubtasks(req))
+		if custErr != nil {
+			return nil, custErr
+		}
+		roots = append(roots, *root)
+	}
+
+	// Every copy, subtasks included, lands in TO_DO.
+	if custErr := s.ensureWithinWIPLimit(userID, workspaceID, enum.StatusToDo, len(clones)); custErr != nil {
+		return nil, custErr
+	}
+
+	if err := s.taskRepo.CreateClones(clones); err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to clone tasks")
+		return nil, response.RepositoryError("failed to clone tasks")
+	}
+
+	for _, clone := range clones {
+		s.recordStatusChange(clone.Task, nil)
+	}
+
+	s.publishInvalidateUserTasksCache(userID)
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"roots":   len(roots),
+		"tasks":   len(clones),
+	}).Info("Tasks cloned successfully")
+
+	return s.buildTaskResponses(roots), nil
+}
+
+// appendClone adds a copy of source below parentID to clones, followed by
+// copies of its subtasks when withSubtasks is set. position is advanced for
+// every copy so that the copies keep their relative order.
+func (s *taskService) appendClone(clones *[]repositories.TaskClone, source *models.Task, parentID *uuid.UUID, title string, position *string, withSubtasks bool) (*models.Task, *response.CustomError) {
+	next, err := rank.Between(*position, "")
+	if err != nil {
+		s.logger.WithError(err).WithField("last", *position).Error("Failed to compute task position")
+		return nil, response.GeneralError("failed to compute task position")
+	}
+	*position = next
+
+	clone := &models.Task{
+		ID:              uuid.New(),
+		Title:           title,
+		Description:     source.Description,
+		Status:          enum.StatusToDo,
+		Priority:        source.Priority,
+		UserID:          source.UserID,
+		WorkspaceID:     source.WorkspaceID,
+		ParentID:        parentID,
+		ProjectID:       source.ProjectID,
+		DueAt:           source.DueAt,
+		DueAllDay:       source.DueAllDay,
+		Position:        next,
+		ReminderChannel: enum.ReminderChannelEmail,
+		Labels:          source.Labels,
+	}
+	*clones = append(*clones, repositories.TaskClone{SourceID: source.ID, Task: clone})
+
+	if !withSubtasks {
+		return clone, nil
+	}
+
+	subtasks, err := s.taskRepo.GetSubtasks(source.ID, source.UserID, source.WorkspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", source.ID).Error("Failed to get subtasks for cloning")
+		return nil, response.RepositoryError("failed to get subtasks for cloning")
+	}
+	for i := range subtasks {
+		if _, custErr := s.appendClone(clones, &subtasks[i], &clone.ID, subtasks[i].Title, position, true); custErr != nil {
+			return nil, custErr
+		}
+	}
+
+	return clone, nil
+}
+
+func includeSubtasks(req *params.CloneTaskRequest) bool {
+	return req.IncludeSubtasks == nil || *req.IncludeSubtasks
+}
+
+// cloneTitle appends suffix to title, shortening title so that the result
+// still fits into tasks.title.
+func cloneTitle(title, suffix string) string {
+	runes := []rune(title)
+	if room := maxTaskTitleLength - len([]rune(suffix)); len(runes) > room {
+		runes = runes[:room]
+	}
+	return string(runes) + suffix
+}
+
+func containsAny(set map[uuid.UUID]bool, ids []uuid.UUID) bool {
+	for _, id := range ids {
+		if set[id] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneTaskCopiesIntoFreshTasksAtTheEnd(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	remindAt := time.Now().Add(time.Hour)
+	sentAt := time.Now().Add(-time.Hour)
+	rule := "FREQ=DAILY"
+	source := ownedTask(userID)
+	source.Status = enum.StatusDone
+	source.RemindAt = &remindAt
+	source.ReminderSentAt = &sentAt
+	source.RecurrenceRule = &rule
+	source.Labels = []models.Label{{ID: uuid.New(), UserID: userID, Name: "reading"}}
+	subtask := ownedTask(userID)
+	subtask.WorkspaceID = source.WorkspaceID
+	subtask.ParentID = &source.ID
+	suffix := " (copy)"
+
+	var clones []repositories.TaskClone
+	m.tasks.On("GetByID", source.ID, userID, source.WorkspaceID).Return(source, nil)
+	m.tasks.On("GetLastPosition", userID, source.WorkspaceID).Return("a1", nil)
+	m.tasks.On("GetSubtasks", source.ID, userID, source.WorkspaceID).Return([]models.Task{*subtask}, nil)
+	m.tasks.On("GetSubtasks", subtask.ID, userID, source.WorkspaceID).Return([]models.Task{}, nil)
+	m.wipLimits.On("GetLimit", userID, enum.StatusToDo).Return(0, nil)
+	m.tasks.On("CreateClones", mock.Anything).Run(func(args mock.Arguments) {
+		clones = args.Get(0).([]repositories.TaskClone)
+	}).Return(nil)
+	m.tasks.On("GetSubtaskProgress", mock.Anything).Return(map[uuid.UUID]repositories.SubtaskProgress{}, nil)
+	m.checklists.On("GetCounts", mock.Anything).Return(map[uuid.UUID]repositories.ChecklistCount{}, nil)
+	m.comments.On("GetCounts", mock.Anything).Return(map[uuid.UUID]int64{}, nil)
+
+	resp, custErr := service.CloneTask(source.ID, userID, source.WorkspaceID, &params.CloneTaskRequest{TitleSuffix: &suffix})
+
+	require.Nil(t, custErr)
+	require.Len(t, clones, 2)
+	root, child := clones[0].Task, clones[1].Task
+	assert.Equal(t, source.ID, clones[0].SourceID)
+	assert.Equal(t, subtask.ID, clones[1].SourceID)
+	assert.Equal(t, root.ID, resp.ID)
+	assert.Equal(t, "Read chapter three (copy)", root.Title)
+	assert.Equal(t, source.Labels, root.Labels)
+	assert.Equal(t, &root.ID, child.ParentID)
+
+	// The copies follow the last task and keep their order.
+	assert.Greater(t, root.Position, "a1")
+	assert.Greater(t, child.Position, root.Position)
+
+	for _, clone := range []*models.Task{root, child} {
+		assert.NotEqual(t, source.ID, clone.ID)
+		assert.Equal(t, enum.StatusToDo, clone.Status)
+		assert.Nil(t, clone.RemindAt)
+		assert.Nil(t, clone.ReminderSentAt)
+		assert.Nil(t, clone.RecurrenceRule)
+		assert.Nil(t, clone.CompletedAt)
+	}
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type TaskMemberService interface {
+	GetMembers(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskMembersResponse, *response.CustomError)
+	InviteMember(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.InviteMemberRequest) (*params.TaskMemberResponse, *response.CustomError)
+	RevokeMember(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, member string) *response.CustomError
+}
+
+type taskMemberService struct {
+	taskRepo      repositories.TaskRepository
+	memberRepo    repositories.TaskMemberRepository
+	userRepo      repositories.UserRepository
+	workspaceRepo repositories.WorkspaceRepository
+	logger        *logrus.Logger
+	cache         *redis.Client
+}
+
+func NewTaskMemberService(taskRepo repositories.TaskRepository, memberRepo repositories.TaskMemberRepository, userRepo repositories.UserRepository, workspaceRepo repositories.WorkspaceRepository, logger *logrus.Logger, cache *redis.Client) TaskMemberService {
+	return &taskMemberService{
+		taskRepo:      taskRepo,
+		memberRepo:    memberRepo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		logger:        logger,
+		cache:         cache,
+	}
+}
+
+// GetMembers lists the owner and the members of a task. Anyone with access
+// to the task may see who else has.
+func (s *taskMemberService) GetMembers(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskMembersResponse, *response.CustomError) {
+	task, custErr := s.getTask(taskID, userID, workspaceID)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	owner, err := s.userRepo.GetByID(task.UserID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get task owner")
+		return nil, response.RepositoryError("failed to get task members")
+	}
+
+	members, err := s.memberRepo.GetByTaskID(taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get task members")
+		return nil, response.RepositoryError("failed to get task members")
+	}
+
+	result := &params.TaskMembersResponse{
+		TaskID:  taskID,
+		Members: make([]params.TaskMemberResponse, 0, len(members)+1),
+	}
+	result.Members = append(result.Members, params.TaskMemberResponse{
+		UserID:    owner.ID,
+		Username:  owner.Username,
+		Role:      enum.RoleOwner,
+		CreatedAt: task.CreatedAt,
+	})
+	for i := range members {
+		result.Members = append(result.Members, *toTaskMemberResponse(&members[i]))
+	}
+
+	return result, nil
+}
+
+// InviteMember shares a task with another user. Inviting a member again
+// changes their role. Only the owner can share a task.
+func (s *taskMemberService) InviteMember(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.InviteMemberRequest) (*params.TaskMemberResponse, *response.CustomError) {
+	if (req.Username == "") == (req.Email == "") {
+		return nil, response.BadRequestError("exactly one of username or email is required")
+	}
+	if !req.Role.IsValid() {
+		return nil, response.BadRequestError(fmt.Sprintf("invalid role: %s", req.Role))
+	}
+
+	task, custErr := s.getTask(taskID, userID, workspaceID)
+	if custErr != nil {
+		return nil, custErr
+	}
+	if custErr := requireTaskRole(s.memberRepo, s.logger, task, userID, enum.RoleOwner); custErr != nil {
+		return nil, custErr
+	}
+
+	identifier := req.Username
+	if req.Email != "" {
+		identifier = req.Email
+	}
+	user, custErr := findUser(s.userRepo, identifier)
+	if custErr != nil {
+		return nil, custErr
+	}
+	if user.ID == task.UserID {
+		return nil, response.BadRequestError("the owner of a task cannot be invited to it")
+	}
+
+	// A task can only be shared inside its own workspace.
+	role, err := s.workspaceRepo.GetRole(task.WorkspaceID, user.ID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to check workspace membership")
+		return nil, response.RepositoryError("failed to check workspace membership")
+	}
+	if role == "" {
+		return nil, response.BadRequestError("the user is not a member of the task's workspace")
+	}
+
+	member := &models.TaskMember{
+		TaskID:    taskID,
+		UserID:    user.ID,
+		Role:      req.Role,
+		InvitedBy: userID,
+	}
+	if err := s.memberRepo.Upsert(member); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to invite task member")
+		return nil, response.RepositoryError("failed to invite task member")
+	}
+	member.User = *user
+
+	publishInvalidateUserTasksCache(s.cache, s.logger, user.ID)
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id":   taskID,
+		"user_id":   userID,
+		"member_id": user.ID,
+		"role":      member.Role,
+	}).Info("Task member invited successfully")
+
+	return toTaskMemberResponse(member), nil
+}
+
+// RevokeMember removes a member, given by username or email, from a task.
+// The owner can remove anyone; members can only leave the task themselves.
+func (s *taskMemberService) RevokeMember(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, member string) *response.CustomError {
+	task, custErr := s.getTask(taskID, userID, workspaceID)
+	if custErr != nil {
+		return custErr
+	}
+
+	user, custErr := findUser(s.userRepo, member)
+	if custErr != nil {
+		return custErr
+	}
+	if user.ID == task.UserID {
+		return response.BadRequestError("the owner of a task cannot be removed from it")
+	}
+	if user.ID != userID {
+		if custErr := requireTaskRole(s.memberRepo, s.logger, task, userID, enum.RoleOwner); custErr != nil {
+			return custErr
+		}
+	}
+
+	if err := s.memberRepo.Delete(taskID, user.ID); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to revoke task member")
+		return response.RepositoryError("failed to revoke task member")
+	}
+
+	publishInvalidateUserTasksCache(s.cache, s.logger, user.ID)
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id":   taskID,
+		"user_id":   userID,
+		"member_id": user.ID,
+	}).Info("Task member revoked successfully")
+
+	return nil
+}
+
+func (s *taskMemberService) getTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*models.Task, *response.CustomError) {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for members")
+		return nil, taskLookupError(err, "failed to get task")
+	}
+	return task, nil
+}
+
+// findUser looks a user up by username or email. An identifier containing
+// an "@" is tried as an email first, then as a username.
+func findUser(userRepo repositories.UserRepository, identifier string) (*models.User, *response.CustomError) {
+	if strings.Contains(identifier, "@") {
+		if user, err := userRepo.GetByEmail(identifier); err == nil {
+			return user, nil
+		}
+	}
+
+	user, err := userRepo.GetByUsername(identifier)
+	if err != nil {
+		return nil, response.BadRequestError("user not found")
+	}
+	return user, nil
+}
+
+func toTaskMemberResponse(member *models.TaskMember) *params.TaskMemberResponse {
+	return &params.TaskMemberResponse{
+		UserID:    member.UserID,
+		Username:  member.User.Username,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+}
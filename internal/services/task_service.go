@@ -3,104 +3,286 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/config"
 	"go-corenglish/internal/enum"
 	"go-corenglish/internal/models"
 	"go-corenglish/internal/params"
 	"go-corenglish/internal/repositories"
+	"go-corenglish/pkg/delayqueue"
+	"go-corenglish/pkg/rank"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 const cacheTTL = 60 * time.Second
 
+// dateLayout formats calendar dates such as the "My Day" date.
+const dateLayout = "2006-01-02"
+
+// maxTaskDepth is the deepest level a subtask may live at; top-level tasks
+// are level 1.
+const maxTaskDepth = 3
+
+// maxTaskTitleLength mirrors the size of tasks.title.
+const maxTaskTitleLength = 255
+
 type TaskService interface {
-	CreateTask(userID uuid.UUID, req *params.CreateTaskRequest) (*params.TaskResponse, *response.CustomError)
-	GetTask(taskID uuid.UUID, userID uuid.UUID) (*params.TaskResponse, *response.CustomError)
-	GetTasks(userID uuid.UUID, status string, page, limit int) (*params.TasksResponse, *response.CustomError)
-	UpdateTask(taskID uuid.UUID, userID uuid.UUID, req *params.UpdateTaskRequest) (*params.TaskResponse, *response.CustomError)
-	DeleteTask(taskID uuid.UUID, userID uuid.UUID) *response.CustomError
+	CreateTask(userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateTaskRequest) (*params.TaskResponse, *response.CustomError)
+	CreateTasks(userID uuid.UUID, workspaceID uuid.UUID, status enum.TaskStatus, reqs []params.CreateTaskRequest) ([]params.TaskResponse, *response.CustomError)
+	GetTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError)
+	GetTasks(userID uuid.UUID, filter *params.TaskFilter) (*params.TasksResponse, *response.CustomError)
+	GetSubtasks(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]params.TaskResponse, *response.CustomError)
+	GetTaskHistory(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]params.TaskStatusHistoryResponse, *response.CustomError)
+	UpdateTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.UpdateTaskRequest) (*params.TaskResponse, *response.CustomError)
+	ReopenTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.ReopenTaskRequest) (*params.TaskResponse, *response.CustomError)
+	DeleteTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError
+	GetTrash(userID uuid.UUID, workspaceID uuid.UUID, page, limit int) (*params.TasksResponse, *response.CustomError)
+	RestoreTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError)
+	ArchiveTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError)
+	UnarchiveTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError)
+	MoveTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.MoveTaskRequest) (*params.TaskResponse, *response.CustomError)
+	SnoozeTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.SnoozeTaskRequest) (*params.TaskResponse, *response.CustomError)
+	UnsnoozeTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError)
+	GetMyDay(userID uuid.UUID, filter *params.TaskFilter) (*params.MyDayResponse, *response.CustomError)
+	AddToMyDay(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError)
+	RemoveFromMyDay(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError)
+	QuickAddTask(userID uuid.UUID, workspaceID uuid.UUID, req *params.QuickAddTaskRequest) (*params.TaskResponse, *response.CustomError)
+	CloneTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.CloneTaskRequest) (*params.TaskResponse, *response.CustomError)
+	CloneTasks(userID uuid.UUID, filter *params.TaskFilter, req *params.CloneTaskRequest) ([]params.TaskResponse, *response.CustomError)
+	CreateShareLink(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateShareLinkRequest) (*params.ShareLinkResponse, *response.CustomError)
+	GetShareLinks(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]params.ShareLinkResponse, *response.CustomError)
+	RevokeShareLink(taskID uuid.UUID, linkID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError
+	GetSharedTask(token string) (*params.TaskResponse, *response.CustomError)
 }
 
 type taskService struct {
-	taskRepo repositories.TaskRepository
-	logger   *logrus.Logger
-	cache    *redis.Client
+	taskRepo       repositories.TaskRepository
+	labelRepo      repositories.LabelRepository
+	dependencyRepo repositories.TaskDependencyRepository
+	historyRepo    repositories.TaskHistoryRepository
+	wipLimitRepo   repositories.WIPLimitRepository
+	checklistRepo  repositories.ChecklistRepository
+	commentRepo    repositories.CommentRepository
+	userRepo       repositories.UserRepository
+	memberRepo     repositories.TaskMemberRepository
+	shareLinkRepo  repositories.ShareLinkRepository
+	projectRepo    repositories.ProjectRepository
+	workflow       WorkflowService
+	recurrence     RecurrenceService
+	cfg            *config.Config
+	logger         *logrus.Logger
+	cache          *redis.Client
+	reminders      *delayqueue.Queue
 }
 
-func NewTaskService(taskRepo repositories.TaskRepository, logger *logrus.Logger, cache *redis.Client) TaskService {
+func NewTaskService(taskRepo repositories.TaskRepository, labelRepo repositories.LabelRepository, dependencyRepo repositories.TaskDependencyRepository, historyRepo repositories.TaskHistoryRepository, wipLimitRepo repositories.WIPLimitRepository, checklistRepo repositories.ChecklistRepository, commentRepo repositories.CommentRepository, userRepo repositories.UserRepository, memberRepo repositories.TaskMemberRepository, shareLinkRepo repositories.ShareLinkRepository, projectRepo repositories.ProjectRepository, workflow WorkflowService, recurrence RecurrenceService, cfg *config.Config, logger *logrus.Logger, cache *redis.Client) TaskService {
 	return &taskService{
-		taskRepo: taskRepo,
-		logger:   logger,
-		cache:    cache,
+		taskRepo:       taskRepo,
+		labelRepo:      labelRepo,
+		dependencyRepo: dependencyRepo,
+		historyRepo:    historyRepo,
+		wipLimitRepo:   wipLimitRepo,
+		checklistRepo:  checklistRepo,
+		commentRepo:    commentRepo,
+		userRepo:       userRepo,
+		memberRepo:     memberRepo,
+		shareLinkRepo:  shareLinkRepo,
+		projectRepo:    projectRepo,
+		workflow:       workflow,
+		recurrence:     recurrence,
+		cfg:            cfg,
+		logger:         logger,
+		cache:          cache,
+		reminders:      delayqueue.New(cache, ReminderQueueKey),
 	}
 }
 
-func (s *taskService) CreateTask(userID uuid.UUID, req *params.CreateTaskRequest) (*params.TaskResponse, *response.CustomError) {
-	task := &models.Task{
-		Title:       req.Title,
-		Description: req.Description,
-		Status:      enum.StatusToDo,
-		UserID:      userID,
+func (s *taskService) CreateTask(userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateTaskRequest) (*params.TaskResponse, *response.CustomError) {
+	task, custErr := s.newTask(userID, workspaceID, req)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	if custErr := s.ensureWithinWIPLimit(userID, workspaceID, task.Status, 1); custErr != nil {
+		return nil, custErr
+	}
+
+	position, custErr := s.nextPosition(userID, workspaceID)
+	if custErr != nil {
+		return nil, custErr
 	}
+	task.Position = position
 
 	if err := s.taskRepo.Create(task); err != nil {
 		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to create task")
 		return nil, response.RepositoryError("failed to create task")
 	}
 
-	s.publishInvalidateUserTasksCache(userID)
+	s.taskCreated(task)
+
+	return toTaskResponse(task), nil
+}
+
+// CreateTasks creates a task for every request, all in the given status and
+// in a single transaction. Every request is validated, and the status column
+// checked for room for all of them, before anything is written. New tasks
+// start in TO_DO, so any other status must be reachable from there.
+func (s *taskService) CreateTasks(userID uuid.UUID, workspaceID uuid.UUID, status enum.TaskStatus, reqs []params.CreateTaskRequest) ([]params.TaskResponse, *response.CustomError) {
+	if status != enum.StatusToDo {
+		if custErr := s.workflow.CheckTransition(enum.StatusToDo, status, false); custErr != nil {
+			return nil, custErr
+		}
+	}
+
+	tasks := make([]*models.Task, len(reqs))
+	for i := range reqs {
+		task, custErr := s.newTask(userID, workspaceID, &reqs[i])
+		if custErr != nil {
+			return nil, custErr
+		}
+		setStatus(task, status)
+		tasks[i] = task
+	}
+
+	if custErr := s.ensureWithinWIPLimit(userID, workspaceID, status, len(tasks)); custErr != nil {
+		return nil, custErr
+	}
+
+	position, err := s.taskRepo.GetLastPosition(userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get last task position")
+		return nil, response.RepositoryError("failed to get task position")
+	}
+	for _, task := range tasks {
+		position, err = rank.Between(position, "")
+		if err != nil {
+			s.logger.WithError(err).WithField("last", position).Error("Failed to compute task position")
+			return nil, response.GeneralError("failed to compute task position")
+		}
+		task.Position = position
+	}
+
+	if err := s.taskRepo.CreateAll(tasks); err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to create tasks")
+		return nil, response.RepositoryError("failed to create tasks")
+	}
+
+	responses := make([]params.TaskResponse, len(tasks))
+	for i, task := range tasks {
+		s.taskCreated(task)
+		responses[i] = *toTaskResponse(task)
+	}
+
+	return responses, nil
+}
+
+// newTask validates a create request and builds the TO_DO task it asks for,
+// without a position.
+func (s *taskService) newTask(userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateTaskRequest) (*models.Task, *response.CustomError) {
+	task := &models.Task{
+		Title:           req.Title,
+		Description:     req.Description,
+		Status:          enum.StatusToDo,
+		Priority:        enum.PriorityMedium,
+		UserID:          userID,
+		WorkspaceID:     workspaceID,
+		DueAt:           req.DueAt,
+		DueAllDay:       req.DueAllDay,
+		RemindAt:        req.RemindAt,
+		ReminderChannel: enum.ReminderChannelEmail,
+	}
+	if req.Priority != nil {
+		if !req.Priority.IsValid() {
+			return nil, response.BadRequestError(fmt.Sprintf("invalid priority: %s", *req.Priority))
+		}
+		task.Priority = *req.Priority
+	}
+	if req.ReminderChannel != nil {
+		if !req.ReminderChannel.IsValid() {
+			return nil, response.BadRequestError(fmt.Sprintf("invalid reminder channel: %s", *req.ReminderChannel))
+		}
+		task.ReminderChannel = *req.ReminderChannel
+	}
+	if task.DueAllDay && task.DueAt == nil {
+		return nil, response.BadRequestError("an all-day task needs a due_at")
+	}
+
+	if req.ParentID != nil {
+		if custErr := s.validateParent(uuid.Nil, *req.ParentID, userID, workspaceID); custErr != nil {
+			return nil, custErr
+		}
+		task.ParentID = req.ParentID
+	}
+
+	if req.ProjectID != nil {
+		if custErr := s.validateProject(*req.ProjectID, userID, workspaceID); custErr != nil {
+			return nil, custErr
+		}
+		task.ProjectID = req.ProjectID
+	}
+
+	labels, custErr := s.resolveLabels(req.LabelIDs, userID)
+	if custErr != nil {
+		return nil, custErr
+	}
+	task.Labels = labels
+
+	if req.RecurrenceRule != nil {
+		if custErr := startRecurrence(task, *req.RecurrenceRule); custErr != nil {
+			return nil, custErr
+		}
+	}
+
+	return task, nil
+}
+
+// taskCreated records, schedules and announces a newly created task.
+func (s *taskService) taskCreated(task *models.Task) {
+	s.recordStatusChange(task, nil)
+
+	if task.RemindAt != nil {
+		s.syncReminder(task)
+	}
+
+	s.publishInvalidateTaskCaches(task)
 
 	s.logger.WithFields(logrus.Fields{
 		"task_id": task.ID,
-		"user_id": userID,
+		"user_id": task.UserID,
 		"title":   task.Title,
 	}).Info("Task created successfully")
-
-	return &params.TaskResponse{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
-	}, nil
 }
 
-func (s *taskService) GetTask(taskID uuid.UUID, userID uuid.UUID) (*params.TaskResponse, *response.CustomError) {
-	task, err := s.taskRepo.GetByID(taskID, userID)
+func (s *taskService) GetTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError) {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
 	if err != nil {
 		s.logger.WithError(err).WithFields(logrus.Fields{
 			"task_id": taskID,
 			"user_id": userID,
 		}).Error("Failed to get task")
-		return nil, response.RepositoryError("failed to get task")
+		return nil, taskLookupError(err, "failed to get task")
 	}
 
-	return &params.TaskResponse{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
-	}, nil
+	return s.buildTaskResponse(task), nil
 }
 
-func (s *taskService) GetTasks(userID uuid.UUID, status string, page, limit int) (*params.TasksResponse, *response.CustomError) {
-	if status != "" {
-		if !enum.TaskStatus(status).IsValid() {
-			return nil, response.BadRequestError(fmt.Sprintf("invalid status: %s", status))
-		}
+func (s *taskService) GetTasks(userID uuid.UUID, filter *params.TaskFilter) (*params.TasksResponse, *response.CustomError) {
+	if custErr := validateTaskFilter(filter); custErr != nil {
+		return nil, custErr
 	}
 
 	ctx := context.Background()
-	key := s.cacheKeyTasks(userID, status, page, limit)
+	key := s.cacheKeyTasks(userID, filter)
 
 	if val, err := s.cache.Get(ctx, key).Result(); err == nil {
 		var cached params.TasksResponse
@@ -110,31 +292,21 @@ func (s *taskService) GetTasks(userID uuid.UUID, status string, page, limit int)
 		}
 	}
 
-	tasks, total, err := s.taskRepo.GetAll(userID, status, page, limit)
+	tasks, total, err := s.taskRepo.GetAll(userID, filter)
 	if err != nil {
 		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get tasks")
 		return nil, response.RepositoryError("failed to get tasks")
 	}
 
-	taskResponses := make([]params.TaskResponse, len(tasks))
-	for i, task := range tasks {
-		taskResponses[i] = params.TaskResponse{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      task.Status,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   task.UpdatedAt,
-		}
-	}
+	taskResponses := s.buildTaskResponses(tasks)
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	totalPages := int(math.Ceil(float64(total) / float64(filter.Limit)))
 
 	response := &params.TasksResponse{
 		Tasks:      taskResponses,
 		Total:      total,
-		Page:       page,
-		Limit:      limit,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
 		TotalPages: totalPages,
 	}
 
@@ -144,9 +316,9 @@ func (s *taskService) GetTasks(userID uuid.UUID, status string, page, limit int)
 
 	s.logger.WithFields(logrus.Fields{
 		"user_id":     userID,
-		"status":      status,
-		"page":        page,
-		"limit":       limit,
+		"status":      filter.Status,
+		"page":        filter.Page,
+		"limit":       filter.Limit,
 		"total":       total,
 		"total_pages": totalPages,
 	}).Info("Tasks retrieved successfully")
@@ -154,35 +326,157 @@ func (s *taskService) GetTasks(userID uuid.UUID, status string, page, limit int)
 	return response, nil
 }
 
-func (s *taskService) UpdateTask(taskID uuid.UUID, userID uuid.UUID, req *params.UpdateTaskRequest) (*params.TaskResponse, *response.CustomError) {
-	task, err := s.taskRepo.GetByID(taskID, userID)
+func (s *taskService) UpdateTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.UpdateTaskRequest) (*params.TaskResponse, *response.CustomError) {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
 	if err != nil {
 		s.logger.WithError(err).WithFields(logrus.Fields{
 			"task_id": taskID,
 			"user_id": userID,
 		}).Error("Failed to get task for update")
-		return nil, response.RepositoryError("failed to get task for update")
+		return nil, taskLookupError(err, "failed to get task for update")
+	}
+	if custErr := s.requireTaskRole(task, userID, enum.RoleEditor); custErr != nil {
+		return nil, custErr
 	}
 
+	previousStatus := task.Status
+	previousProjectID := task.ProjectID
+
+	// Only the columns the request changes are written.
+	var columns []string
 	if req.Title != nil {
 		task.Title = *req.Title
+		columns = append(columns, "title")
 	}
 	if req.Description != nil {
 		task.Description = req.Description
+		columns = append(columns, "description")
 	}
 	if req.Status != nil {
-		if !req.Status.IsValid() {
-			return nil, response.BadRequestError(fmt.Sprintf("invalid status: %s", *req.Status))
+		if custErr := s.changeStatus(task, *req.Status, false); custErr != nil {
+			return nil, custErr
+		}
+		columns = append(columns, statusColumns...)
+	}
+	if req.Priority != nil {
+		if !req.Priority.IsValid() {
+			return nil, response.BadRequestError(fmt.Sprintf("invalid priority: %s", *req.Priority))
+		}
+		task.Priority = *req.Priority
+		columns = append(columns, "priority")
+	}
+	if req.ClearDueAt {
+		task.DueAt = nil
+		task.DueAllDay = false
+		columns = append(columns, "due_at", "due_all_day")
+	} else if req.DueAt != nil {
+		// A new due date is a point in time unless stated otherwise.
+		task.DueAt = req.DueAt
+		task.DueAllDay = false
+		columns = append(columns, "due_at", "due_all_day")
+	}
+	if req.DueAllDay != nil && !req.ClearDueAt {
+		if *req.DueAllDay && task.DueAt == nil {
+			return nil, response.BadRequestError("an all-day task needs a due_at")
+		}
+		task.DueAllDay = *req.DueAllDay
+		columns = append(columns, "due_all_day")
+	}
+	if req.ClearParent || req.ParentID != nil {
+		// The hierarchy is the owner's to organise.
+		if custErr := s.requireTaskRole(task, userID, enum.RoleOwner); custErr != nil {
+			return nil, custErr
+		}
+	}
+	if req.ClearParent {
+		task.ParentID = nil
+		columns = append(columns, "parent_id")
+	} else if req.ParentID != nil && (task.ParentID == nil || *task.ParentID != *req.ParentID) {
+		if custErr := s.validateParent(task.ID, *req.ParentID, userID, task.WorkspaceID); custErr != nil {
+			return nil, custErr
+		}
+		task.ParentID = req.ParentID
+		columns = append(columns, "parent_id")
+	}
+	if req.ClearProject || req.ProjectID != nil {
+		// Projects belong to the owner, like labels and the hierarchy.
+		if custErr := s.requireTaskRole(task, userID, enum.RoleOwner); custErr != nil {
+			return nil, custErr
 		}
-		task.Status = *req.Status
+	}
+	if req.ClearProject {
+		task.ProjectID = nil
+		columns = append(columns, "project_id")
+	} else if req.ProjectID != nil && (task.ProjectID == nil || *task.ProjectID != *req.ProjectID) {
+		if custErr := s.validateProject(*req.ProjectID, task.UserID, task.WorkspaceID); custErr != nil {
+			return nil, custErr
+		}
+		task.ProjectID = req.ProjectID
+		columns = append(columns, "project_id")
 	}
 
-	if err := s.taskRepo.Update(task); err != nil {
+	previousSeriesID, previousIndex := task.RecurrenceSeriesID, task.RecurrenceIndex
+	if req.ClearRecurrence {
+		clearRecurrence(task)
+	} else if req.RecurrenceRule != nil {
+		if custErr := startRecurrence(task, *req.RecurrenceRule); custErr != nil {
+			return nil, custErr
+		}
+	} else if task.RecurrenceRule != nil && task.DueAt == nil {
+		return nil, response.BadRequestError("a recurring task needs a due_at")
+	}
+	reminderColumns, custErr := applyReminder(task, req)
+	if custErr != nil {
+		return nil, custErr
+	}
+	columns = append(columns, reminderColumns...)
+
+	edit := repositories.TaskEdit{
+		Columns:    columns,
+		Recurrence: req.ClearRecurrence || req.RecurrenceRule != nil,
+	}
+	if req.LabelIDs != nil {
+		// Labels belong to the owner, also when an editor changes them.
+		labels, custErr := s.resolveLabels(*req.LabelIDs, task.UserID)
+		if custErr != nil {
+			return nil, custErr
+		}
+		edit.Labels = &labels
+	}
+
+	// Everything is checked by now, so the task is saved in one go.
+	if err := s.taskRepo.Edit(task, edit); err != nil {
 		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to update task")
 		return nil, response.RepositoryError("failed to update task")
 	}
 
-	s.publishInvalidateUserTasksCache(userID)
+	if task.Status != previousStatus {
+		s.recordStatusChange(task, &previousStatus)
+	}
+
+	// Leaving a series, or starting a new one, ends the old series here.
+	if previousSeriesID != nil && (task.RecurrenceSeriesID == nil || *task.RecurrenceSeriesID != *previousSeriesID) {
+		if err := s.taskRepo.EndRecurrenceSeries(*previousSeriesID, previousIndex); err != nil {
+			s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to end recurring series")
+			return nil, response.RepositoryError("failed to end recurring series")
+		}
+	}
+
+	if !previousStatus.IsDone() && task.Status.IsDone() {
+		s.spawnNextOccurrence(task)
+	}
+
+	// Finishing the task, or editing or clearing its reminder, updates the
+	// queue. Other edits leave a reminder that was already sent alone.
+	if req.RemindAt != nil || req.ClearReminder || task.Status != previousStatus {
+		s.syncReminder(task)
+	}
+
+	if req.ClearParent || req.ParentID != nil {
+		// The progress of the old parent changes too, whatever its project.
+		s.publishInvalidateUserTasksCache(task.UserID)
+	}
+	s.publishInvalidateTaskCaches(task, previousProjectID)
 
 	s.logger.WithFields(logrus.Fields{
 		"task_id": taskID,
@@ -191,53 +485,928 @@ func (s *taskService) UpdateTask(taskID uuid.UUID, userID uuid.UUID, req *params
 		"status":  task.Status,
 	}).Info("Task updated successfully")
 
-	return &params.TaskResponse{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
-	}, nil
+	return s.buildTaskResponse(task), nil
+}
+
+func (s *taskService) ReopenTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.ReopenTaskRequest) (*params.TaskResponse, *response.CustomError) {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for reopen")
+		return nil, taskLookupError(err, "failed to get task for reopen")
+	}
+	if custErr := s.requireTaskRole(task, userID, enum.RoleEditor); custErr != nil {
+		return nil, custErr
+	}
+
+	if !task.Status.IsDone() {
+		return nil, response.BadRequestError("only finished tasks can be reopened")
+	}
+
+	previousStatus := task.Status
+	status := enum.StatusToDo
+	if req.Status != nil {
+		status = *req.Status
+	}
+	if custErr := s.changeStatus(task, status, true); custErr != nil {
+		return nil, custErr
+	}
+
+	if err := s.taskRepo.Update(task, statusColumns...); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to reopen task")
+		return nil, response.RepositoryError("failed to reopen task")
+	}
+
+	s.recordStatusChange(task, &previousStatus)
+
+	if task.RemindAt != nil {
+		s.syncReminder(task)
+	}
+
+	s.publishInvalidateTaskCaches(task)
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id": taskID,
+		"user_id": userID,
+		"status":  task.Status,
+	}).Info("Task reopened successfully")
+
+	return s.buildTaskResponse(task), nil
+}
+
+func (s *taskService) GetSubtasks(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]params.TaskResponse, *response.CustomError) {
+	if _, err := s.taskRepo.GetByID(taskID, userID, workspaceID); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get parent task")
+		return nil, taskLookupError(err, "failed to get task")
+	}
+
+	subtasks, err := s.taskRepo.GetSubtasks(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get subtasks")
+		return nil, response.RepositoryError("failed to get subtasks")
+	}
+
+	return s.buildTaskResponses(subtasks), nil
 }
 
-func (s *taskService) DeleteTask(taskID uuid.UUID, userID uuid.UUID) *response.CustomError {
-	if err := s.taskRepo.Delete(taskID, userID); err != nil {
+func (s *taskService) GetTaskHistory(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]params.TaskStatusHistoryResponse, *response.CustomError) {
+	if _, err := s.taskRepo.GetByID(taskID, userID, workspaceID); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for history")
+		return nil, taskLookupError(err, "failed to get task")
+	}
+
+	entries, err := s.historyRepo.GetByTaskID(taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get task history")
+		return nil, response.RepositoryError("failed to get task history")
+	}
+
+	history := make([]params.TaskStatusHistoryResponse, len(entries))
+	for i, entry := range entries {
+		history[i] = params.TaskStatusHistoryResponse{
+			ID:         entry.ID,
+			FromStatus: entry.FromStatus,
+			ToStatus:   entry.ToStatus,
+			ChangedAt:  entry.ChangedAt,
+		}
+	}
+
+	return history, nil
+}
+
+func (s *taskService) DeleteTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for deletion")
+		return taskLookupError(err, "failed to get task for deletion")
+	}
+	if custErr := s.requireTaskRole(task, userID, enum.RoleEditor); custErr != nil {
+		return custErr
+	}
+
+	if err := s.taskRepo.Delete(taskID, userID, workspaceID); err != nil {
 		s.logger.WithError(err).WithFields(logrus.Fields{
 			"task_id": taskID,
 			"user_id": userID,
 		}).Error("Failed to delete task")
-		return response.RepositoryError("failed to delete task")
+		return taskLookupError(err, "failed to delete task")
 	}
 
-	s.publishInvalidateUserTasksCache(userID)
+	s.cancelReminder(taskID)
+
+	// The subtasks go too, and they may sit in other projects.
+	s.publishInvalidateUserTasksCache(task.UserID)
+	s.publishInvalidateTaskCaches(task)
 
 	s.logger.WithFields(logrus.Fields{
 		"task_id": taskID,
 		"user_id": userID,
-	}).Info("Task deleted successfully")
+	}).Info("Task moved to trash successfully")
 
 	return nil
 }
 
-func (s *taskService) cacheKeyTasks(userID uuid.UUID, status string, page, limit int) string {
-	return fmt.Sprintf("tasks:%s:%s:%d:%d", userID.String(), status, page, limit)
+func (s *taskService) GetTrash(userID uuid.UUID, workspaceID uuid.UUID, page, limit int) (*params.TasksResponse, *response.CustomError) {
+	tasks, total, err := s.taskRepo.GetTrash(userID, workspaceID, page, limit)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get trashed tasks")
+		return nil, response.RepositoryError("failed to get trashed tasks")
+	}
+
+	taskResponses := make([]params.TaskResponse, len(tasks))
+	for i := range tasks {
+		taskResponses[i] = *toTaskResponse(&tasks[i])
+	}
+
+	return &params.TasksResponse{
+		Tasks:      taskResponses,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
 }
 
-func (s *taskService) publishInvalidateUserTasksCache(userID uuid.UUID) {
-	ctx := context.Background()
+// RestoreTask brings a task back from the trash with the subtasks trashed
+// along with it. They return to their status columns, so each column must
+// have room for them.
+func (s *taskService) RestoreTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError) {
+	counts, err := s.taskRepo.CountTrashedByStatus(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to count trashed tasks")
+		return nil, response.RepositoryError("failed to restore task")
+	}
+	for status, count := range counts {
+		if custErr := s.ensureWithinWIPLimit(userID, workspaceID, status, int(count)); custErr != nil {
+			return nil, custErr
+		}
+	}
 
-	msg := map[string]string{
-		"user_id": userID.String(),
+	if err := s.taskRepo.Restore(taskID, userID, workspaceID); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to restore task")
+		return nil, taskLookupError(err, "failed to restore task")
+	}
+
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get restored task")
+		return nil, taskLookupError(err, "failed to get restored task")
 	}
 
-	data, err := json.Marshal(msg)
+	// The subtasks come back too, and they may sit in other projects.
+	s.publishInvalidateUserTasksCache(task.UserID)
+	s.publishInvalidateTaskCaches(task)
+
+	if task.RemindAt != nil {
+		s.syncReminder(task)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id": taskID,
+		"user_id": userID,
+	}).Info("Task restored successfully")
+
+	return s.buildTaskResponse(task), nil
+}
+
+func (s *taskService) ArchiveTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError) {
+	now := time.Now()
+	return s.setArchived(taskID, userID, workspaceID, &now)
+}
+
+func (s *taskService) UnarchiveTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError) {
+	return s.setArchived(taskID, userID, workspaceID, nil)
+}
+
+func (s *taskService) setArchived(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, archivedAt *time.Time) (*params.TaskResponse, *response.CustomError) {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to marshal cache invalidation message")
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for archive update")
+		return nil, taskLookupError(err, "failed to get task")
+	}
+	if custErr := s.requireTaskRole(task, userID, enum.RoleEditor); custErr != nil {
+		return nil, custErr
+	}
+	// An unarchived task counts against its column again.
+	if archivedAt == nil && task.ArchivedAt != nil {
+		if custErr := s.ensureWithinWIPLimit(task.UserID, task.WorkspaceID, task.Status, 1); custErr != nil {
+			return nil, custErr
+		}
+	}
+
+	if err := s.taskRepo.SetArchived(taskID, userID, workspaceID, archivedAt); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to update task archive state")
+		return nil, taskLookupError(err, "failed to update task archive state")
+	}
+
+	s.publishInvalidateTaskCaches(task)
+
+	task, err = s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get task after archive update")
+		return nil, taskLookupError(err, "failed to get task")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id":  taskID,
+		"user_id":  userID,
+		"archived": archivedAt != nil,
+	}).Info("Task archive state updated successfully")
+
+	return s.buildTaskResponse(task), nil
+}
+
+func (s *taskService) MoveTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.MoveTaskRequest) (*params.TaskResponse, *response.CustomError) {
+	if (req.BeforeID == nil) == (req.AfterID == nil) {
+		return nil, response.BadRequestError("exactly one of before_id or after_id is required")
+	}
+
+	anchorID, before := req.AfterID, false
+	if req.BeforeID != nil {
+		anchorID, before = req.BeforeID, true
+	}
+	if *anchorID == taskID {
+		return nil, response.BadRequestError("a task cannot be moved relative to itself")
+	}
+
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for move")
+		return nil, taskLookupError(err, "failed to get task for move")
+	}
+	// The manual order is the owner's own.
+	if custErr := s.requireTaskRole(task, userID, enum.RoleOwner); custErr != nil {
+		return nil, custErr
+	}
+
+	anchor, err := s.taskRepo.GetByID(*anchorID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id":   taskID,
+			"anchor_id": *anchorID,
+		}).Error("Failed to get anchor task for move")
+		return nil, taskLookupError(err, "failed to get anchor task")
+	}
+	if anchor.UserID != userID {
+		return nil, response.BadRequestError("a task can only be moved relative to your own tasks")
+	}
+
+	adjacent, err := s.taskRepo.GetAdjacentPosition(userID, task.WorkspaceID, anchor.Position, task.ID, before)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get adjacent task position")
+		return nil, response.RepositoryError("failed to move task")
+	}
+
+	lower, upper := anchor.Position, adjacent
+	if before {
+		lower, upper = adjacent, anchor.Position
+	}
+	position, err := rank.Between(lower, upper)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"lower":   lower,
+			"upper":   upper,
+		}).Error("Failed to compute task position")
+		return nil, response.GeneralError("failed to move task")
+	}
+
+	previousStatus := task.Status
+	var columns []string
+	if req.Status != nil {
+		if custErr := s.changeStatus(task, *req.Status, false); custErr != nil {
+			return nil, custErr
+		}
+		columns = statusColumns
+	}
+	task.Position = position
+
+	if err := s.taskRepo.UpdatePosition(task, columns...); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to move task")
+		return nil, response.RepositoryError("failed to move task")
+	}
+
+	if task.Status != previousStatus {
+		s.recordStatusChange(task, &previousStatus)
+	}
+
+	if !previousStatus.IsDone() && task.Status.IsDone() {
+		s.spawnNextOccurrence(task)
+	}
+
+	if task.RemindAt != nil && task.Status != previousStatus {
+		s.syncReminder(task)
+	}
+
+	s.publishInvalidateTaskCaches(task)
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id":  taskID,
+		"user_id":  userID,
+		"position": task.Position,
+		"status":   task.Status,
+	}).Info("Task moved successfully")
+
+	return s.buildTaskResponse(task), nil
+}
+
+func (s *taskService) SnoozeTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.SnoozeTaskRequest) (*params.TaskResponse, *response.CustomError) {
+	if !req.Until.After(time.Now()) {
+		return nil, response.BadRequestError("until must be in the future")
+	}
+
+	until := req.Until
+	return s.updateTaskFields(taskID, userID, workspaceID, "snooze", "snoozed_until", func(task *models.Task) {
+		task.SnoozedUntil = &until
+	})
+}
+
+func (s *taskService) UnsnoozeTask(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError) {
+	return s.updateTaskFields(taskID, userID, workspaceID, "unsnooze", "snoozed_until", func(task *models.Task) {
+		task.SnoozedUntil = nil
+	})
+}
+
+// GetMyDay lists the tasks pinned to today's "My Day" list. Today is taken
+// in the user's time zone, so the list starts empty at local midnight.
+// Snoozed tasks are included since they were pinned on purpose. Only the
+// user's own tasks can be pinned, so shared tasks are left out.
+func (s *taskService) GetMyDay(userID uuid.UUID, filter *params.TaskFilter) (*params.MyDayResponse, *response.CustomError) {
+	user, today, custErr := s.userToday(userID)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	owned := false
+	filter.FocusDate = &today
+	filter.IncludeSnoozed = true
+	filter.Shared = &owned
+	if len(filter.Sort) == 0 {
+		filter.Sort = []params.SortField{{Field: "position"}}
+	}
+
+	tasks, custErr := s.GetTasks(userID, filter)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	return &params.MyDayResponse{
+		Date:          today.Format(dateLayout),
+		Timezone:      user.Location().String(),
+		TasksResponse: *tasks,
+	}, nil
+}
+
+func (s *taskService) AddToMyDay(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError) {
+	_, today, custErr := s.userToday(userID)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	return s.updateTaskFields(taskID, userID, workspaceID, "add to my day", "focus_date", func(task *models.Task) {
+		task.FocusDate = &today
+	})
+}
+
+func (s *taskService) RemoveFromMyDay(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) (*params.TaskResponse, *response.CustomError) {
+	return s.updateTaskFields(taskID, userID, workspaceID, "remove from my day", "focus_date", func(task *models.Task) {
+		task.FocusDate = nil
+	})
+}
+
+// userToday returns the user together with the current date in the user's
+// time zone, as midnight UTC.
+func (s *taskService) userToday(userID uuid.UUID) (*models.User, time.Time, *response.CustomError) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get user")
+		return nil, time.Time{}, response.RepositoryError("failed to get user")
+	}
+
+	now := time.Now().In(user.Location())
+	return user, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// updateTaskFields loads a task, applies a change to column that needs no
+// further checks and saves it. These changes plan the owner's own day, so members
+// may not make them.
+func (s *taskService) updateTaskFields(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, action string, column string, apply func(task *models.Task)) (*params.TaskResponse, *response.CustomError) {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+			"action":  action,
+		}).Error("Failed to get task")
+		return nil, taskLookupError(err, fmt.Sprintf("failed to get task to %s", action))
+	}
+	if custErr := s.requireTaskRole(task, userID, enum.RoleOwner); custErr != nil {
+		return nil, custErr
+	}
+
+	apply(task)
+
+	if err := s.taskRepo.Update(task, column); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"action":  action,
+		}).Error("Failed to update task")
+		return nil, response.RepositoryError(fmt.Sprintf("failed to %s", action))
+	}
+
+	s.publishInvalidateTaskCaches(task)
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id": taskID,
+		"user_id": userID,
+		"action":  action,
+	}).Info("Task updated successfully")
+
+	return s.buildTaskResponse(task), nil
+}
+
+// nextPosition returns a position after every existing task of the user in
+// the workspace, so that new tasks are appended to its manual order.
+func (s *taskService) nextPosition(userID uuid.UUID, workspaceID uuid.UUID) (string, *response.CustomError) {
+	last, err := s.taskRepo.GetLastPosition(userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get last task position")
+		return "", response.RepositoryError("failed to get task position")
+	}
+
+	position, err := rank.Between(last, "")
+	if err != nil {
+		s.logger.WithError(err).WithField("last", last).Error("Failed to compute task position")
+		return "", response.GeneralError("failed to compute task position")
+	}
+
+	return position, nil
+}
+
+// validateTaskFilter checks the options of a task list query.
+func validateTaskFilter(filter *params.TaskFilter) *response.CustomError {
+	if filter.Status != "" {
+		if !enum.TaskStatus(filter.Status).IsValid() {
+			return response.BadRequestError(fmt.Sprintf("invalid status: %s", filter.Status))
+		}
+	}
+	if filter.Priority != "" {
+		if !enum.TaskPriority(filter.Priority).IsValid() {
+			return response.BadRequestError(fmt.Sprintf("invalid priority: %s", filter.Priority))
+		}
+	}
+	for _, field := range filter.Sort {
+		if !repositories.IsValidTaskSortField(field.Field) {
+			return response.BadRequestError(fmt.Sprintf("invalid sort field: %s", field.Field))
+		}
+	}
+	if filter.LabelMatch != params.LabelMatchAny && filter.LabelMatch != params.LabelMatchAll {
+		return response.BadRequestError(fmt.Sprintf("invalid label_match: %s", filter.LabelMatch))
+	}
+	if filter.DueBefore != nil && filter.DueAfter != nil && !filter.DueAfter.Before(*filter.DueBefore) {
+		return response.BadRequestError("due_after must be earlier than due_before")
+	}
+
+	return nil
+}
+
+// cacheKeyTasks builds the cache key for a tasks list page. Every filter
+// option must be part of the key. Pages of a project's list live under
+// "tasks:<user_id>:project:<project_id>:" and all others under
+// "tasks:<user_id>:all:", so the worker can drop the lists of one project,
+// or everything of a user at once.
+func (s *taskService) cacheKeyTasks(userID uuid.UUID, filter *params.TaskFilter) string {
+	scope := "all:" + filter.WorkspaceID.String()
+	if filter.ProjectID != nil {
+		scope = "project:" + filter.ProjectID.String()
+	}
+
+	return fmt.Sprintf("tasks:%s:%s:%s:%s:%s:%s:%s:%s:%t:%t:%t:%s:%s:%s:%d:%d",
+		userID.String(),
+		scope,
+		filter.Status,
+		filter.Priority,
+		strings.Join(filter.Labels, ","),
+		filter.LabelMatch,
+		formatCacheTime(filter.DueBefore),
+		formatCacheTime(filter.DueAfter),
+		filter.Overdue,
+		filter.IncludeArchived,
+		filter.IncludeSnoozed,
+		formatCacheDate(filter.FocusDate),
+		formatCacheBool(filter.Shared),
+		formatCacheSort(filter.Sort),
+		filter.Page,
+		filter.Limit,
+	)
+}
+
+// validateParent checks that parentID may become the parent of taskID
+// (uuid.Nil for a task that is being created) in the given workspace: the
+// parent must belong to the user and live in that workspace, must not be the
+// task itself or one of its descendants, and the resulting tree must not
+// exceed maxTaskDepth.
+func (s *taskService) validateParent(taskID uuid.UUID, parentID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	if taskID != uuid.Nil && taskID == parentID {
+		return response.BadRequestError("a task cannot be its own parent")
+	}
+
+	parent, err := s.taskRepo.GetByID(parentID, userID, workspaceID)
+	if err != nil || parent.UserID != userID || parent.WorkspaceID != workspaceID {
+		return response.BadRequestError("parent task not found")
+	}
+
+	ancestors, err := s.taskRepo.GetAncestorIDs(parentID)
+	if err != nil {
+		s.logger.WithError(err).WithField("parent_id", parentID).Error("Failed to get parent ancestors")
+		return response.RepositoryError("failed to validate parent task")
+	}
+
+	for _, ancestorID := range ancestors {
+		if ancestorID == taskID {
+			return response.BadRequestError("a task cannot be moved under one of its own subtasks")
+		}
+	}
+
+	height := 0
+	if taskID != uuid.Nil {
+		if height, err = s.taskRepo.GetSubtreeHeight(taskID); err != nil {
+			s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get subtree height")
+			return response.RepositoryError("failed to validate parent task")
+		}
+	}
+
+	// The parent sits at level len(ancestors)+1, the task one level below it.
+	if len(ancestors)+2+height > maxTaskDepth {
+		return response.BadRequestError(fmt.Sprintf("subtasks cannot be nested more than %d levels deep", maxTaskDepth))
+	}
+
+	return nil
+}
+
+// validateProject checks that a project of the task owner can take tasks of
+// the given workspace. Archived projects take no new tasks.
+func (s *taskService) validateProject(projectID uuid.UUID, ownerID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	project, err := s.projectRepo.GetByID(projectID, ownerID, workspaceID)
+	if errors.Is(err, repositories.ErrProjectNotFound) {
+		return response.BadRequestError("project not found")
+	}
+	if err != nil {
+		s.logger.WithError(err).WithField("project_id", projectID).Error("Failed to get project")
+		return response.RepositoryError("failed to validate project")
+	}
+	if project.ArchivedAt != nil {
+		return response.BadRequestError("project is archived")
+	}
+
+	return nil
+}
+
+// statusColumns are the columns changeStatus may change.
+var statusColumns = []string{"status", "started_at", "completed_at"}
+
+// changeStatus moves the task to the given status if the workflow allows the
+// transition and, when the task would be started or finished, none of its
+// blockers is unfinished.
+func (s *taskService) changeStatus(task *models.Task, status enum.TaskStatus, reopen bool) *response.CustomError {
+	if status == task.Status {
+		return nil
+	}
+
+	if custErr := s.workflow.CheckTransition(task.Status, status, reopen); custErr != nil {
+		return custErr
+	}
+
+	if category := status.Category(); category == enum.CategoryInProgress || category == enum.CategoryDone {
+		if custErr := s.ensureNotBlocked(task.ID); custErr != nil {
+			return custErr
+		}
+	}
+
+	if custErr := s.ensureWithinWIPLimit(task.UserID, task.WorkspaceID, status, 1); custErr != nil {
+		return custErr
+	}
+
+	setStatus(task, status)
+	return nil
+}
+
+// setStatus moves the task to status and keeps its start and completion
+// times in line with the status category.
+func setStatus(task *models.Task, status enum.TaskStatus) {
+	now := time.Now()
+	switch status.Category() {
+	case enum.CategoryInProgress:
+		if task.StartedAt == nil {
+			task.StartedAt = &now
+		}
+		task.CompletedAt = nil
+	case enum.CategoryDone:
+		task.CompletedAt = &now
+	default:
+		task.CompletedAt = nil
+	}
+
+	task.Status = status
+}
+
+// recordStatusChange appends to the task's status history. A failure only
+// loses analytics data, so it is logged rather than failing the request.
+func (s *taskService) recordStatusChange(task *models.Task, from *enum.TaskStatus) {
+	entry := &models.TaskStatusHistory{
+		TaskID:     task.ID,
+		UserID:     task.UserID,
+		FromStatus: from,
+		ToStatus:   task.Status,
+		ChangedAt:  time.Now(),
+	}
+
+	if err := s.historyRepo.Create(entry); err != nil {
+		s.logger.WithError(err).WithField("task_id", task.ID).Warn("Failed to record task status change")
+	}
+}
+
+// spawnNextOccurrence creates the next occurrence of a recurring task that
+// was just finished. The task itself is already saved, so a failure is
+// logged and left to the worker to catch up on.
+func (s *taskService) spawnNextOccurrence(task *models.Task) {
+	next, err := s.recurrence.SpawnNext(task)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", task.ID).Warn("Failed to create next task occurrence")
 		return
 	}
+	if next == nil {
+		return
+	}
+
+	if next.RemindAt != nil {
+		s.syncReminder(next)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id":       task.ID,
+		"occurrence_id": next.ID,
+		"due_at":        next.DueAt,
+	}).Info("Next task occurrence created")
+}
+
+// ensureWithinWIPLimit refuses to add tasks to a status column of the
+// workspace's board when they would exceed the user's WIP limit.
+func (s *taskService) ensureWithinWIPLimit(userID uuid.UUID, workspaceID uuid.UUID, status enum.TaskStatus, adding int) *response.CustomError {
+	limit, err := s.wipLimitRepo.GetLimit(userID, status)
+	if err != nil {
+		s.logger.WithError(err).WithField("status", status).Error("Failed to get WIP limit")
+		return response.RepositoryError("failed to check wip limit")
+	}
+	if limit == 0 || adding == 0 {
+		return nil
+	}
+
+	count, err := s.taskRepo.CountByStatus(userID, workspaceID, status)
+	if err != nil {
+		s.logger.WithError(err).WithField("status", status).Error("Failed to count tasks in status")
+		return response.RepositoryError("failed to check wip limit")
+	}
+
+	if count+int64(adding) > int64(limit) {
+		message := fmt.Sprintf("status %s has reached its wip limit of %d tasks", status, limit)
+		if adding > 1 {
+			message = fmt.Sprintf("adding %d tasks to status %s would exceed its wip limit of %d tasks", adding, status, limit)
+		}
+		return response.WIPLimitErrorWithAdditionalInfo(
+			map[string]interface{}{
+				"status":    status,
+				"wip_limit": limit,
+				"count":     count,
+				"adding":    adding,
+			},
+			message,
+		)
+	}
+
+	return nil
+}
+
+// ensureNotBlocked refuses to start or finish a task while any of the tasks
+// blocking it is unfinished. The blockers are listed in the error.
+func (s *taskService) ensureNotBlocked(taskID uuid.UUID) *response.CustomError {
+	blockers, err := s.dependencyRepo.GetUnfinishedBlockers(taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get task blockers")
+		return response.RepositoryError("failed to check task dependencies")
+	}
+
+	if len(blockers) > 0 {
+		return response.TaskBlockedErrorWithAdditionalInfo(
+			map[string]interface{}{"blocked_by": toTaskSummaryResponses(blockers)},
+			"task is blocked by unfinished tasks",
+		)
+	}
+
+	return nil
+}
+
+// resolveLabels loads the given labels, making sure each one exists and
+// belongs to the user.
+func (s *taskService) resolveLabels(labelIDs []uuid.UUID, userID uuid.UUID) ([]models.Label, *response.CustomError) {
+	labels, err := s.labelRepo.GetByIDs(labelIDs, userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get labels")
+		return nil, response.RepositoryError("failed to get labels")
+	}
+
+	if len(labels) != len(labelIDs) {
+		return nil, response.BadRequestError("one or more labels do not exist")
+	}
+
+	return labels, nil
+}
+
+func formatCacheTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func formatCacheDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+func formatCacheBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func (s *taskService) publishInvalidateUserTasksCache(userID uuid.UUID) {
+	publishInvalidateUserTasksCache(s.cache, s.logger, userID)
+}
+
+func (s *taskService) publishInvalidateTaskCaches(task *models.Task, previousProjectIDs ...*uuid.UUID) {
+	publishInvalidateTaskCaches(s.cache, s.logger, s.memberRepo, task, previousProjectIDs...)
+}
+
+func (s *taskService) requireTaskRole(task *models.Task, userID uuid.UUID, required enum.TaskRole) *response.CustomError {
+	return requireTaskRole(s.memberRepo, s.logger, task, userID, required)
+}
+
+func formatCacheSort(sort []params.SortField) string {
+	keys := make([]string, len(sort))
+	for i, field := range sort {
+		if field.Desc {
+			keys[i] = "-" + field.Field
+		} else {
+			keys[i] = field.Field
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// buildTaskResponse converts a task and attaches its subtask progress.
+func (s *taskService) buildTaskResponse(task *models.Task) *params.TaskResponse {
+	responses := s.buildTaskResponses([]models.Task{*task})
+	return &responses[0]
+}
+
+// buildTaskResponses converts tasks and attaches their subtask progress with
+// a single query. Progress is left out if it cannot be loaded.
+func (s *taskService) buildTaskResponses(tasks []models.Task) []params.TaskResponse {
+	responses := make([]params.TaskResponse, len(tasks))
+	ids := make([]uuid.UUID, len(tasks))
+	for i := range tasks {
+		responses[i] = *toTaskResponse(&tasks[i])
+		ids[i] = tasks[i].ID
+	}
+
+	progress, err := s.taskRepo.GetSubtaskProgress(ids)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to get subtask progress")
+	}
+	for i := range responses {
+		if p, ok := progress[responses[i].ID]; ok && p.Total > 0 {
+			responses[i].Progress = &params.TaskProgress{
+				Done:    p.Done,
+				Total:   p.Total,
+				Percent: math.Round(float64(p.Done)/float64(p.Total)*10000) / 100,
+			}
+		}
+	}
+
+	checklists, err := s.checklistRepo.GetCounts(ids)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to get checklist counts")
+	}
+	for i := range responses {
+		if c, ok := checklists[responses[i].ID]; ok && c.Total > 0 {
+			responses[i].Checklist = &params.ChecklistSummary{
+				Checked: c.Checked,
+				Total:   c.Total,
+			}
+		}
+	}
+
+	comments, err := s.commentRepo.GetCounts(ids)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to get comment counts")
+	}
+	for i := range responses {
+		responses[i].CommentCount = comments[responses[i].ID]
+	}
+
+	return responses
+}
+
+func toTaskResponse(task *models.Task) *params.TaskResponse {
+	return &params.TaskResponse{
+		ID:           task.ID,
+		WorkspaceID:  task.WorkspaceID,
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       task.Status,
+		Priority:     task.Priority,
+		DueAt:        task.DueAt,
+		DueAllDay:    task.DueAllDay,
+		Overdue:      task.DueAt != nil && !task.Status.IsDone() && task.DueAt.Before(time.Now()),
+		StartedAt:    task.StartedAt,
+		CompletedAt:  task.CompletedAt,
+		Archived:     task.ArchivedAt != nil,
+		ArchivedAt:   task.ArchivedAt,
+		Position:     task.Position,
+		Labels:       toLabelResponses(task.Labels),
+		ParentID:     task.ParentID,
+		ProjectID:    task.ProjectID,
+		AssignmentID: task.AssignmentID,
+		Recurrence:   toTaskRecurrence(task),
+		Reminder:     toTaskReminder(task),
+		SnoozedUntil: task.SnoozedUntil,
+		FocusDate:    formatDate(task.FocusDate),
+		CreatedAt:    task.CreatedAt,
+		UpdatedAt:    task.UpdatedAt,
+		DeletedAt:    deletedAt(task.DeletedAt),
+	}
+}
+
+func toTaskReminder(task *models.Task) *params.TaskReminder {
+	if task.RemindAt == nil {
+		return nil
+	}
+	return &params.TaskReminder{
+		RemindAt: *task.RemindAt,
+		Channel:  task.ReminderChannel,
+		SentAt:   task.ReminderSentAt,
+	}
+}
+
+func toTaskRecurrence(task *models.Task) *params.TaskRecurrence {
+	if task.RecurrenceRule == nil || task.RecurrenceSeriesID == nil {
+		return nil
+	}
+	return &params.TaskRecurrence{
+		Rule:     *task.RecurrenceRule,
+		SeriesID: *task.RecurrenceSeriesID,
+		Index:    task.RecurrenceIndex,
+	}
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	date := t.Format(dateLayout)
+	return &date
+}
 
-	if err := s.cache.Publish(ctx, "tasks:invalidate", data).Err(); err != nil {
-		s.logger.WithError(err).Error("Failed to publish cache invalidation event")
+func deletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
 	}
+	return &d.Time
 }
@@ -1 +1,361 @@
 package services
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-corenglish/internal/config"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// taskServiceMocks holds the repositories behind a taskService under test.
+type taskServiceMocks struct {
+	tasks        *repositories.MockBookRepository
+	labels       *repositories.MockLabelRepository
+	dependencies *repositories.MockTaskDependencyRepository
+	history      *repositories.MockTaskHistoryRepository
+	wipLimits    *repositories.MockWIPLimitRepository
+	checklists   *repositories.MockChecklistRepository
+	comments     *repositories.MockCommentRepository
+	users        *repositories.MockUserRepository
+	members      *repositories.MockTaskMemberRepository
+	shareLinks   *repositories.MockShareLinkRepository
+	projects     *repositories.MockProjectRepository
+}
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+// newTestCache returns a Redis client that cannot connect. Cache and queue
+// failures are only logged, so services work without Redis.
+func newTestCache(t *testing.T) *redis.Client {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// newTestWorkflow returns the default workflow: TO_DO, IN_PROGRESS and DONE,
+// where finished tasks only move back through a reopen.
+func newTestWorkflow(t *testing.T) WorkflowService {
+	workflowRepo := new(repositories.MockWorkflowRepository)
+	workflowRepo.On("GetStatuses").Return([]models.TaskStatusDefinition{
+		{Name: enum.StatusToDo, Category: enum.CategoryToDo, Position: 10},
+		{Name: enum.StatusInProgress, Category: enum.CategoryInProgress, Position: 20},
+		{Name: enum.StatusDone, Category: enum.CategoryDone, Position: 30},
+	}, nil)
+	workflowRepo.On("GetTransitions").Return([]models.TaskStatusTransition{
+		{FromStatus: enum.StatusToDo, ToStatus: enum.StatusInProgress},
+		{FromStatus: enum.StatusToDo, ToStatus: enum.StatusDone},
+		{FromStatus: enum.StatusInProgress, ToStatus: enum.StatusToDo},
+		{FromStatus: enum.StatusInProgress, ToStatus: enum.StatusDone},
+		{FromStatus: enum.StatusDone, ToStatus: enum.StatusToDo, RequiresReopen: true},
+		{FromStatus: enum.StatusDone, ToStatus: enum.StatusInProgress, RequiresReopen: true},
+	}, nil)
+
+	workflow := NewWorkflowService(workflowRepo, newTestLogger())
+	require.NoError(t, workflow.Load())
+	return workflow
+}
+
+func newTestTaskService(t *testing.T) (*taskService, *taskServiceMocks) {
+	m := &taskServiceMocks{
+		tasks:        new(repositories.MockBookRepository),
+		labels:       new(repositories.MockLabelRepository),
+		dependencies: new(repositories.MockTaskDependencyRepository),
+		history:      new(repositories.MockTaskHistoryRepository),
+		wipLimits:    new(repositories.MockWIPLimitRepository),
+		checklists:   new(repositories.MockChecklistRepository),
+		comments:     new(repositories.MockCommentRepository),
+		users:        new(repositories.MockUserRepository),
+		members:      new(repositories.MockTaskMemberRepository),
+		shareLinks:   new(repositories.MockShareLinkRepository),
+		projects:     new(repositories.MockProjectRepository),
+	}
+	t.Cleanup(func() {
+		m.tasks.AssertExpectations(t)
+		m.labels.AssertExpectations(t)
+		m.wipLimits.AssertExpectations(t)
+	})
+
+	// Bookkeeping that every write does.
+	m.history.On("Create", mock.Anything).Return(nil).Maybe()
+	m.members.On("GetUserIDs", mock.Anything).Return([]uuid.UUID{}, nil).Maybe()
+
+	logger := newTestLogger()
+	recurrence := NewRecurrenceService(m.tasks, m.history, m.users, logger)
+	service := NewTaskService(m.tasks, m.labels, m.dependencies, m.history, m.wipLimits, m.checklists, m.comments, m.users, m.members, m.shareLinks, m.projects, newTestWorkflow(t), recurrence, &config.Config{}, logger, newTestCache(t))
+	return service.(*taskService), m
+}
+
+// ownedTask returns a task of userID in the TO_DO status.
+func ownedTask(userID uuid.UUID) *models.Task {
+	return &models.Task{
+		ID:          uuid.New(),
+		Title:       "Read chapter three",
+		Status:      enum.StatusToDo,
+		Priority:    enum.PriorityMedium,
+		UserID:      userID,
+		WorkspaceID: uuid.New(),
+		Position:    "m",
+	}
+}
+
+func TestUpdateTaskRejectsUnknownLabelsBeforeSaving(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	labelIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	title := "Read chapter four"
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+	m.labels.On("GetByIDs", labelIDs, userID).Return([]models.Label{{ID: labelIDs[0], UserID: userID}}, nil)
+
+	_, custErr := service.UpdateTask(task.ID, userID, task.WorkspaceID, &params.UpdateTaskRequest{Title: &title, LabelIDs: &labelIDs})
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusBadRequest, custErr.StatusCode)
+	m.tasks.AssertNotCalled(t, "Edit", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTaskSavesFieldsAndLabelsTogether(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	labels := []models.Label{{ID: uuid.New(), UserID: userID, Name: "reading"}}
+	labelIDs := []uuid.UUID{labels[0].ID}
+	title := "Read chapter four"
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+	m.labels.On("GetByIDs", labelIDs, userID).Return(labels, nil)
+	m.tasks.On("Edit", task, repositories.TaskEdit{Columns: []string{"title"}, Labels: &labels}).Return(nil).Once()
+	m.tasks.On("GetSubtaskProgress", mock.Anything).Return(map[uuid.UUID]repositories.SubtaskProgress{}, nil).Maybe()
+	m.checklists.On("GetCounts", mock.Anything).Return(map[uuid.UUID]repositories.ChecklistCount{}, nil).Maybe()
+	m.comments.On("GetCounts", mock.Anything).Return(map[uuid.UUID]int64{}, nil).Maybe()
+
+	resp, custErr := service.UpdateTask(task.ID, userID, task.WorkspaceID, &params.UpdateTaskRequest{Title: &title, LabelIDs: &labelIDs})
+
+	require.Nil(t, custErr)
+	assert.Equal(t, title, resp.Title)
+}
+
+func TestGetTaskOfAnotherWorkspaceIsNotFound(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	otherWorkspaceID := uuid.New()
+
+	m.tasks.On("GetByID", task.ID, userID, otherWorkspaceID).Return(nil, repositories.ErrTaskNotFound)
+
+	_, custErr := service.GetTask(task.ID, userID, otherWorkspaceID)
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusNotFound, custErr.StatusCode)
+}
+
+func TestGetTaskReportsRepositoryFailures(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(nil, errors.New("connection refused"))
+
+	_, custErr := service.GetTask(task.ID, userID, task.WorkspaceID)
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusInternalServerError, custErr.StatusCode)
+}
+
+func TestMoveTaskStaysInTheWorkspaceOrder(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	anchor := ownedTask(userID)
+	anchor.WorkspaceID = task.WorkspaceID
+	anchor.Position = "a1"
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+	m.tasks.On("GetByID", anchor.ID, userID, task.WorkspaceID).Return(anchor, nil)
+	m.tasks.On("GetAdjacentPosition", userID, task.WorkspaceID, "a1", task.ID, false).Return("a3", nil)
+	m.tasks.On("UpdatePosition", task, []string(nil)).Return(nil)
+	m.tasks.On("GetSubtaskProgress", mock.Anything).Return(map[uuid.UUID]repositories.SubtaskProgress{}, nil).Maybe()
+	m.checklists.On("GetCounts", mock.Anything).Return(map[uuid.UUID]repositories.ChecklistCount{}, nil).Maybe()
+	m.comments.On("GetCounts", mock.Anything).Return(map[uuid.UUID]int64{}, nil).Maybe()
+
+	resp, custErr := service.MoveTask(task.ID, userID, task.WorkspaceID, &params.MoveTaskRequest{AfterID: &anchor.ID})
+
+	require.Nil(t, custErr)
+	assert.Greater(t, resp.Position, "a1")
+	assert.Less(t, resp.Position, "a3")
+}
+
+func TestCreateTaskEnforcesTheWIPLimit(t *testing.T) {
+	const limit = 3
+
+	tests := []struct {
+		name     string
+		count    int64
+		wantCode int
+	}{
+		{name: "the last free slot", count: limit - 1},
+		{name: "the task after the limit", count: limit, wantCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestTaskService(t)
+			userID, workspaceID := uuid.New(), uuid.New()
+
+			m.labels.On("GetByIDs", []uuid.UUID(nil), userID).Return([]models.Label{}, nil)
+			m.wipLimits.On("GetLimit", userID, enum.StatusToDo).Return(limit, nil)
+			m.tasks.On("CountByStatus", userID, workspaceID, enum.StatusToDo).Return(tt.count, nil)
+			if tt.wantCode == 0 {
+				m.tasks.On("GetLastPosition", userID, workspaceID).Return("a0", nil)
+				m.tasks.On("Create", mock.AnythingOfType("*models.Task")).Return(nil)
+			}
+
+			resp, custErr := service.CreateTask(userID, workspaceID, &params.CreateTaskRequest{Title: "Read chapter three"})
+
+			if tt.wantCode != 0 {
+				require.NotNil(t, custErr)
+				assert.Equal(t, tt.wantCode, custErr.StatusCode)
+				m.tasks.AssertNotCalled(t, "Create", mock.Anything)
+				return
+			}
+			require.Nil(t, custErr)
+			assert.Equal(t, enum.StatusToDo, resp.Status)
+		})
+	}
+}
+
+func TestRestoreTaskNeedsRoomForTheWholeSubtree(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+
+	// One slot is left in IN_PROGRESS, but the task comes back with two.
+	m.tasks.On("CountTrashedByStatus", task.ID, userID, task.WorkspaceID).
+		Return(map[enum.TaskStatus]int64{enum.StatusInProgress: 2}, nil)
+	m.wipLimits.On("GetLimit", userID, enum.StatusInProgress).Return(3, nil)
+	m.tasks.On("CountByStatus", userID, task.WorkspaceID, enum.StatusInProgress).Return(int64(2), nil)
+
+	_, custErr := service.RestoreTask(task.ID, userID, task.WorkspaceID)
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusConflict, custErr.StatusCode)
+	m.tasks.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUnarchiveTaskEnforcesTheWIPLimit(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	archivedAt := time.Now().Add(-time.Hour)
+	task.ArchivedAt = &archivedAt
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+	m.wipLimits.On("GetLimit", userID, enum.StatusToDo).Return(1, nil)
+	m.tasks.On("CountByStatus", userID, task.WorkspaceID, enum.StatusToDo).Return(int64(1), nil)
+
+	_, custErr := service.UnarchiveTask(task.ID, userID, task.WorkspaceID)
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusConflict, custErr.StatusCode)
+	m.tasks.AssertNotCalled(t, "SetArchived", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateTaskRefusesATransitionTheWorkflowDoesNotAllow(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	task.Status = enum.StatusDone
+	status := enum.StatusToDo
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+
+	_, custErr := service.UpdateTask(task.ID, userID, task.WorkspaceID, &params.UpdateTaskRequest{Status: &status})
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusConflict, custErr.StatusCode)
+	m.tasks.AssertNotCalled(t, "Edit", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTaskCannotStartWhileABlockerIsOpen(t *testing.T) {
+	service, m := newTestTaskService(t)
+	userID := uuid.New()
+	task := ownedTask(userID)
+	blocker := ownedTask(userID)
+	status := enum.StatusInProgress
+
+	m.tasks.On("GetByID", task.ID, userID, task.WorkspaceID).Return(task, nil)
+	m.dependencies.On("GetUnfinishedBlockers", task.ID).Return([]models.Task{*blocker}, nil)
+
+	_, custErr := service.UpdateTask(task.ID, userID, task.WorkspaceID, &params.UpdateTaskRequest{Status: &status})
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusConflict, custErr.StatusCode)
+	assert.Equal(t, map[string]interface{}{"blocked_by": toTaskSummaryResponses([]models.Task{*blocker})}, custErr.AdditionalInfo)
+	m.tasks.AssertNotCalled(t, "Edit", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTaskByAViewerIsForbidden(t *testing.T) {
+	service, m := newTestTaskService(t)
+	viewerID := uuid.New()
+	task := ownedTask(uuid.New())
+	title := "Read chapter four"
+
+	m.tasks.On("GetByID", task.ID, viewerID, task.WorkspaceID).Return(task, nil)
+	m.members.On("GetRole", task.ID, viewerID).Return(enum.RoleViewer, nil)
+
+	_, custErr := service.UpdateTask(task.ID, viewerID, task.WorkspaceID, &params.UpdateTaskRequest{Title: &title})
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusForbidden, custErr.StatusCode)
+	m.tasks.AssertNotCalled(t, "Edit", mock.Anything, mock.Anything)
+}
+
+func TestDeleteTaskByAnEditor(t *testing.T) {
+	service, m := newTestTaskService(t)
+	editorID := uuid.New()
+	task := ownedTask(uuid.New())
+
+	m.tasks.On("GetByID", task.ID, editorID, task.WorkspaceID).Return(task, nil)
+	m.members.On("GetRole", task.ID, editorID).Return(enum.RoleEditor, nil)
+	m.tasks.On("Delete", task.ID, editorID, task.WorkspaceID).Return(nil)
+
+	assert.Nil(t, service.DeleteTask(task.ID, editorID, task.WorkspaceID))
+}
+
+func TestTaskOfANonMemberIsNotFound(t *testing.T) {
+	service, m := newTestTaskService(t)
+	strangerID := uuid.New()
+	task := ownedTask(uuid.New())
+	title := "Read chapter four"
+
+	// Tasks neither owned by nor shared with the user are not visible.
+	m.tasks.On("GetByID", task.ID, strangerID, task.WorkspaceID).Return(nil, repositories.ErrTaskNotFound)
+
+	_, custErr := service.UpdateTask(task.ID, strangerID, task.WorkspaceID, &params.UpdateTaskRequest{Title: &title})
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusNotFound, custErr.StatusCode)
+
+	custErr = service.DeleteTask(task.ID, strangerID, task.WorkspaceID)
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusNotFound, custErr.StatusCode)
+	m.tasks.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// shareTokenBytes is the amount of randomness in a share link token.
+const shareTokenBytes = 32
+
+// CreateShareLink creates a read-only link to a task for someone without an
+// account. Only the owner can share a task.
+func (s *taskService) CreateShareLink(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.CreateShareLinkRequest) (*params.ShareLinkResponse, *response.CustomError) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, response.BadRequestError("expires_at must be in the future")
+	}
+
+	if custErr := s.ensureCanShare(taskID, userID, workspaceID); custErr != nil {
+		return nil, custErr
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to generate share link token")
+		return nil, response.GeneralError("failed to create share link")
+	}
+
+	link := &models.TaskShareLink{
+		TaskID:    taskID,
+		TokenHash: hashShareToken(token),
+		CreatedBy: userID,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.shareLinkRepo.Create(link); err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to create share link")
+		return nil, response.RepositoryError("failed to create share link")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"share_link_id": link.ID,
+		"task_id":       taskID,
+		"user_id":       userID,
+	}).Info("Share link created successfully")
+
+	result := toShareLinkResponse(link)
+	result.Token = token
+	result.URL = "/api/v1/shared/" + token
+	return result, nil
+}
+
+func (s *taskService) GetShareLinks(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) ([]params.ShareLinkResponse, *response.CustomError) {
+	if custErr := s.ensureCanShare(taskID, userID, workspaceID); custErr != nil {
+		return nil, custErr
+	}
+
+	links, err := s.shareLinkRepo.GetByTaskID(taskID)
+	if err != nil {
+		s.logger.WithError(err).WithField("task_id", taskID).Error("Failed to get share links")
+		return nil, response.RepositoryError("failed to get share links")
+	}
+
+	responses := make([]params.ShareLinkResponse, len(links))
+	for i := range links {
+		responses[i] = *toShareLinkResponse(&links[i])
+	}
+	return responses, nil
+}
+
+// RevokeShareLink deletes a share link; its token stops working at once.
+func (s *taskService) RevokeShareLink(taskID uuid.UUID, linkID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	if custErr := s.ensureCanShare(taskID, userID, workspaceID); custErr != nil {
+		return custErr
+	}
+
+	if err := s.shareLinkRepo.Delete(linkID, taskID); err != nil {
+		s.logger.WithError(err).WithField("share_link_id", linkID).Error("Failed to revoke share link")
+		return response.RepositoryError("failed to revoke share link")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"share_link_id": linkID,
+		"task_id":       taskID,
+		"user_id":       userID,
+	}).Info("Share link revoked successfully")
+
+	return nil
+}
+
+// GetSharedTask returns the task behind a share link token, redacted for a
+// reader without an account. Unknown, revoked and expired tokens, and links
+// to trashed tasks, are all reported alike.
+func (s *taskService) GetSharedTask(token string) (*params.TaskResponse, *response.CustomError) {
+	link, err := s.shareLinkRepo.GetByTokenHash(hashShareToken(token))
+	if err != nil || (link.ExpiresAt != nil && !link.ExpiresAt.After(time.Now())) {
+		return nil, response.NotFoundError("share link not found or expired")
+	}
+
+	// Links are created by the owner, so the task is loaded on their behalf.
+	task, err := s.taskRepo.GetAccessible(link.TaskID, link.CreatedBy)
+	if err != nil {
+		s.logger.WithError(err).WithField("share_link_id", link.ID).Warn("Failed to get shared task")
+		return nil, response.NotFoundError("share link not found or expired")
+	}
+
+	return redactTaskResponse(s.buildTaskResponse(task)), nil
+}
+
+// ensureCanShare checks that the user owns the task and may therefore
+// manage its share links.
+func (s *taskService) ensureCanShare(taskID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID) *response.CustomError {
+	task, err := s.taskRepo.GetByID(taskID, userID, workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"task_id": taskID,
+			"user_id": userID,
+		}).Error("Failed to get task for share links")
+		return taskLookupError(err, "failed to get task")
+	}
+	return s.requireTaskRole(task, userID, enum.RoleOwner)
+}
+
+// redactTaskResponse clears what only concerns the owner's own planning:
+// the place of the task in their lists, reminders, recurrence, snoozing and
+// My Day.
+func redactTaskResponse(task *params.TaskResponse) *params.TaskResponse {
+	task.ParentID = nil
+	task.ProjectID = nil
+	task.AssignmentID = nil
+	task.Position = ""
+	task.Reminder = nil
+	task.Recurrence = nil
+	task.SnoozedUntil = nil
+	task.FocusDate = nil
+	return task
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func toShareLinkResponse(link *models.TaskShareLink) *params.ShareLinkResponse {
+	return &params.ShareLinkResponse{
+		ID:        link.ID,
+		ExpiresAt: link.ExpiresAt,
+		Expired:   link.ExpiresAt != nil && !link.ExpiresAt.After(time.Now()),
+		CreatedAt: link.CreatedAt,
+	}
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// templatePlaceholder matches a {{variable}} placeholder. Whitespace inside
+// the braces is ignored.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+type TemplateService interface {
+	CreateTemplate(userID uuid.UUID, req *params.CreateTemplateRequest) (*params.TemplateResponse, *response.CustomError)
+	GetTemplate(templateID uuid.UUID, userID uuid.UUID) (*params.TemplateResponse, *response.CustomError)
+	GetTemplates(userID uuid.UUID) ([]params.TemplateResponse, *response.CustomError)
+	UpdateTemplate(templateID uuid.UUID, userID uuid.UUID, req *params.UpdateTemplateRequest) (*params.TemplateResponse, *response.CustomError)
+	DeleteTemplate(templateID uuid.UUID, userID uuid.UUID) *response.CustomError
+	InstantiateTemplate(templateID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.InstantiateTemplateRequest) ([]params.TaskResponse, *response.CustomError)
+}
+
+type templateService struct {
+	templateRepo repositories.TemplateRepository
+	taskService  TaskService
+	workflow     WorkflowService
+	logger       *logrus.Logger
+}
+
+func NewTemplateService(templateRepo repositories.TemplateRepository, taskService TaskService, workflow WorkflowService, logger *logrus.Logger) TemplateService {
+	return &templateService{
+		templateRepo: templateRepo,
+		taskService:  taskService,
+		workflow:     workflow,
+		logger:       logger,
+	}
+}
+
+func (s *templateService) CreateTemplate(userID uuid.UUID, req *params.CreateTemplateRequest) (*params.TemplateResponse, *response.CustomError) {
+	if _, err := s.templateRepo.GetByName(req.Name, userID); err == nil {
+		return nil, response.BadRequestError("template with this name already exists")
+	}
+
+	template := &models.TaskTemplate{
+		UserID:      userID,
+		Name:        req.Name,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      enum.StatusToDo,
+		Priority:    enum.PriorityMedium,
+	}
+	if req.Status != nil {
+		if custErr := s.validateStatus(*req.Status); custErr != nil {
+			return nil, custErr
+		}
+		template.Status = *req.Status
+	}
+	if req.Priority != nil {
+		if !req.Priority.IsValid() {
+			return nil, response.BadRequestError(fmt.Sprintf("invalid priority: %s", *req.Priority))
+		}
+		template.Priority = *req.Priority
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to create task template")
+		return nil, response.RepositoryError("failed to create template")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"template_id": template.ID,
+		"user_id":     userID,
+		"name":        template.Name,
+	}).Info("Task template created successfully")
+
+	return toTemplateResponse(template), nil
+}
+
+func (s *templateService) GetTemplate(templateID uuid.UUID, userID uuid.UUID) (*params.TemplateResponse, *response.CustomError) {
+	template, err := s.templateRepo.GetByID(templateID, userID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"template_id": templateID,
+			"user_id":     userID,
+		}).Error("Failed to get task template")
+		return nil, response.RepositoryError("failed to get template")
+	}
+
+	return toTemplateResponse(template), nil
+}
+
+func (s *templateService) GetTemplates(userID uuid.UUID) ([]params.TemplateResponse, *response.CustomError) {
+	templates, err := s.templateRepo.GetAll(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get task templates")
+		return nil, response.RepositoryError("failed to get templates")
+	}
+
+	responses := make([]params.TemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = *toTemplateResponse(&templates[i])
+	}
+	return responses, nil
+}
+
+func (s *templateService) UpdateTemplate(templateID uuid.UUID, userID uuid.UUID, req *params.UpdateTemplateRequest) (*params.TemplateResponse, *response.CustomError) {
+	template, err := s.templateRepo.GetByID(templateID, userID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"template_id": templateID,
+			"user_id":     userID,
+		}).Error("Failed to get task template for update")
+		return nil, response.RepositoryError("failed to get template for update")
+	}
+
+	if req.Name != nil && *req.Name != template.Name {
+		if _, err := s.templateRepo.GetByName(*req.Name, userID); err == nil {
+			return nil, response.BadRequestError("template with this name already exists")
+		}
+		template.Name = *req.Name
+	}
+	if req.Title != nil {
+		template.Title = *req.Title
+	}
+	if req.ClearDescription {
+		template.Description = nil
+	} else if req.Description != nil {
+		template.Description = req.Description
+	}
+	if req.Status != nil {
+		if custErr := s.validateStatus(*req.Status); custErr != nil {
+			return nil, custErr
+		}
+		template.Status = *req.Status
+	}
+	if req.Priority != nil {
+		if !req.Priority.IsValid() {
+			return nil, response.BadRequestError(fmt.Sprintf("invalid priority: %s", *req.Priority))
+		}
+		template.Priority = *req.Priority
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		s.logger.WithError(err).WithField("template_id", templateID).Error("Failed to update task template")
+		return nil, response.RepositoryError("failed to update template")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"template_id": templateID,
+		"user_id":     userID,
+	}).Info("Task template updated successfully")
+
+	return toTemplateResponse(template), nil
+}
+
+func (s *templateService) DeleteTemplate(templateID uuid.UUID, userID uuid.UUID) *response.CustomError {
+	if err := s.templateRepo.Delete(templateID, userID); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"template_id": templateID,
+			"user_id":     userID,
+		}).Error("Failed to delete task template")
+		return response.RepositoryError("failed to delete template")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"template_id": templateID,
+		"user_id":     userID,
+	}).Info("Task template deleted successfully")
+
+	return nil
+}
+
+// InstantiateTemplate creates one task per instance, in the template's
+// status, through the task service, so the tasks are validated, positioned
+// and cached like any other task. Either every instance is created or, when
+// one of them is invalid or the status column has no room for all of them,
+// none is.
+func (s *templateService) InstantiateTemplate(templateID uuid.UUID, userID uuid.UUID, workspaceID uuid.UUID, req *params.InstantiateTemplateRequest) ([]params.TaskResponse, *response.CustomError) {
+	template, err := s.templateRepo.GetByID(templateID, userID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"template_id": templateID,
+			"user_id":     userID,
+		}).Error("Failed to get task template for instantiation")
+		return nil, response.RepositoryError("failed to get template")
+	}
+
+	instances := req.Instances
+	if len(instances) == 0 {
+		instances = []params.TemplateInstance{{}}
+	}
+
+	requests := make([]params.CreateTaskRequest, len(instances))
+	for i, instance := range instances {
+		title, custErr := renderTemplate(template.Title, instance.Variables)
+		if custErr != nil {
+			return nil, custErr
+		}
+		if utf8.RuneCountInString(title) > maxTaskTitleLength {
+			return nil, response.BadRequestError(fmt.Sprintf("title of instance %d is longer than %d characters", i+1, maxTaskTitleLength))
+		}
+
+		requests[i] = params.CreateTaskRequest{
+			Title:    title,
+			Priority: &template.Priority,
+			DueAt:    instance.DueAt,
+			LabelIDs: instance.LabelIDs,
+		}
+		if template.Description != nil {
+			description, custErr := renderTemplate(*template.Description, instance.Variables)
+			if custErr != nil {
+				return nil, custErr
+			}
+			requests[i].Description = &description
+		}
+	}
+
+	tasks, custErr := s.taskService.CreateTasks(userID, workspaceID, template.Status, requests)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"template_id": templateID,
+		"user_id":     userID,
+		"tasks":       len(tasks),
+	}).Info("Task template instantiated successfully")
+
+	return tasks, nil
+}
+
+// validateStatus checks that new tasks, which start in TO_DO, can be moved
+// to the template's default status.
+func (s *templateService) validateStatus(status enum.TaskStatus) *response.CustomError {
+	return s.workflow.CheckTransition(enum.StatusToDo, status, false)
+}
+
+// renderTemplate replaces every {{variable}} placeholder in text. A
+// placeholder without a value is an error.
+func renderTemplate(text string, variables map[string]string) (string, *response.CustomError) {
+	var missing []string
+	rendered := templatePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+		value, ok := variables[name]
+		if !ok {
+			if !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+			return placeholder
+		}
+		return value
+	})
+
+	if len(missing) > 0 {
+		return "", response.BadRequestError(fmt.Sprintf("missing value for template variables: %s", strings.Join(missing, ", ")))
+	}
+	return rendered, nil
+}
+
+// templateVariables lists the distinct placeholder names used by a template.
+func templateVariables(template *models.TaskTemplate) []string {
+	texts := []string{template.Title}
+	if template.Description != nil {
+		texts = append(texts, *template.Description)
+	}
+
+	seen := make(map[string]bool)
+	variables := []string{}
+	for _, text := range texts {
+		for _, match := range templatePlaceholder.FindAllStringSubmatch(text, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				variables = append(variables, match[1])
+			}
+		}
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+func toTemplateResponse(template *models.TaskTemplate) *params.TemplateResponse {
+	return &params.TemplateResponse{
+		ID:          template.ID,
+		Name:        template.Name,
+		Title:       template.Title,
+		Description: template.Description,
+		Status:      template.Status,
+		Priority:    template.Priority,
+		Variables:   templateVariables(template),
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+}
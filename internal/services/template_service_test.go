@@ -0,0 +1,121 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTemplateService(t *testing.T, template *models.TaskTemplate) (TemplateService, *taskServiceMocks) {
+	tasks, m := newTestTaskService(t)
+
+	templates := new(repositories.MockTemplateRepository)
+	templates.On("GetByID", template.ID, template.UserID).Return(template, nil)
+
+	return NewTemplateService(templates, tasks, tasks.workflow, newTestLogger()), m
+}
+
+func weeklyTemplate(userID uuid.UUID, status enum.TaskStatus) *models.TaskTemplate {
+	return &models.TaskTemplate{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Name:     "weekly reading",
+		Title:    "Read chapter {{week}}",
+		Status:   status,
+		Priority: enum.PriorityMedium,
+	}
+}
+
+func weeks(n int) []params.TemplateInstance {
+	instances := make([]params.TemplateInstance, n)
+	for i := range instances {
+		instances[i] = params.TemplateInstance{Variables: map[string]string{"week": string(rune('1' + i))}}
+	}
+	return instances
+}
+
+func TestInstantiateTemplateCreatesTasksInTheTemplateStatus(t *testing.T) {
+	userID, workspaceID := uuid.New(), uuid.New()
+	template := weeklyTemplate(userID, enum.StatusInProgress)
+	service, m := newTestTemplateService(t, template)
+
+	m.labels.On("GetByIDs", []uuid.UUID(nil), userID).Return([]models.Label{}, nil)
+	m.wipLimits.On("GetLimit", userID, enum.StatusInProgress).Return(0, nil)
+	m.tasks.On("GetLastPosition", userID, workspaceID).Return("a0", nil)
+	m.tasks.On("CreateAll", mock.MatchedBy(func(tasks []*models.Task) bool {
+		if len(tasks) != 2 {
+			return false
+		}
+		for _, task := range tasks {
+			if task.Status != enum.StatusInProgress || task.StartedAt == nil {
+				return false
+			}
+		}
+		return "a0" < tasks[0].Position && tasks[0].Position < tasks[1].Position
+	})).Return(nil).Once()
+
+	tasks, custErr := service.InstantiateTemplate(template.ID, userID, workspaceID, &params.InstantiateTemplateRequest{Instances: weeks(2)})
+
+	require.Nil(t, custErr)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "Read chapter 1", tasks[0].Title)
+	assert.Equal(t, "Read chapter 2", tasks[1].Title)
+	m.tasks.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestInstantiateTemplateCreatesNothingWhenAnInstanceIsInvalid(t *testing.T) {
+	userID, workspaceID := uuid.New(), uuid.New()
+	template := weeklyTemplate(userID, enum.StatusToDo)
+	service, m := newTestTemplateService(t, template)
+	unknownLabel := []uuid.UUID{uuid.New()}
+
+	instances := weeks(2)
+	instances[1].LabelIDs = unknownLabel
+	m.labels.On("GetByIDs", []uuid.UUID(nil), userID).Return([]models.Label{}, nil)
+	m.labels.On("GetByIDs", unknownLabel, userID).Return([]models.Label{}, nil)
+
+	_, custErr := service.InstantiateTemplate(template.ID, userID, workspaceID, &params.InstantiateTemplateRequest{Instances: instances})
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusBadRequest, custErr.StatusCode)
+	m.tasks.AssertNotCalled(t, "Create", mock.Anything)
+	m.tasks.AssertNotCalled(t, "CreateAll", mock.Anything)
+}
+
+func TestInstantiateTemplateNeedsRoomForEveryInstance(t *testing.T) {
+	userID, workspaceID := uuid.New(), uuid.New()
+	template := weeklyTemplate(userID, enum.StatusToDo)
+	service, m := newTestTemplateService(t, template)
+
+	// Two of three slots are free, but three tasks are asked for.
+	m.labels.On("GetByIDs", []uuid.UUID(nil), userID).Return([]models.Label{}, nil)
+	m.wipLimits.On("GetLimit", userID, enum.StatusToDo).Return(3, nil)
+	m.tasks.On("CountByStatus", userID, workspaceID, enum.StatusToDo).Return(int64(1), nil)
+
+	_, custErr := service.InstantiateTemplate(template.ID, userID, workspaceID, &params.InstantiateTemplateRequest{Instances: weeks(3)})
+
+	require.NotNil(t, custErr)
+	assert.Equal(t, http.StatusConflict, custErr.StatusCode)
+	m.tasks.AssertNotCalled(t, "CreateAll", mock.Anything)
+}
+
+func TestInstantiateTemplateRejectsAStatusNewTasksCannotReach(t *testing.T) {
+	userID, workspaceID := uuid.New(), uuid.New()
+	template := weeklyTemplate(userID, enum.StatusToDo)
+	template.Status = "IN_REVIEW"
+	service, m := newTestTemplateService(t, template)
+
+	_, custErr := service.InstantiateTemplate(template.ID, userID, workspaceID, &params.InstantiateTemplateRequest{Instances: weeks(1)})
+
+	require.NotNil(t, custErr)
+	m.tasks.AssertNotCalled(t, "CreateAll", mock.Anything)
+}
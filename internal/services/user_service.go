@@ -0,0 +1,93 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type UserService interface {
+	GetProfile(userID uuid.UUID) (*params.UserProfileResponse, *response.CustomError)
+	UpdateSettings(userID uuid.UUID, req *params.UpdateUserSettingsRequest) (*params.UserProfileResponse, *response.CustomError)
+}
+
+type userService struct {
+	userRepo repositories.UserRepository
+	logger   *logrus.Logger
+}
+
+func NewUserService(userRepo repositories.UserRepository, logger *logrus.Logger) UserService {
+	return &userService{
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+func (s *userService) GetProfile(userID uuid.UUID) (*params.UserProfileResponse, *response.CustomError) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get user")
+		return nil, response.RepositoryError("failed to get user")
+	}
+
+	return toUserProfileResponse(user), nil
+}
+
+func (s *userService) UpdateSettings(userID uuid.UUID, req *params.UpdateUserSettingsRequest) (*params.UserProfileResponse, *response.CustomError) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get user for settings update")
+		return nil, response.RepositoryError("failed to get user")
+	}
+
+	if req.AutoArchiveDays != nil {
+		user.AutoArchiveDays = *req.AutoArchiveDays
+	}
+	if req.Timezone != nil {
+		if !isValidTimezone(*req.Timezone) {
+			return nil, response.BadRequestError(fmt.Sprintf("invalid timezone: %s", *req.Timezone))
+		}
+		user.Timezone = *req.Timezone
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to update user settings")
+		return nil, response.RepositoryError("failed to update user settings")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":           userID,
+		"auto_archive_days": user.AutoArchiveDays,
+		"timezone":          user.Timezone,
+	}).Info("User settings updated successfully")
+
+	return toUserProfileResponse(user), nil
+}
+
+// isValidTimezone accepts IANA time zone names such as "Europe/Berlin" or
+// "UTC". "Local" is refused since it depends on the server.
+func isValidTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+func toUserProfileResponse(user *models.User) *params.UserProfileResponse {
+	return &params.UserProfileResponse{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		Settings: params.UserSettingsResponse{
+			AutoArchiveDays: user.AutoArchiveDays,
+			Timezone:        user.Timezone,
+		},
+	}
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WorkflowRefreshInterval is how long the workflow is kept in memory before
+// being reloaded, so statuses added to the database are picked up without a
+// restart.
+const WorkflowRefreshInterval = time.Minute
+
+type WorkflowService interface {
+	Load() error
+	GetWorkflow() (*params.WorkflowResponse, *response.CustomError)
+	GetStatuses() []models.TaskStatusDefinition
+	CheckTransition(from, to enum.TaskStatus, reopen bool) *response.CustomError
+}
+
+type workflowService struct {
+	workflowRepo repositories.WorkflowRepository
+	logger       *logrus.Logger
+
+	mu          sync.RWMutex
+	loadedAt    time.Time
+	statuses    []models.TaskStatusDefinition
+	transitions map[enum.TaskStatus][]models.TaskStatusTransition
+}
+
+func NewWorkflowService(workflowRepo repositories.WorkflowRepository, logger *logrus.Logger) WorkflowService {
+	return &workflowService{
+		workflowRepo: workflowRepo,
+		logger:       logger,
+	}
+}
+
+// Load reads statuses and transitions from the database and registers the
+// statuses so that enum.TaskStatus.IsValid accepts them.
+func (s *workflowService) Load() error {
+	statuses, err := s.workflowRepo.GetStatuses()
+	if err != nil {
+		return fmt.Errorf("failed to load workflow statuses: %w", err)
+	}
+
+	transitions, err := s.workflowRepo.GetTransitions()
+	if err != nil {
+		return fmt.Errorf("failed to load workflow transitions: %w", err)
+	}
+
+	registered := make(map[enum.TaskStatus]enum.StatusCategory, len(statuses))
+	for _, status := range statuses {
+		registered[status.Name] = status.Category
+	}
+
+	byFrom := make(map[enum.TaskStatus][]models.TaskStatusTransition)
+	for _, transition := range transitions {
+		byFrom[transition.FromStatus] = append(byFrom[transition.FromStatus], transition)
+	}
+
+	enum.RegisterStatuses(registered)
+
+	s.mu.Lock()
+	s.statuses = statuses
+	s.transitions = byFrom
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+
+	s.logger.WithFields(logrus.Fields{
+		"statuses":    len(statuses),
+		"transitions": len(transitions),
+	}).Info("Task workflow loaded")
+
+	return nil
+}
+
+func (s *workflowService) GetWorkflow() (*params.WorkflowResponse, *response.CustomError) {
+	s.refreshIfStale()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	workflow := &params.WorkflowResponse{
+		Statuses:    make([]params.WorkflowStatusResponse, len(s.statuses)),
+		Transitions: []params.WorkflowTransitionResponse{},
+	}
+	for i, status := range s.statuses {
+		workflow.Statuses[i] = params.WorkflowStatusResponse{
+			Name:     status.Name,
+			Category: status.Category,
+			Position: status.Position,
+		}
+		for _, transition := range s.transitions[status.Name] {
+			workflow.Transitions = append(workflow.Transitions, params.WorkflowTransitionResponse{
+				From:           transition.FromStatus,
+				To:             transition.ToStatus,
+				RequiresReopen: transition.RequiresReopen,
+			})
+		}
+	}
+
+	return workflow, nil
+}
+
+// GetStatuses returns the workflow's statuses in display order.
+func (s *workflowService) GetStatuses() []models.TaskStatusDefinition {
+	s.refreshIfStale()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]models.TaskStatusDefinition, len(s.statuses))
+	copy(statuses, s.statuses)
+	return statuses
+}
+
+// CheckTransition validates moving a task from one status to another. reopen
+// is true when the caller explicitly asked to reopen the task. Illegal moves
+// are answered with a conflict listing the statuses that are allowed next.
+func (s *workflowService) CheckTransition(from, to enum.TaskStatus, reopen bool) *response.CustomError {
+	s.refreshIfStale()
+
+	if !to.IsValid() {
+		return response.BadRequestError(fmt.Sprintf("invalid status: %s", to))
+	}
+	if from == to {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	allowed := make([]params.AllowedTransition, 0, len(s.transitions[from]))
+	for _, transition := range s.transitions[from] {
+		if transition.ToStatus == to && (!transition.RequiresReopen || reopen) {
+			return nil
+		}
+		allowed = append(allowed, params.AllowedTransition{
+			Status:         transition.ToStatus,
+			RequiresReopen: transition.RequiresReopen,
+		})
+	}
+
+	return response.ConflictErrorWithAdditionalInfo(
+		map[string]interface{}{
+			"current_status":      from,
+			"requested_status":    to,
+			"allowed_transitions": allowed,
+		},
+		fmt.Sprintf("cannot move task from %s to %s", from, to),
+	)
+}
+
+func (s *workflowService) refreshIfStale() {
+	s.mu.RLock()
+	stale := time.Since(s.loadedAt) > WorkflowRefreshInterval
+	s.mu.RUnlock()
+
+	if !stale {
+		return
+	}
+
+	if err := s.Load(); err != nil {
+		s.logger.WithError(err).Warn("Failed to refresh task workflow, keeping previous one")
+
+		// Back off until the next interval instead of retrying on every call.
+		s.mu.Lock()
+		s.loadedAt = time.Now()
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTransition(t *testing.T) {
+	workflow := newTestWorkflow(t)
+
+	tests := []struct {
+		name     string
+		from, to enum.TaskStatus
+		reopen   bool
+		wantCode int
+	}{
+		{name: "start a task", from: enum.StatusToDo, to: enum.StatusInProgress},
+		{name: "finish a task", from: enum.StatusInProgress, to: enum.StatusDone},
+		{name: "finish without starting", from: enum.StatusToDo, to: enum.StatusDone},
+		{name: "stay in the same status", from: enum.StatusDone, to: enum.StatusDone},
+		{name: "reopen a finished task", from: enum.StatusDone, to: enum.StatusToDo, reopen: true},
+		{name: "move a finished task back without reopening", from: enum.StatusDone, to: enum.StatusToDo, wantCode: http.StatusConflict},
+		{name: "restart a finished task without reopening", from: enum.StatusDone, to: enum.StatusInProgress, wantCode: http.StatusConflict},
+		{name: "unknown status", from: enum.StatusToDo, to: "IN_REVIEW", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			custErr := workflow.CheckTransition(tt.from, tt.to, tt.reopen)
+
+			if tt.wantCode == 0 {
+				assert.Nil(t, custErr)
+				return
+			}
+			require.NotNil(t, custErr)
+			assert.Equal(t, tt.wantCode, custErr.StatusCode)
+		})
+	}
+}
+
+func TestCheckTransitionListsTheAllowedStatuses(t *testing.T) {
+	workflow := newTestWorkflow(t)
+
+	custErr := workflow.CheckTransition(enum.StatusDone, enum.StatusToDo, false)
+
+	require.NotNil(t, custErr)
+	info := custErr.AdditionalInfo.(map[string]interface{})
+	assert.Equal(t, []params.AllowedTransition{
+		{Status: enum.StatusToDo, RequiresReopen: true},
+		{Status: enum.StatusInProgress, RequiresReopen: true},
+	}, info["allowed_transitions"])
+}
+
+func TestWorkflowPicksUpNewStatusesOnRefresh(t *testing.T) {
+	workflowRepo := new(repositories.MockWorkflowRepository)
+	workflowRepo.On("GetStatuses").Return([]models.TaskStatusDefinition{
+		{Name: enum.StatusToDo, Category: enum.CategoryToDo, Position: 10},
+		{Name: enum.StatusInProgress, Category: enum.CategoryInProgress, Position: 20},
+		{Name: "IN_REVIEW", Category: enum.CategoryInProgress, Position: 25},
+		{Name: enum.StatusDone, Category: enum.CategoryDone, Position: 30},
+	}, nil)
+	workflowRepo.On("GetTransitions").Return([]models.TaskStatusTransition{
+		{FromStatus: enum.StatusInProgress, ToStatus: "IN_REVIEW"},
+		{FromStatus: "IN_REVIEW", ToStatus: enum.StatusDone},
+	}, nil)
+
+	// Start from the default workflow and put it back afterwards, since the
+	// registered statuses are shared by every test.
+	workflow := newTestWorkflow(t).(*workflowService)
+	t.Cleanup(func() { newTestWorkflow(t) })
+	workflow.workflowRepo = workflowRepo
+
+	require.NotNil(t, workflow.CheckTransition(enum.StatusInProgress, "IN_REVIEW", false), "the status is unknown until the next refresh")
+
+	workflow.loadedAt = time.Now().Add(-2 * WorkflowRefreshInterval)
+
+	assert.Nil(t, workflow.CheckTransition(enum.StatusInProgress, "IN_REVIEW", false))
+	assert.Equal(t, enum.CategoryInProgress, enum.TaskStatus("IN_REVIEW").Category())
+	assert.NotNil(t, workflow.CheckTransition(enum.StatusToDo, "IN_REVIEW", false))
+}
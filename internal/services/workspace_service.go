@@ -0,0 +1,324 @@
+package services
+
+import (
+	"fmt"
+	"go-corenglish/internal/commons/response"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/internal/params"
+	"go-corenglish/internal/repositories"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type WorkspaceService interface {
+	CreateWorkspace(userID uuid.UUID, req *params.CreateWorkspaceRequest) (*params.WorkspaceResponse, *response.CustomError)
+	GetWorkspaces(userID uuid.UUID) ([]params.WorkspaceResponse, *response.CustomError)
+	GetWorkspace(workspaceID uuid.UUID, userID uuid.UUID) (*params.WorkspaceResponse, *response.CustomError)
+	UpdateWorkspace(workspaceID uuid.UUID, userID uuid.UUID, req *params.UpdateWorkspaceRequest) (*params.WorkspaceResponse, *response.CustomError)
+	DeleteWorkspace(workspaceID uuid.UUID, userID uuid.UUID) *response.CustomError
+	GetMembers(workspaceID uuid.UUID, userID uuid.UUID) (*params.WorkspaceMembersResponse, *response.CustomError)
+	AddMember(workspaceID uuid.UUID, userID uuid.UUID, req *params.AddWorkspaceMemberRequest) (*params.WorkspaceMemberResponse, *response.CustomError)
+	RemoveMember(workspaceID uuid.UUID, userID uuid.UUID, member string) *response.CustomError
+}
+
+type workspaceService struct {
+	workspaceRepo repositories.WorkspaceRepository
+	userRepo      repositories.UserRepository
+	logger        *logrus.Logger
+	cache         *redis.Client
+}
+
+func NewWorkspaceService(workspaceRepo repositories.WorkspaceRepository, userRepo repositories.UserRepository, logger *logrus.Logger, cache *redis.Client) WorkspaceService {
+	return &workspaceService{
+		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+		logger:        logger,
+		cache:         cache,
+	}
+}
+
+// CreateWorkspace creates a shared workspace owned by the user.
+func (s *workspaceService) CreateWorkspace(userID uuid.UUID, req *params.CreateWorkspaceRequest) (*params.WorkspaceResponse, *response.CustomError) {
+	workspace := &models.Workspace{
+		Name:      req.Name,
+		CreatedBy: userID,
+		Members:   []models.WorkspaceMember{{UserID: userID, Role: enum.WorkspaceOwner}},
+	}
+	if err := s.workspaceRepo.Create(workspace); err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to create workspace")
+		return nil, response.RepositoryError("failed to create workspace")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"workspace_id": workspace.ID,
+		"user_id":      userID,
+	}).Info("Workspace created successfully")
+
+	return toWorkspaceResponse(workspace, enum.WorkspaceOwner), nil
+}
+
+// GetWorkspaces lists the workspaces the user belongs to, personal first.
+func (s *workspaceService) GetWorkspaces(userID uuid.UUID) ([]params.WorkspaceResponse, *response.CustomError) {
+	memberships, err := s.workspaceRepo.GetMemberships(userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("Failed to get workspaces")
+		return nil, response.RepositoryError("failed to get workspaces")
+	}
+
+	workspaces := make([]params.WorkspaceResponse, len(memberships))
+	for i := range memberships {
+		workspaces[i] = *toWorkspaceResponse(&memberships[i].Workspace, memberships[i].Role)
+	}
+	return workspaces, nil
+}
+
+func (s *workspaceService) GetWorkspace(workspaceID uuid.UUID, userID uuid.UUID) (*params.WorkspaceResponse, *response.CustomError) {
+	workspace, role, custErr := s.getWorkspace(workspaceID, userID, enum.WorkspaceMember)
+	if custErr != nil {
+		return nil, custErr
+	}
+	return toWorkspaceResponse(workspace, role), nil
+}
+
+// UpdateWorkspace renames a workspace. Admins and the owner may do this.
+func (s *workspaceService) UpdateWorkspace(workspaceID uuid.UUID, userID uuid.UUID, req *params.UpdateWorkspaceRequest) (*params.WorkspaceResponse, *response.CustomError) {
+	workspace, role, custErr := s.getWorkspace(workspaceID, userID, enum.WorkspaceAdmin)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	workspace.Name = req.Name
+	if err := s.workspaceRepo.Update(workspace); err != nil {
+		s.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to update workspace")
+		return nil, response.RepositoryError("failed to update workspace")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"workspace_id": workspaceID,
+		"user_id":      userID,
+	}).Info("Workspace updated successfully")
+
+	return toWorkspaceResponse(workspace, role), nil
+}
+
+// DeleteWorkspace removes a shared workspace together with all its tasks.
+// Only the owner may do this, and a personal workspace is never deleted.
+func (s *workspaceService) DeleteWorkspace(workspaceID uuid.UUID, userID uuid.UUID) *response.CustomError {
+	workspace, _, custErr := s.getWorkspace(workspaceID, userID, enum.WorkspaceOwner)
+	if custErr != nil {
+		return custErr
+	}
+	if workspace.Personal {
+		return response.BadRequestError("a personal workspace cannot be deleted")
+	}
+
+	members, err := s.workspaceRepo.GetMembers(workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to get workspace members")
+		return response.RepositoryError("failed to get workspace members")
+	}
+
+	if err := s.workspaceRepo.Delete(workspaceID); err != nil {
+		s.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to delete workspace")
+		return response.RepositoryError("failed to delete workspace")
+	}
+
+	for _, member := range members {
+		publishInvalidateUserTasksCache(s.cache, s.logger, member.UserID)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"workspace_id": workspaceID,
+		"user_id":      userID,
+	}).Info("Workspace deleted successfully")
+
+	return nil
+}
+
+// GetMembers lists the members of a workspace to any of its members.
+func (s *workspaceService) GetMembers(workspaceID uuid.UUID, userID uuid.UUID) (*params.WorkspaceMembersResponse, *response.CustomError) {
+	if _, _, custErr := s.getWorkspace(workspaceID, userID, enum.WorkspaceMember); custErr != nil {
+		return nil, custErr
+	}
+
+	members, err := s.workspaceRepo.GetMembers(workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to get workspace members")
+		return nil, response.RepositoryError("failed to get workspace members")
+	}
+
+	result := &params.WorkspaceMembersResponse{
+		WorkspaceID: workspaceID,
+		Members:     make([]params.WorkspaceMemberResponse, len(members)),
+	}
+	for i := range members {
+		result.Members[i] = *toWorkspaceMemberResponse(&members[i])
+	}
+	return result, nil
+}
+
+// AddMember adds a user, given by username or email, to a workspace or
+// changes their role. Admins may add members; only the owner may grant or
+// take away the admin role. Personal workspaces cannot be shared.
+func (s *workspaceService) AddMember(workspaceID uuid.UUID, userID uuid.UUID, req *params.AddWorkspaceMemberRequest) (*params.WorkspaceMemberResponse, *response.CustomError) {
+	if (req.Username == "") == (req.Email == "") {
+		return nil, response.BadRequestError("exactly one of username or email is required")
+	}
+	if !req.Role.IsValid() {
+		return nil, response.BadRequestError(fmt.Sprintf("invalid role: %s", req.Role))
+	}
+
+	workspace, role, custErr := s.getWorkspace(workspaceID, userID, enum.WorkspaceAdmin)
+	if custErr != nil {
+		return nil, custErr
+	}
+	if workspace.Personal {
+		return nil, response.BadRequestError("a personal workspace cannot be shared")
+	}
+
+	identifier := req.Username
+	if req.Email != "" {
+		identifier = req.Email
+	}
+	user, custErr := findUser(s.userRepo, identifier)
+	if custErr != nil {
+		return nil, custErr
+	}
+
+	current, custErr := s.getRole(workspaceID, user.ID)
+	if custErr != nil {
+		return nil, custErr
+	}
+	if current == enum.WorkspaceOwner {
+		return nil, response.BadRequestError("the role of the workspace owner cannot be changed")
+	}
+	if (req.Role == enum.WorkspaceAdmin || current == enum.WorkspaceAdmin) && role != enum.WorkspaceOwner {
+		return nil, response.ForbiddenError("only the owner of the workspace can manage admins")
+	}
+
+	member := &models.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		UserID:      user.ID,
+		Role:        req.Role,
+	}
+	if err := s.workspaceRepo.UpsertMember(member); err != nil {
+		s.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to add workspace member")
+		return nil, response.RepositoryError("failed to add workspace member")
+	}
+	member.User = *user
+
+	s.logger.WithFields(logrus.Fields{
+		"workspace_id": workspaceID,
+		"user_id":      userID,
+		"member_id":    user.ID,
+		"role":         member.Role,
+	}).Info("Workspace member added successfully")
+
+	return toWorkspaceMemberResponse(member), nil
+}
+
+// RemoveMember removes a member, given by username or email, from a
+// workspace. Admins may remove members and the owner anyone; every member
+// but the owner may leave on their own.
+func (s *workspaceService) RemoveMember(workspaceID uuid.UUID, userID uuid.UUID, member string) *response.CustomError {
+	_, role, custErr := s.getWorkspace(workspaceID, userID, enum.WorkspaceMember)
+	if custErr != nil {
+		return custErr
+	}
+
+	user, custErr := findUser(s.userRepo, member)
+	if custErr != nil {
+		return custErr
+	}
+
+	current, custErr := s.getRole(workspaceID, user.ID)
+	if custErr != nil {
+		return custErr
+	}
+	switch {
+	case current == "":
+		return response.NotFoundError("workspace member not found")
+	case current == enum.WorkspaceOwner:
+		return response.BadRequestError("the owner of a workspace cannot be removed from it")
+	case user.ID == userID:
+		// Leaving on one's own needs no further role.
+	case current == enum.WorkspaceAdmin && role != enum.WorkspaceOwner:
+		return response.ForbiddenError("only the owner of the workspace can manage admins")
+	case !role.Allows(enum.WorkspaceAdmin):
+		return response.ForbiddenError("admin access to the workspace is required")
+	}
+
+	if err := s.workspaceRepo.DeleteMember(workspaceID, user.ID); err != nil {
+		s.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to remove workspace member")
+		return response.RepositoryError("failed to remove workspace member")
+	}
+
+	publishInvalidateUserTasksCache(s.cache, s.logger, user.ID)
+
+	s.logger.WithFields(logrus.Fields{
+		"workspace_id": workspaceID,
+		"user_id":      userID,
+		"member_id":    user.ID,
+	}).Info("Workspace member removed successfully")
+
+	return nil
+}
+
+// getWorkspace loads a workspace the user belongs to and checks that their
+// role allows what is required. Non-members are told the workspace does not
+// exist.
+func (s *workspaceService) getWorkspace(workspaceID uuid.UUID, userID uuid.UUID, required enum.WorkspaceRole) (*models.Workspace, enum.WorkspaceRole, *response.CustomError) {
+	role, custErr := s.getRole(workspaceID, userID)
+	if custErr != nil {
+		return nil, "", custErr
+	}
+	if role == "" {
+		return nil, "", response.NotFoundError("workspace not found")
+	}
+	if !role.Allows(required) {
+		return nil, "", response.ForbiddenError(fmt.Sprintf("%s access to the workspace is required", strings.ToLower(string(required))))
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to get workspace")
+		return nil, "", response.RepositoryError("failed to get workspace")
+	}
+	return workspace, role, nil
+}
+
+func (s *workspaceService) getRole(workspaceID uuid.UUID, userID uuid.UUID) (enum.WorkspaceRole, *response.CustomError) {
+	role, err := s.workspaceRepo.GetRole(workspaceID, userID)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"workspace_id": workspaceID,
+			"user_id":      userID,
+		}).Error("Failed to get workspace member role")
+		return "", response.RepositoryError("failed to check workspace access")
+	}
+	return role, nil
+}
+
+func toWorkspaceResponse(workspace *models.Workspace, role enum.WorkspaceRole) *params.WorkspaceResponse {
+	return &params.WorkspaceResponse{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Personal:  workspace.Personal,
+		Role:      role,
+		CreatedAt: workspace.CreatedAt,
+		UpdatedAt: workspace.UpdatedAt,
+	}
+}
+
+func toWorkspaceMemberResponse(member *models.WorkspaceMember) *params.WorkspaceMemberResponse {
+	return &params.WorkspaceMemberResponse{
+		UserID:    member.UserID,
+		Username:  member.User.Username,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+}
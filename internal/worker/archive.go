@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// archiveFinishedTasks applies every user's auto-archive policy and drops
+// the cached task lists of the users whose tasks were archived.
+func (w *Worker) archiveFinishedTasks(ctx context.Context) {
+	userIDs, err := w.taskRepo.ArchiveFinishedTasks()
+	if err != nil {
+		w.logger.WithError(err).Error("Failed to archive finished tasks")
+		return
+	}
+
+	for _, userID := range userIDs {
+		w.invalidateUserTasksCache(ctx, userID.String())
+	}
+
+	w.logger.WithFields(logrus.Fields{
+		"users": len(userIDs),
+	}).Info("Finished tasks archived")
+}
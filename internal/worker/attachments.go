@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// blobDeletionBatch is the number of queued blobs removed per batch.
+const blobDeletionBatch = 100
+
+// deleteAttachmentBlobs removes the stored content of deleted attachments,
+// including those removed together with a task purged from the trash. A key
+// leaves the queue only once its blob is gone, so failures are retried on
+// the next run and concurrent workers at worst delete the same blob twice.
+func (w *Worker) deleteAttachmentBlobs(ctx context.Context) {
+	deleted := 0
+	for ctx.Err() == nil {
+		keys, err := w.attachmentRepo.GetPendingBlobDeletions(blobDeletionBatch)
+		if err != nil {
+			w.logger.WithError(err).Error("Failed to get pending attachment blob deletions")
+			return
+		}
+
+		progressed := false
+		for _, key := range keys {
+			if err := w.storage.Delete(ctx, key); err != nil {
+				w.logger.WithError(err).WithField("storage_key", key).Warn("Failed to delete attachment blob")
+				continue
+			}
+			if err := w.attachmentRepo.RemoveBlobDeletion(key); err != nil {
+				continue
+			}
+			deleted++
+			progressed = true
+		}
+
+		if len(keys) < blobDeletionBatch || !progressed {
+			break
+		}
+	}
+
+	w.logger.WithFields(logrus.Fields{
+		"deleted": deleted,
+	}).Info("Attachment blobs deleted")
+}
@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"context"
+	"go-corenglish/internal/services"
+	"time"
+)
+
+// startJobs launches the periodic maintenance jobs. Each job runs once at
+// start-up and then on its own interval until ctx is cancelled.
+func (w *Worker) startJobs(ctx context.Context) {
+	go w.runPeriodically(ctx, "purge_trash", time.Duration(w.cfg.TrashPurgeInterval)*time.Minute, w.purgeTrash)
+	go w.runPeriodically(ctx, "archive_finished_tasks", time.Duration(w.cfg.ArchiveInterval)*time.Minute, w.archiveFinishedTasks)
+	go w.runPeriodically(ctx, "delete_attachment_blobs", time.Duration(w.cfg.AttachmentCleanupInterval)*time.Minute, w.deleteAttachmentBlobs)
+	go w.runPeriodically(ctx, "materialize_recurring_tasks", time.Duration(w.cfg.RecurrenceInterval)*time.Minute, w.materializeRecurringTasks)
+	go w.runPeriodically(ctx, "dispatch_reminders", time.Duration(w.cfg.ReminderPollInterval)*time.Second, w.dispatchReminders)
+	go w.runPeriodically(ctx, "requeue_reminders", time.Duration(w.cfg.ReminderReconcileInterval)*time.Minute, w.requeueReminders)
+	go w.runPeriodically(ctx, "refresh_workflow", services.WorkflowRefreshInterval, w.refreshWorkflow)
+}
+
+func (w *Worker) runPeriodically(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context)) {
+	if interval <= 0 {
+		w.logger.WithField("job", name).Warn("Job disabled: interval must be positive")
+		return
+	}
+
+	w.logger.WithField("job", name).Infof("Job scheduled every %s", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
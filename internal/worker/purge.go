@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// purgeTrash permanently deletes tasks that have been in the trash for
+// longer than the configured retention period.
+func (w *Worker) purgeTrash(ctx context.Context) {
+	cutoff := time.Now().UTC().AddDate(0, 0, -w.cfg.TrashRetentionDays)
+
+	purged, err := w.taskRepo.PurgeDeleted(cutoff)
+	if err != nil {
+		w.logger.WithError(err).Error("Failed to purge trashed tasks")
+		return
+	}
+
+	w.logger.WithFields(logrus.Fields{
+		"purged": purged,
+		"cutoff": cutoff,
+	}).Info("Trashed tasks purged")
+}
@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// materializeRecurringTasks creates the occurrences of recurring tasks due
+// within the lookahead window, so that they show up before they are due.
+func (w *Worker) materializeRecurringTasks(ctx context.Context) {
+	horizon := time.Now().AddDate(0, 0, w.cfg.RecurrenceLookaheadDays)
+
+	userIDs, err := w.recurrence.MaterializeUpcoming(horizon)
+	if err != nil {
+		w.logger.WithError(err).Error("Failed to materialize recurring tasks")
+		return
+	}
+
+	for _, userID := range userIDs {
+		w.invalidateUserTasksCache(ctx, userID.String())
+	}
+
+	w.logger.WithFields(logrus.Fields{
+		"users":   len(userIDs),
+		"horizon": horizon,
+	}).Info("Recurring tasks materialized")
+}
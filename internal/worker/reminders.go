@@ -0,0 +1,208 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/models"
+	"go-corenglish/pkg/notify"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// reminderBatchSize is how many due reminders are taken from the queue
+	// at a time.
+	reminderBatchSize = 100
+	// reminderAttemptsKey counts failed deliveries per task.
+	reminderAttemptsKey = "reminders:attempts"
+	maxReminderAttempts = 5
+	reminderRetryDelay  = time.Minute
+)
+
+// newNotifiers sets up a notifier for every configured reminder channel.
+// Without SMTP settings, reminder emails are only logged.
+func newNotifiers(w *Worker) map[enum.ReminderChannel]notify.Notifier {
+	notifiers := make(map[enum.ReminderChannel]notify.Notifier)
+
+	if w.cfg.SMTPHost == "" {
+		notifiers[enum.ReminderChannelEmail] = &logNotifier{logger: w.logger}
+	} else {
+		email, err := notify.NewEmailNotifier(notify.EmailOptions{
+			Host:     w.cfg.SMTPHost,
+			Port:     w.cfg.SMTPPort,
+			Username: w.cfg.SMTPUsername,
+			Password: w.cfg.SMTPPassword,
+			From:     w.cfg.SMTPFrom,
+		})
+		if err != nil {
+			w.logger.WithError(err).Error("Email reminders disabled")
+		} else {
+			notifiers[enum.ReminderChannelEmail] = email
+		}
+	}
+
+	if w.cfg.ReminderWebhookURL != "" {
+		webhook, err := notify.NewWebhookNotifier(w.cfg.ReminderWebhookURL, w.cfg.ReminderWebhookSecret)
+		if err != nil {
+			w.logger.WithError(err).Error("Webhook reminders disabled")
+		} else {
+			notifiers[enum.ReminderChannelWebhook] = webhook
+		}
+	}
+
+	return notifiers
+}
+
+// dispatchReminders delivers every reminder that is due. Taking a reminder
+// from the queue is atomic and the task is claimed in the database before
+// delivery, so each reminder is sent at most once across all workers.
+func (w *Worker) dispatchReminders(ctx context.Context) {
+	for {
+		ids, err := w.reminders.PopDue(ctx, time.Now(), reminderBatchSize)
+		if err != nil {
+			w.logger.WithError(err).Error("Failed to take due reminders")
+			return
+		}
+
+		for _, id := range ids {
+			w.deliverReminder(ctx, id)
+		}
+
+		if len(ids) < reminderBatchSize {
+			return
+		}
+	}
+}
+
+func (w *Worker) deliverReminder(ctx context.Context, id string) {
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		w.logger.WithField("job_id", id).Warn("Ignoring malformed reminder job")
+		return
+	}
+
+	task, err := w.taskRepo.ClaimReminder(taskID)
+	if err != nil {
+		w.retryReminder(ctx, taskID, err)
+		return
+	}
+	if task == nil {
+		w.logger.WithField("task_id", taskID).Debug("Reminder no longer pending")
+		return
+	}
+
+	notifier, ok := w.notifiers[task.ReminderChannel]
+	if !ok {
+		w.logger.WithFields(logrus.Fields{
+			"task_id": taskID,
+			"channel": task.ReminderChannel,
+		}).Error("Dropping reminder: channel is not configured")
+		return
+	}
+
+	user, err := w.userRepo.GetByID(task.UserID)
+	if err != nil {
+		w.retryReminder(ctx, taskID, err)
+		return
+	}
+
+	if err := notifier.Send(ctx, reminderMessage(task, user)); err != nil {
+		w.retryReminder(ctx, taskID, err)
+		return
+	}
+
+	w.redis.HDel(ctx, reminderAttemptsKey, id)
+
+	w.logger.WithFields(logrus.Fields{
+		"task_id": taskID,
+		"user_id": task.UserID,
+		"channel": task.ReminderChannel,
+	}).Info("Reminder sent")
+}
+
+// retryReminder puts a failed reminder back into the queue with a growing
+// delay, until it has failed maxReminderAttempts times.
+func (w *Worker) retryReminder(ctx context.Context, taskID uuid.UUID, cause error) {
+	id := taskID.String()
+	logger := w.logger.WithError(cause).WithField("task_id", taskID)
+
+	attempts, err := w.redis.HIncrBy(ctx, reminderAttemptsKey, id, 1).Result()
+	if err != nil {
+		w.logger.WithError(err).WithField("task_id", taskID).Error("Failed to count reminder attempts")
+		return
+	}
+	if attempts >= maxReminderAttempts {
+		w.redis.HDel(ctx, reminderAttemptsKey, id)
+		logger.WithField("attempts", attempts).Error("Giving up on reminder")
+		return
+	}
+
+	if err := w.taskRepo.ReleaseReminder(taskID); err != nil {
+		logger.Error("Failed to release reminder for retry")
+		return
+	}
+	if err := w.reminders.Schedule(ctx, id, time.Now().Add(time.Duration(attempts)*reminderRetryDelay)); err != nil {
+		logger.Error("Failed to requeue reminder")
+		return
+	}
+
+	logger.WithField("attempts", attempts).Warn("Reminder failed, retrying later")
+}
+
+// requeueReminders schedules every pending reminder found in the database.
+// Scheduling is idempotent, so this repairs reminders whose scheduling
+// failed or that were lost by Redis without ever sending one twice.
+func (w *Worker) requeueReminders(ctx context.Context) {
+	tasks, err := w.taskRepo.GetPendingReminders()
+	if err != nil {
+		w.logger.WithError(err).Error("Failed to get pending reminders")
+		return
+	}
+
+	for _, task := range tasks {
+		if err := w.reminders.Schedule(ctx, task.ID.String(), *task.RemindAt); err != nil {
+			w.logger.WithError(err).WithField("task_id", task.ID).Error("Failed to requeue reminder")
+		}
+	}
+
+	w.logger.WithFields(logrus.Fields{
+		"reminders": len(tasks),
+	}).Info("Pending reminders requeued")
+}
+
+func reminderMessage(task *models.Task, user *models.User) notify.Message {
+	body := fmt.Sprintf("Hi %s,\n\nthis is your reminder for the task \"%s\".", user.Username, task.Title)
+	if task.DueAt != nil {
+		body += fmt.Sprintf("\nIt is due at %s.", task.DueAt.UTC().Format(time.RFC1123))
+	}
+
+	return notify.Message{
+		To:      user.Email,
+		Subject: "Reminder: " + task.Title,
+		Body:    body,
+		Data: map[string]interface{}{
+			"event":     "task.reminder",
+			"task_id":   task.ID,
+			"user_id":   task.UserID,
+			"title":     task.Title,
+			"due_at":    task.DueAt,
+			"remind_at": task.RemindAt,
+		},
+	}
+}
+
+// logNotifier stands in for email delivery when no SMTP server is set up.
+type logNotifier struct {
+	logger *logrus.Logger
+}
+
+func (n *logNotifier) Send(ctx context.Context, msg notify.Message) error {
+	n.logger.WithFields(logrus.Fields{
+		"to":      msg.To,
+		"subject": msg.Subject,
+	}).Info("Reminder email (SMTP not configured)")
+	return nil
+}
@@ -2,9 +2,16 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"go-corenglish/internal/config"
+	"go-corenglish/internal/enum"
+	"go-corenglish/internal/repositories"
+	"go-corenglish/internal/services"
 	"go-corenglish/pkg/database"
+	"go-corenglish/pkg/delayqueue"
+	"go-corenglish/pkg/notify"
+	"go-corenglish/pkg/storage"
 	"os"
 	"os/signal"
 	"syscall"
@@ -15,18 +22,39 @@ import (
 )
 
 type Worker struct {
-	logger *logrus.Logger
-	redis  *redis.Client
+	cfg            *config.Config
+	logger         *logrus.Logger
+	redis          *redis.Client
+	taskRepo       repositories.TaskRepository
+	userRepo       repositories.UserRepository
+	attachmentRepo repositories.AttachmentRepository
+	storage        storage.Storage
+	recurrence     services.RecurrenceService
+	workflow       services.WorkflowService
+	reminders      *delayqueue.Queue
+	notifiers      map[enum.ReminderChannel]notify.Notifier
 }
 
-func NewWorker(logger *logrus.Logger, redis *redis.Client) *Worker {
-	return &Worker{
-		logger: logger,
-		redis:  redis,
+func NewWorker(cfg *config.Config, logger *logrus.Logger, redis *redis.Client, taskRepo repositories.TaskRepository, userRepo repositories.UserRepository, attachmentRepo repositories.AttachmentRepository, store storage.Storage, recurrence services.RecurrenceService, workflow services.WorkflowService) *Worker {
+	w := &Worker{
+		cfg:            cfg,
+		logger:         logger,
+		redis:          redis,
+		taskRepo:       taskRepo,
+		userRepo:       userRepo,
+		attachmentRepo: attachmentRepo,
+		storage:        store,
+		recurrence:     recurrence,
+		workflow:       workflow,
+		reminders:      delayqueue.New(redis, services.ReminderQueueKey),
 	}
+	w.notifiers = newNotifiers(w)
+	return w
 }
 
 func (w *Worker) Start(ctx context.Context) {
+	w.startJobs(ctx)
+
 	sub := w.redis.Subscribe(ctx, "tasks:invalidate")
 	defer sub.Close()
 
@@ -45,8 +73,39 @@ func (w *Worker) Start(ctx context.Context) {
 	}
 }
 
-func (w *Worker) handleMessage(ctx context.Context, userID string) {
-	pattern := fmt.Sprintf("tasks:%s:*", userID)
+type invalidateMessage struct {
+	UserID     string   `json:"user_id"`
+	Scope      string   `json:"scope"`
+	ProjectIDs []string `json:"project_ids"`
+}
+
+func (w *Worker) handleMessage(ctx context.Context, payload string) {
+	var msg invalidateMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil || msg.UserID == "" {
+		w.logger.WithField("payload", payload).Warn("Ignoring malformed cache invalidation message")
+		return
+	}
+
+	if msg.Scope != services.InvalidateScopeTask {
+		w.invalidateUserTasksCache(ctx, msg.UserID)
+		return
+	}
+
+	// A task change leaves the lists of the user's other projects alone.
+	w.deleteCacheKeys(ctx, fmt.Sprintf("tasks:%s:all:*", msg.UserID))
+	for _, projectID := range msg.ProjectIDs {
+		w.deleteCacheKeys(ctx, fmt.Sprintf("tasks:%s:project:%s:*", msg.UserID, projectID))
+	}
+}
+
+// invalidateUserTasksCache deletes every cached task list page of a user,
+// project lists included.
+func (w *Worker) invalidateUserTasksCache(ctx context.Context, userID string) {
+	w.deleteCacheKeys(ctx, fmt.Sprintf("tasks:%s:*", userID))
+}
+
+// deleteCacheKeys deletes every cached task list page matching pattern.
+func (w *Worker) deleteCacheKeys(ctx context.Context, pattern string) {
 	iter := w.redis.Scan(ctx, 0, pattern, 0).Iterator()
 
 	for iter.Next(ctx) {
@@ -70,10 +129,35 @@ func Run() {
 
 	logger := setupLogger(cfg)
 
+	db, err := database.Connect(cfg, logger)
+	if err != nil {
+		logger.Fatalf("Failed to connect to database: %v", err)
+	}
+
 	redisClient := database.ConnectRedis(cfg, logger)
 	defer redisClient.Close()
 
-	worker := NewWorker(logger, redisClient)
+	attachmentStorage, err := storage.New(cfg)
+	if err != nil {
+		logger.Fatalf("Failed to set up attachment storage: %v", err)
+	}
+
+	taskRepo := repositories.NewTaskRepository(db, logger)
+	userRepo := repositories.NewUserRepository(db, logger)
+	attachmentRepo := repositories.NewAttachmentRepository(db, logger)
+	historyRepo := repositories.NewTaskHistoryRepository(db, logger)
+	workflowRepo := repositories.NewWorkflowRepository(db, logger)
+
+	// Status categories, such as which statuses count as finished, come from
+	// the workflow.
+	workflowService := services.NewWorkflowService(workflowRepo, logger)
+	if err := workflowService.Load(); err != nil {
+		logger.Fatalf("Failed to load task workflow: %v", err)
+	}
+
+	recurrenceService := services.NewRecurrenceService(taskRepo, historyRepo, userRepo, logger)
+
+	worker := NewWorker(cfg, logger, redisClient, taskRepo, userRepo, attachmentRepo, attachmentStorage, recurrenceService, workflowService)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -0,0 +1,14 @@
+package worker
+
+import (
+	"context"
+)
+
+// refreshWorkflow reloads the task workflow, so that statuses added to the
+// database are known to the worker without a restart. On failure the
+// previous workflow is kept.
+func (w *Worker) refreshWorkflow(ctx context.Context) {
+	if err := w.workflow.Load(); err != nil {
+		w.logger.WithError(err).Warn("Failed to refresh task workflow, keeping previous one")
+	}
+}
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_user_id_due_at;
+
+-- Drop column
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_at;
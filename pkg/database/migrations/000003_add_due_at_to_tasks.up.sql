@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN due_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_tasks_user_id_due_at ON tasks(user_id, due_at);
@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_user_id_priority;
+
+-- Drop column
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
+
+-- Drop enum type
+DROP TYPE IF EXISTS task_priority;
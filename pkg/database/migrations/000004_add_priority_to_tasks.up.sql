@@ -0,0 +1,6 @@
+-- Declaration order matters: ORDER BY priority sorts LOW < MEDIUM < HIGH < URGENT.
+CREATE TYPE task_priority AS ENUM ('LOW', 'MEDIUM', 'HIGH', 'URGENT');
+
+ALTER TABLE tasks ADD COLUMN priority task_priority NOT NULL DEFAULT 'MEDIUM';
+
+CREATE INDEX idx_tasks_user_id_priority ON tasks(user_id, priority);
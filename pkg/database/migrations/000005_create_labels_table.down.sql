@@ -0,0 +1,10 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_labels_updated_at ON labels;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_task_labels_label_id;
+DROP INDEX IF EXISTS idx_labels_user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS task_labels;
+DROP TABLE IF EXISTS labels;
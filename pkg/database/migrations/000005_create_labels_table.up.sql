@@ -0,0 +1,28 @@
+CREATE TABLE labels (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '#808080',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX idx_labels_user_id ON labels(user_id);
+
+CREATE TABLE task_labels (
+    task_id UUID NOT NULL,
+    label_id UUID NOT NULL,
+    PRIMARY KEY (task_id, label_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (label_id) REFERENCES labels(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_labels_label_id ON task_labels(label_id);
+
+-- Add trigger to update updated_at
+CREATE TRIGGER update_labels_updated_at 
+    BEFORE UPDATE ON labels 
+    FOR EACH ROW 
+    EXECUTE FUNCTION update_updated_at_column();
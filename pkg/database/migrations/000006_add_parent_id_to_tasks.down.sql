@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+
+-- Drop constraint and column
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_parent_not_self;
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
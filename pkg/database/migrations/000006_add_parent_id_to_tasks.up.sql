@@ -0,0 +1,6 @@
+-- Deleting a task removes its whole subtree.
+ALTER TABLE tasks ADD COLUMN parent_id UUID REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE tasks ADD CONSTRAINT chk_tasks_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_task_dependencies_blocked_by_id;
+
+-- Drop task_dependencies table
+DROP TABLE IF EXISTS task_dependencies;
@@ -0,0 +1,12 @@
+-- A row means task_id is blocked by blocked_by_id.
+CREATE TABLE task_dependencies (
+    task_id UUID NOT NULL,
+    blocked_by_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, blocked_by_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (blocked_by_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CHECK (task_id <> blocked_by_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocked_by_id ON task_dependencies(blocked_by_id);
@@ -0,0 +1,15 @@
+CREATE TYPE task_status AS ENUM ('TO_DO', 'IN_PROGRESS', 'DONE');
+
+-- Map custom statuses back onto the built-in one of the same category.
+UPDATE tasks SET status = s.category
+FROM task_statuses s
+WHERE s.name = tasks.status AND tasks.status NOT IN ('TO_DO', 'IN_PROGRESS', 'DONE');
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS fk_tasks_status;
+ALTER TABLE tasks ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE tasks ALTER COLUMN status TYPE task_status USING status::task_status;
+ALTER TABLE tasks ALTER COLUMN status SET DEFAULT 'TO_DO';
+
+-- Drop workflow tables
+DROP TABLE IF EXISTS task_status_transitions;
+DROP TABLE IF EXISTS task_statuses;
@@ -0,0 +1,42 @@
+-- Statuses are data rather than a Postgres ENUM so new ones (e.g. BLOCKED,
+-- IN_REVIEW) can be added by inserting rows. category tells the application
+-- whether a status means not started, in progress or finished.
+CREATE TABLE task_statuses (
+    name VARCHAR(50) PRIMARY KEY,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('TO_DO', 'IN_PROGRESS', 'DONE')),
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A task may only move from from_status to to_status if a row exists.
+-- requires_reopen transitions are only allowed through the reopen endpoint.
+CREATE TABLE task_status_transitions (
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    requires_reopen BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (from_status, to_status),
+    FOREIGN KEY (from_status) REFERENCES task_statuses(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (to_status) REFERENCES task_statuses(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    CHECK (from_status <> to_status)
+);
+
+INSERT INTO task_statuses (name, category, position) VALUES
+    ('TO_DO', 'TO_DO', 10),
+    ('IN_PROGRESS', 'IN_PROGRESS', 20),
+    ('DONE', 'DONE', 30);
+
+INSERT INTO task_status_transitions (from_status, to_status, requires_reopen) VALUES
+    ('TO_DO', 'IN_PROGRESS', FALSE),
+    ('TO_DO', 'DONE', FALSE),
+    ('IN_PROGRESS', 'TO_DO', FALSE),
+    ('IN_PROGRESS', 'DONE', FALSE),
+    ('DONE', 'TO_DO', TRUE),
+    ('DONE', 'IN_PROGRESS', TRUE);
+
+ALTER TABLE tasks ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE tasks ALTER COLUMN status TYPE VARCHAR(50) USING status::text;
+ALTER TABLE tasks ALTER COLUMN status SET DEFAULT 'TO_DO';
+ALTER TABLE tasks ADD CONSTRAINT fk_tasks_status
+    FOREIGN KEY (status) REFERENCES task_statuses(name) ON UPDATE CASCADE;
+
+DROP TYPE IF EXISTS task_status;
@@ -0,0 +1,11 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_task_status_history_user_id;
+DROP INDEX IF EXISTS idx_task_status_history_task_id;
+DROP INDEX IF EXISTS idx_tasks_user_id_completed_at;
+
+-- Drop task_status_history table
+DROP TABLE IF EXISTS task_status_history;
+
+-- Drop columns
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS started_at;
@@ -0,0 +1,24 @@
+ALTER TABLE tasks ADD COLUMN started_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tasks ADD COLUMN completed_at TIMESTAMP WITH TIME ZONE;
+
+-- Best-effort backfill for tasks that already moved through the workflow.
+UPDATE tasks SET started_at = updated_at
+WHERE status IN (SELECT name FROM task_statuses WHERE category IN ('IN_PROGRESS', 'DONE'));
+UPDATE tasks SET completed_at = updated_at
+WHERE status IN (SELECT name FROM task_statuses WHERE category = 'DONE');
+
+CREATE INDEX idx_tasks_user_id_completed_at ON tasks(user_id, completed_at);
+
+CREATE TABLE task_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_status_history_task_id ON task_status_history(task_id, changed_at);
+CREATE INDEX idx_task_status_history_user_id ON task_status_history(user_id, changed_at);
@@ -0,0 +1,8 @@
+-- Trashed tasks cannot be represented without the column.
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+
+-- Drop column
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
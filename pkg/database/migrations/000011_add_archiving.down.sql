@@ -0,0 +1,7 @@
+-- Drop columns
+ALTER TABLE users DROP COLUMN IF EXISTS auto_archive_days;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_user_id_archived_at;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS archived_at;
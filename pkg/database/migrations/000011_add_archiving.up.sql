@@ -0,0 +1,6 @@
+ALTER TABLE tasks ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_tasks_user_id_archived_at ON tasks(user_id, archived_at);
+
+-- 0 disables automatic archiving of finished tasks.
+ALTER TABLE users ADD COLUMN auto_archive_days INTEGER NOT NULL DEFAULT 0 CHECK (auto_archive_days >= 0);
@@ -0,0 +1,14 @@
+-- Positions are fractional rank keys (see pkg/rank) compared byte-wise.
+ALTER TABLE tasks ADD COLUMN position VARCHAR(255) COLLATE "C";
+
+-- Existing tasks keep their creation order: 'h' announces an eight digit integer part.
+UPDATE tasks t SET position = ranked.position
+FROM (
+    SELECT id, 'h' || LPAD(ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at, id)::TEXT, 8, '0') AS position
+    FROM tasks
+) ranked
+WHERE t.id = ranked.id;
+
+ALTER TABLE tasks ALTER COLUMN position SET NOT NULL;
+
+CREATE INDEX idx_tasks_user_id_position ON tasks(user_id, position);
@@ -0,0 +1,8 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_wip_limits_updated_at ON wip_limits;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_user_id_status;
+
+-- Drop tables
+DROP TABLE IF EXISTS wip_limits;
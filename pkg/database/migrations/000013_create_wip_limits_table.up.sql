@@ -0,0 +1,19 @@
+-- Per-user work-in-progress limit of a board column (status).
+CREATE TABLE wip_limits (
+    user_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    wip_limit INTEGER NOT NULL CHECK (wip_limit > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, status),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (status) REFERENCES task_statuses(name) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_tasks_user_id_status ON tasks(user_id, status);
+
+-- Add trigger to update updated_at
+CREATE TRIGGER update_wip_limits_updated_at
+    BEFORE UPDATE ON wip_limits
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_task_checklist_items_updated_at ON task_checklist_items;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_task_checklist_items_task_id_position;
+
+-- Drop tables
+DROP TABLE IF EXISTS task_checklist_items;
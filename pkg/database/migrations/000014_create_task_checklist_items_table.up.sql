@@ -0,0 +1,19 @@
+CREATE TABLE task_checklist_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL,
+    text VARCHAR(500) NOT NULL,
+    checked BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Fractional rank key (see pkg/rank) compared byte-wise.
+    position VARCHAR(255) COLLATE "C" NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_checklist_items_task_id_position ON task_checklist_items(task_id, position);
+
+-- Add trigger to update updated_at
+CREATE TRIGGER update_task_checklist_items_updated_at
+    BEFORE UPDATE ON task_checklist_items
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
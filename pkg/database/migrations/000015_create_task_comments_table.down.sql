@@ -0,0 +1,8 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_task_comments_updated_at ON task_comments;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_task_comments_task_id_created_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS task_comments;
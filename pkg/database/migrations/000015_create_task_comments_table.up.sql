@@ -0,0 +1,20 @@
+CREATE TABLE task_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    body TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- Comments are paged by (created_at, id).
+CREATE INDEX idx_task_comments_task_id_created_at ON task_comments(task_id, created_at, id);
+
+-- Add trigger to update updated_at
+CREATE TRIGGER update_task_comments_updated_at
+    BEFORE UPDATE ON task_comments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
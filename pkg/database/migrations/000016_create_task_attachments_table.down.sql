@@ -0,0 +1,10 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS queue_task_attachments_blob_deletion ON task_attachments;
+DROP FUNCTION IF EXISTS queue_attachment_blob_deletion();
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_task_attachments_task_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS attachment_blob_deletions;
+DROP TABLE IF EXISTS task_attachments;
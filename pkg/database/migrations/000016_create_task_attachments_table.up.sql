@@ -0,0 +1,37 @@
+CREATE TABLE task_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    checksum_sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(500) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_attachments_task_id ON task_attachments(task_id);
+
+-- Blobs whose attachment row is gone, waiting to be removed from storage by
+-- the worker. Rows are queued by a trigger so that attachments deleted by
+-- cascade (e.g. when a task is purged from the trash) are covered too.
+CREATE TABLE attachment_blob_deletions (
+    storage_key VARCHAR(500) PRIMARY KEY,
+    queued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION queue_attachment_blob_deletion()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO attachment_blob_deletions (storage_key) VALUES (OLD.storage_key)
+    ON CONFLICT (storage_key) DO NOTHING;
+    RETURN OLD;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER queue_task_attachments_blob_deletion
+    AFTER DELETE ON task_attachments
+    FOR EACH ROW
+    EXECUTE FUNCTION queue_attachment_blob_deletion();
@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_recurrence_occurrence;
+
+-- Drop columns
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_index;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_start;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_series_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_rule;
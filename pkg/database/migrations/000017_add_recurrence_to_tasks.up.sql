@@ -0,0 +1,9 @@
+-- A recurring series is the set of tasks sharing recurrence_series_id. Every
+-- occurrence carries the RRULE, the series start (DTSTART) and its number.
+ALTER TABLE tasks ADD COLUMN recurrence_rule VARCHAR(255);
+ALTER TABLE tasks ADD COLUMN recurrence_series_id UUID;
+ALTER TABLE tasks ADD COLUMN recurrence_start TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tasks ADD COLUMN recurrence_index INTEGER NOT NULL DEFAULT 0;
+
+-- Each occurrence is created at most once, whether on completion or by the worker.
+CREATE UNIQUE INDEX idx_tasks_recurrence_occurrence ON tasks(recurrence_series_id, recurrence_index);
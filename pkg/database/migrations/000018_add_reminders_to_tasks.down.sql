@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_pending_reminders;
+
+-- Drop columns
+ALTER TABLE tasks DROP COLUMN IF EXISTS reminder_sent_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS reminder_channel;
+ALTER TABLE tasks DROP COLUMN IF EXISTS remind_at;
@@ -0,0 +1,8 @@
+-- reminder_sent_at is set when a worker takes the reminder, so that it is
+-- delivered at most once even with several workers.
+ALTER TABLE tasks ADD COLUMN remind_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tasks ADD COLUMN reminder_channel VARCHAR(20) NOT NULL DEFAULT 'EMAIL';
+ALTER TABLE tasks ADD COLUMN reminder_sent_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_tasks_pending_reminders ON tasks(remind_at)
+    WHERE remind_at IS NOT NULL AND reminder_sent_at IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,8 @@
+-- Drop columns
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_user_id_focus_date;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS focus_date;
+ALTER TABLE tasks DROP COLUMN IF EXISTS snoozed_until;
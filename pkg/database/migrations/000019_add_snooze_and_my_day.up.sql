@@ -0,0 +1,10 @@
+-- Snoozed tasks are hidden from the task lists until snoozed_until.
+ALTER TABLE tasks ADD COLUMN snoozed_until TIMESTAMP WITH TIME ZONE;
+
+-- focus_date is the day, in the user's time zone, whose "My Day" list the task is pinned to.
+ALTER TABLE tasks ADD COLUMN focus_date DATE;
+
+CREATE INDEX idx_tasks_user_id_focus_date ON tasks(user_id, focus_date);
+
+-- IANA time zone name such as 'Asia/Jakarta'.
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,8 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_task_templates_updated_at ON task_templates;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_task_templates_user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS task_templates;
@@ -0,0 +1,22 @@
+CREATE TABLE task_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    status VARCHAR(50) NOT NULL DEFAULT 'TO_DO',
+    priority task_priority NOT NULL DEFAULT 'MEDIUM',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (status) REFERENCES task_statuses(name) ON UPDATE CASCADE,
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX idx_task_templates_user_id ON task_templates(user_id);
+
+-- Add trigger to update updated_at
+CREATE TRIGGER update_task_templates_updated_at
+    BEFORE UPDATE ON task_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,2 @@
+-- Drop columns
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_all_day;
@@ -0,0 +1,3 @@
+-- An all-day task is due on a day rather than at a time; due_at is then the
+-- last second of that day in the user's time zone.
+ALTER TABLE tasks ADD COLUMN due_all_day BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,8 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_task_members_updated_at ON task_members;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_task_members_user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS task_members;
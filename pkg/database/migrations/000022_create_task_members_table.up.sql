@@ -0,0 +1,22 @@
+-- Users a task is shared with. The owner (tasks.user_id) is never a member.
+CREATE TABLE task_members (
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('EDITOR', 'VIEWER')),
+    invited_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, user_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (invited_by) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- Access checks and task lists look members up by user.
+CREATE INDEX idx_task_members_user_id ON task_members(user_id, task_id);
+
+-- Add trigger to update updated_at
+CREATE TRIGGER update_task_members_updated_at
+    BEFORE UPDATE ON task_members
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
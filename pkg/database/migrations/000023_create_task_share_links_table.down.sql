@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_task_share_links_task_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS task_share_links;
@@ -0,0 +1,14 @@
+-- Read-only links to a task for people without an account. Only a SHA-256
+-- hash of the token is stored; the token itself is shown once on creation.
+CREATE TABLE task_share_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_by UUID NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_share_links_task_id ON task_share_links(task_id);
@@ -0,0 +1,15 @@
+-- Drop triggers
+DROP TRIGGER IF EXISTS update_workspace_members_updated_at ON workspace_members;
+DROP TRIGGER IF EXISTS update_workspaces_updated_at ON workspaces;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_workspace_id;
+DROP INDEX IF EXISTS idx_workspace_members_user_id;
+DROP INDEX IF EXISTS idx_workspaces_personal;
+
+-- Drop columns
+ALTER TABLE tasks DROP COLUMN IF EXISTS workspace_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
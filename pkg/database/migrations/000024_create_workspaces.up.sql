@@ -0,0 +1,54 @@
+CREATE TABLE workspaces (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    personal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- Every user has exactly one personal workspace.
+CREATE UNIQUE INDEX idx_workspaces_personal ON workspaces(created_by) WHERE personal;
+
+CREATE TABLE workspace_members (
+    workspace_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('OWNER', 'ADMIN', 'MEMBER')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workspace_id, user_id),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- Access checks look memberships up by user.
+CREATE INDEX idx_workspace_members_user_id ON workspace_members(user_id, workspace_id);
+
+-- Move every existing user and their tasks into a personal workspace.
+INSERT INTO workspaces (name, personal, created_by)
+SELECT 'Personal', TRUE, id FROM users;
+
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT id, created_by, 'OWNER' FROM workspaces WHERE personal;
+
+ALTER TABLE tasks ADD COLUMN workspace_id UUID REFERENCES workspaces(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+UPDATE tasks SET workspace_id = workspaces.id
+FROM workspaces
+WHERE workspaces.personal AND workspaces.created_by = tasks.user_id;
+
+ALTER TABLE tasks ALTER COLUMN workspace_id SET NOT NULL;
+
+CREATE INDEX idx_tasks_workspace_id ON tasks(workspace_id);
+
+-- Add triggers to update updated_at
+CREATE TRIGGER update_workspaces_updated_at
+    BEFORE UPDATE ON workspaces
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_workspace_members_updated_at
+    BEFORE UPDATE ON workspace_members
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,12 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_projects_updated_at ON projects;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_project_id;
+DROP INDEX IF EXISTS idx_projects_user_id;
+
+-- Drop columns
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,26 @@
+CREATE TABLE projects (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '#808080',
+    archived_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    UNIQUE (workspace_id, user_id, name)
+);
+
+CREATE INDEX idx_projects_user_id ON projects(user_id, workspace_id);
+
+-- Deleting a project keeps its tasks, outside of any project.
+ALTER TABLE tasks ADD COLUMN project_id UUID REFERENCES projects(id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_project_id ON tasks(project_id);
+
+-- Add trigger to update updated_at
+CREATE TRIGGER update_projects_updated_at
+    BEFORE UPDATE ON projects
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
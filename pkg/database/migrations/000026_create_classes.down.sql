@@ -0,0 +1,17 @@
+-- Drop triggers
+DROP TRIGGER IF EXISTS update_class_assignments_updated_at ON class_assignments;
+DROP TRIGGER IF EXISTS update_classes_updated_at ON classes;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_assignment_id;
+DROP INDEX IF EXISTS idx_class_assignments_class_id;
+DROP INDEX IF EXISTS idx_class_students_user_id;
+DROP INDEX IF EXISTS idx_classes_teacher_id;
+
+-- Drop columns
+ALTER TABLE tasks DROP COLUMN IF EXISTS assignment_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS class_assignments;
+DROP TABLE IF EXISTS class_students;
+DROP TABLE IF EXISTS classes;
@@ -0,0 +1,52 @@
+CREATE TABLE classes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    teacher_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (teacher_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_classes_teacher_id ON classes(teacher_id);
+
+CREATE TABLE class_students (
+    class_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (class_id, user_id),
+    FOREIGN KEY (class_id) REFERENCES classes(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_class_students_user_id ON class_students(user_id);
+
+CREATE TABLE class_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    class_id UUID NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    priority task_priority NOT NULL DEFAULT 'MEDIUM',
+    due_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (class_id) REFERENCES classes(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_class_assignments_class_id ON class_assignments(class_id);
+
+-- Every student gets their own task for an assignment. Deleting the
+-- assignment keeps the students' tasks.
+ALTER TABLE tasks ADD COLUMN assignment_id UUID REFERENCES class_assignments(id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_assignment_id ON tasks(assignment_id);
+
+-- Add triggers to update updated_at
+CREATE TRIGGER update_classes_updated_at
+    BEFORE UPDATE ON classes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_class_assignments_updated_at
+    BEFORE UPDATE ON class_assignments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tasks_user_id_workspace_id_position;
+
+CREATE INDEX idx_tasks_user_id_position ON tasks(user_id, position);
@@ -0,0 +1,5 @@
+-- Every workspace has its own manual order, so positions are looked up per
+-- user and workspace.
+DROP INDEX IF EXISTS idx_tasks_user_id_position;
+
+CREATE INDEX idx_tasks_user_id_workspace_id_position ON tasks(user_id, workspace_id, position);
@@ -0,0 +1,63 @@
+// Package delayqueue is a delayed job queue on top of a Redis sorted set.
+// Members are job IDs scored by the Unix time in milliseconds at which they
+// are due, so scheduling an existing job again simply moves it.
+package delayqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// popDue removes and returns up to ARGV[2] members due at or before ARGV[1].
+// Running it as a script makes taking a job atomic, so with several
+// consumers every job is handed to exactly one of them.
+var popDue = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+if #due > 0 then
+	redis.call('ZREM', KEYS[1], unpack(due))
+end
+return due
+`)
+
+type Queue struct {
+	client *redis.Client
+	key    string
+}
+
+func New(client *redis.Client, key string) *Queue {
+	return &Queue{
+		client: client,
+		key:    key,
+	}
+}
+
+// Schedule makes the job due at the given time, replacing any earlier
+// schedule of the same job.
+func (q *Queue) Schedule(ctx context.Context, id string, at time.Time) error {
+	err := q.client.ZAdd(ctx, q.key, redis.Z{Score: float64(at.UnixMilli()), Member: id}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to schedule job: %w", err)
+	}
+	return nil
+}
+
+// Cancel removes the job from the queue. Cancelling a job that is not queued
+// is not an error.
+func (q *Queue) Cancel(ctx context.Context, id string) error {
+	if err := q.client.ZRem(ctx, q.key, id).Err(); err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+	return nil
+}
+
+// PopDue takes up to limit jobs that are due at now, oldest first.
+func (q *Queue) PopDue(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	ids, err := popDue.Run(ctx, q.client, []string{q.key}, now.UnixMilli(), limit).StringSlice()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to pop due jobs: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type EmailOptions struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// EmailNotifier sends plain text emails through an SMTP server. STARTTLS is
+// used whenever the server offers it.
+type EmailNotifier struct {
+	opts EmailOptions
+}
+
+func NewEmailNotifier(opts EmailOptions) (*EmailNotifier, error) {
+	if opts.Host == "" {
+		return nil, fmt.Errorf("SMTP host is required")
+	}
+	if opts.From == "" {
+		return nil, fmt.Errorf("sender address is required")
+	}
+	if opts.Port == "" {
+		opts.Port = "587"
+	}
+
+	return &EmailNotifier{opts: opts}, nil
+}
+
+func (n *EmailNotifier) Send(ctx context.Context, msg Message) error {
+	if msg.To == "" {
+		return fmt.Errorf("email recipient is required")
+	}
+
+	var auth smtp.Auth
+	if n.opts.Username != "" {
+		auth = smtp.PlainAuth("", n.opts.Username, n.opts.Password, n.opts.Host)
+	}
+
+	body := strings.Join([]string{
+		"From: " + n.opts.From,
+		"To: " + msg.To,
+		"Subject: " + sanitizeHeader(msg.Subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		msg.Body,
+	}, "\r\n")
+
+	// net/smtp has no context support, so the deadline is only checked up front.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(n.opts.Host, n.opts.Port)
+	if err := smtp.SendMail(addr, auth, n.opts.From, []string{msg.To}, []byte(body)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// sanitizeHeader keeps user-provided text such as task titles from adding
+// headers of its own.
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
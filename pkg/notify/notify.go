@@ -0,0 +1,18 @@
+// Package notify delivers messages to users over external channels such as
+// email or webhooks.
+package notify
+
+import "context"
+
+// Message is a notification for a single user. Channels use the parts they
+// understand: email sends Subject and Body to To, webhooks post Data.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	Data    map[string]interface{}
+}
+
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,431 @@
+// Package quickadd parses one-line task descriptions such as
+// "Review essay tomorrow 5pm #writing !high" into a title, a due date,
+// labels and a priority.
+//
+// Dates and times are resolved against a reference time whose location is
+// the user's time zone, so the result depends only on the text and that
+// time. Recognised words are removed from the title; everything else is kept
+// in its original order. Short forms that are common words (e.g. "sat" and
+// "sun") are not recognised as weekdays.
+package quickadd
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	PriorityLow    = "LOW"
+	PriorityMedium = "MEDIUM"
+	PriorityHigh   = "HIGH"
+	PriorityUrgent = "URGENT"
+)
+
+// MaxLabelLength mirrors the size of label names.
+const MaxLabelLength = 50
+
+// tonightHour is the time used for "tonight" when no time is given.
+const tonightHour = 20
+
+var ErrEmptyTitle = errors.New("the text does not contain a title")
+
+// Result is the parsed form of a quick-add text.
+type Result struct {
+	Title string
+	// DueAt is nil when the text names no date or time. When only a day is
+	// given, AllDay is set and DueAt is the last second of that day.
+	DueAt  *time.Time
+	AllDay bool
+	// Labels holds the #hashtags without "#", in order and without
+	// case-insensitive duplicates.
+	Labels []string
+	// Priority is one of the Priority constants, or "" without a marker.
+	Priority string
+}
+
+var priorityMarkers = map[string]string{
+	"low":    PriorityLow,
+	"medium": PriorityMedium,
+	"med":    PriorityMedium,
+	"high":   PriorityHigh,
+	"urgent": PriorityUrgent,
+}
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday,
+	"sunday":   time.Sunday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+var (
+	labelPattern    = regexp.MustCompile(`^#([\pL\pN_-]+)$`)
+	isoDatePattern  = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	dayPattern      = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+	clock12Pattern  = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)$`)
+	clock24Pattern  = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+	meridiemPattern = regexp.MustCompile(`^(am|pm)$`)
+	numberPattern   = regexp.MustCompile(`^\d{1,3}$`)
+)
+
+// date is a calendar day, optionally with a time that applies unless the
+// text names one explicitly.
+type date struct {
+	year        int
+	month       time.Month
+	day         int
+	defaultHour *int
+}
+
+type clock struct {
+	hour, minute int
+}
+
+// parser walks the words of one text.
+type parser struct {
+	now    time.Time
+	words  []string
+	result Result
+
+	date    *date
+	clock   *clock
+	instant *time.Time // "in 2 hours" names an exact time
+}
+
+// Parse splits text into its parts relative to now.
+func Parse(text string, now time.Time) (*Result, error) {
+	p := &parser{now: now, words: strings.Fields(text)}
+
+	var title []string
+	for i := 0; i < len(p.words); {
+		if n := p.match(i); n > 0 {
+			i += n
+			continue
+		}
+		title = append(title, p.words[i])
+		i++
+	}
+
+	p.result.Title = strings.Join(title, " ")
+	if p.result.Title == "" {
+		return nil, ErrEmptyTitle
+	}
+	p.resolveDue()
+	return &p.result, nil
+}
+
+// match consumes the marker, date or time starting at word i and returns the
+// number of words it used, or 0 if the word belongs to the title.
+func (p *parser) match(i int) int {
+	word := p.words[i]
+
+	if m := labelPattern.FindStringSubmatch(trimPunctuation(word)); m != nil && utf8.RuneCountInString(m[1]) <= MaxLabelLength {
+		p.addLabel(m[1])
+		return 1
+	}
+	if strings.HasPrefix(word, "!") {
+		if priority, ok := priorityMarkers[strings.ToLower(trimPunctuation(word[1:]))]; ok {
+			p.result.Priority = priority
+			return 1
+		}
+		return 0
+	}
+
+	if p.date == nil && p.instant == nil {
+		if n := p.matchDate(i); n > 0 {
+			return n
+		}
+		// "on friday", "by tomorrow", "due oct 20"
+		if isAny(p.lower(i), "on", "by", "due") {
+			if n := p.matchDate(i + 1); n > 0 {
+				return n + 1
+			}
+		}
+	}
+	if p.clock == nil && p.instant == nil {
+		if n := p.matchClock(i); n > 0 {
+			return n
+		}
+		if p.lower(i) == "at" {
+			if n := p.matchClock(i + 1); n > 0 {
+				return n + 1
+			}
+		}
+	}
+	return 0
+}
+
+func (p *parser) matchDate(i int) int {
+	word := p.lower(i)
+	today := p.now
+	switch {
+	case word == "":
+		return 0
+	case word == "today":
+		p.setDate(today, nil)
+		return 1
+	case word == "tonight":
+		hour := tonightHour
+		p.setDate(today, &hour)
+		return 1
+	case isAny(word, "tomorrow", "tmr", "tmrw"):
+		p.setDate(today.AddDate(0, 0, 1), nil)
+		return 1
+	case word == "next" && p.lower(i+1) == "week":
+		// Monday of the following week.
+		p.setDate(today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7), nil)
+		return 2
+	case word == "next" && p.lower(i+1) == "month":
+		p.date = &date{year: today.Year(), month: today.Month() + 1, day: 1}
+		return 2
+	case word == "next":
+		// "next friday" is the coming Friday.
+		if _, ok := weekdays[p.lower(i+1)]; ok {
+			return 1 + p.matchDate(i+1)
+		}
+		return 0
+	case word == "in":
+		return p.matchOffset(i)
+	}
+
+	if weekday, ok := weekdays[word]; ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		p.setDate(today.AddDate(0, 0, days), nil)
+		return 1
+	}
+
+	if m := isoDatePattern.FindStringSubmatch(word); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		if !validDay(year, time.Month(month), day) {
+			return 0
+		}
+		p.date = &date{year: year, month: time.Month(month), day: day}
+		return 1
+	}
+
+	// "oct 20" or "20 oct"
+	if month, ok := months[word]; ok {
+		if day, ok := p.dayOfMonth(i + 1); ok {
+			return p.setMonthDay(month, day)
+		}
+	}
+	if day, ok := p.dayOfMonth(i); ok {
+		if month, ok := months[p.lower(i+1)]; ok {
+			return p.setMonthDay(month, day)
+		}
+	}
+	return 0
+}
+
+// matchOffset handles "in 3 days", "in 2 weeks", "in 1 month" and "in 2
+// hours" / "in 30 minutes", starting at the word "in".
+func (p *parser) matchOffset(i int) int {
+	if !numberPattern.MatchString(p.lower(i + 1)) {
+		return 0
+	}
+	n, _ := strconv.Atoi(p.lower(i + 1))
+
+	switch strings.TrimSuffix(p.lower(i+2), "s") {
+	case "day":
+		p.setDate(p.now.AddDate(0, 0, n), nil)
+	case "week":
+		p.setDate(p.now.AddDate(0, 0, 7*n), nil)
+	case "month":
+		p.setDate(p.now.AddDate(0, n, 0), nil)
+	case "hour":
+		if p.clock != nil {
+			return 0
+		}
+		instant := p.now.Add(time.Duration(n) * time.Hour)
+		p.instant = &instant
+	case "minute", "min":
+		if p.clock != nil {
+			return 0
+		}
+		instant := p.now.Add(time.Duration(n) * time.Minute)
+		p.instant = &instant
+	default:
+		return 0
+	}
+	return 3
+}
+
+func (p *parser) matchClock(i int) int {
+	word := p.lower(i)
+	if word == "noon" {
+		p.clock = &clock{hour: 12}
+		return 1
+	}
+
+	// "5pm", "5:30pm"
+	if m := clock12Pattern.FindStringSubmatch(word); m != nil {
+		if c, ok := twelveHourClock(m[1], m[2], m[3]); ok {
+			p.clock = c
+			return 1
+		}
+		return 0
+	}
+	// "5 pm", "5:30 pm"
+	if m := meridiemPattern.FindStringSubmatch(p.lower(i + 1)); m != nil {
+		hour, minute, _ := strings.Cut(word, ":")
+		if c, ok := twelveHourClock(hour, minute, m[1]); ok {
+			p.clock = c
+			return 2
+		}
+	}
+	// "17:00"
+	if m := clock24Pattern.FindStringSubmatch(word); m != nil {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		if hour > 23 || minute > 59 {
+			return 0
+		}
+		p.clock = &clock{hour: hour, minute: minute}
+		return 1
+	}
+	return 0
+}
+
+func twelveHourClock(hourText, minuteText, meridiem string) (*clock, bool) {
+	hour, err := strconv.Atoi(hourText)
+	if err != nil || hour < 1 || hour > 12 {
+		return nil, false
+	}
+	minute := 0
+	if minuteText != "" {
+		if minute, err = strconv.Atoi(minuteText); err != nil || len(minuteText) != 2 || minute > 59 {
+			return nil, false
+		}
+	}
+
+	hour %= 12
+	if meridiem == "pm" {
+		hour += 12
+	}
+	return &clock{hour: hour, minute: minute}, true
+}
+
+// dayOfMonth reads a day number such as "20" or "20th".
+func (p *parser) dayOfMonth(i int) (int, bool) {
+	m := dayPattern.FindStringSubmatch(p.lower(i))
+	if m == nil {
+		return 0, false
+	}
+	day, _ := strconv.Atoi(m[1])
+	return day, day >= 1 && day <= 31
+}
+
+// setMonthDay picks the next occurrence of the day, today included.
+func (p *parser) setMonthDay(month time.Month, day int) int {
+	year := p.now.Year()
+	if month < p.now.Month() || (month == p.now.Month() && day < p.now.Day()) {
+		year++
+	}
+	if !validDay(year, month, day) {
+		return 0
+	}
+	p.date = &date{year: year, month: month, day: day}
+	return 2
+}
+
+func (p *parser) setDate(t time.Time, defaultHour *int) {
+	p.date = &date{year: t.Year(), month: t.Month(), day: t.Day(), defaultHour: defaultHour}
+}
+
+func (p *parser) addLabel(name string) {
+	for _, label := range p.result.Labels {
+		if strings.EqualFold(label, name) {
+			return
+		}
+	}
+	p.result.Labels = append(p.result.Labels, name)
+}
+
+// resolveDue combines the date and time found in the text into DueAt.
+func (p *parser) resolveDue() {
+	loc := p.now.Location()
+
+	switch {
+	case p.instant != nil:
+		p.result.DueAt = p.instant
+
+	case p.date != nil && p.clock == nil && p.date.defaultHour == nil:
+		// The last second of the day, so the task is not overdue before
+		// the day is over.
+		due := time.Date(p.date.year, p.date.month, p.date.day+1, 0, 0, 0, 0, loc).Add(-time.Second)
+		p.result.DueAt = &due
+		p.result.AllDay = true
+
+	case p.date != nil:
+		c := p.clock
+		if c == nil {
+			c = &clock{hour: *p.date.defaultHour}
+		}
+		due := time.Date(p.date.year, p.date.month, p.date.day, c.hour, c.minute, 0, 0, loc)
+		p.result.DueAt = &due
+
+	case p.clock != nil:
+		// A time alone means its next occurrence.
+		due := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), p.clock.hour, p.clock.minute, 0, 0, loc)
+		if !due.After(p.now) {
+			due = time.Date(p.now.Year(), p.now.Month(), p.now.Day()+1, p.clock.hour, p.clock.minute, 0, 0, loc)
+		}
+		p.result.DueAt = &due
+	}
+}
+
+// lower returns word i lowercased and without trailing punctuation, or ""
+// past the end.
+func (p *parser) lower(i int) string {
+	if i >= len(p.words) {
+		return ""
+	}
+	return strings.ToLower(trimPunctuation(p.words[i]))
+}
+
+func trimPunctuation(word string) string {
+	return strings.TrimRight(word, ",.;")
+}
+
+func isAny(word string, candidates ...string) bool {
+	for _, candidate := range candidates {
+		if word == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func validDay(year int, month time.Month, day int) bool {
+	if month < time.January || month > time.December || day < 1 {
+		return false
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Day() == day
+}
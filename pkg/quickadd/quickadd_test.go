@@ -0,0 +1,229 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var jakarta = time.FixedZone("WIB", 7*60*60)
+
+// now is Friday, 16 October 2026, 10:00 in Jakarta.
+var now = time.Date(2026, time.October, 16, 10, 0, 0, 0, jakarta)
+
+func at(year int, month time.Month, day, hour, minute int) *time.Time {
+	t := time.Date(year, month, day, hour, minute, 0, 0, jakarta)
+	return &t
+}
+
+func endOfDay(year int, month time.Month, day int) *time.Time {
+	t := time.Date(year, month, day, 23, 59, 59, 0, jakarta)
+	return &t
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Result
+	}{
+		{
+			name: "example from the API docs",
+			text: "Review essay tomorrow 5pm #writing !high",
+			want: Result{Title: "Review essay", DueAt: at(2026, time.October, 17, 17, 0), Labels: []string{"writing"}, Priority: PriorityHigh},
+		},
+		{
+			name: "plain title",
+			text: "  Read chapter   three ",
+			want: Result{Title: "Read chapter three"},
+		},
+		{
+			name: "today is all day",
+			text: "Call parents today",
+			want: Result{Title: "Call parents", DueAt: endOfDay(2026, time.October, 16), AllDay: true},
+		},
+		{
+			name: "tonight defaults to the evening",
+			text: "Prepare slides tonight",
+			want: Result{Title: "Prepare slides", DueAt: at(2026, time.October, 16, 20, 0)},
+		},
+		{
+			name: "tonight with a time",
+			text: "Prepare slides tonight at 9:30pm",
+			want: Result{Title: "Prepare slides", DueAt: at(2026, time.October, 16, 21, 30)},
+		},
+		{
+			name: "weekday is the next one",
+			text: "Quiz monday",
+			want: Result{Title: "Quiz", DueAt: endOfDay(2026, time.October, 19), AllDay: true},
+		},
+		{
+			name: "same weekday means next week",
+			text: "Quiz on friday",
+			want: Result{Title: "Quiz", DueAt: endOfDay(2026, time.October, 23), AllDay: true},
+		},
+		{
+			name: "next weekday",
+			text: "Quiz next wed 08:15",
+			want: Result{Title: "Quiz", DueAt: at(2026, time.October, 21, 8, 15)},
+		},
+		{
+			name: "next week starts on monday",
+			text: "Plan lessons next week",
+			want: Result{Title: "Plan lessons", DueAt: endOfDay(2026, time.October, 19), AllDay: true},
+		},
+		{
+			name: "next month",
+			text: "Renew library card next month",
+			want: Result{Title: "Renew library card", DueAt: endOfDay(2026, time.November, 1), AllDay: true},
+		},
+		{
+			name: "in days",
+			text: "Submit report in 3 days",
+			want: Result{Title: "Submit report", DueAt: endOfDay(2026, time.October, 19), AllDay: true},
+		},
+		{
+			name: "in weeks with a time",
+			text: "Exam in 2 weeks at 9am",
+			want: Result{Title: "Exam", DueAt: at(2026, time.October, 30, 9, 0)},
+		},
+		{
+			name: "in hours is exact",
+			text: "Check oven in 2 hours",
+			want: Result{Title: "Check oven", DueAt: at(2026, time.October, 16, 12, 0)},
+		},
+		{
+			name: "in minutes is exact",
+			text: "Stretch in 45 mins",
+			want: Result{Title: "Stretch", DueAt: at(2026, time.October, 16, 10, 45)},
+		},
+		{
+			name: "iso date",
+			text: "Hand in thesis 2027-01-15 #uni",
+			want: Result{Title: "Hand in thesis", DueAt: endOfDay(2027, time.January, 15), AllDay: true, Labels: []string{"uni"}},
+		},
+		{
+			name: "month and day",
+			text: "Dentist due oct 20th, 14:30",
+			want: Result{Title: "Dentist", DueAt: at(2026, time.October, 20, 14, 30)},
+		},
+		{
+			name: "day and month that already passed",
+			text: "Birthday 3 march",
+			want: Result{Title: "Birthday", DueAt: endOfDay(2027, time.March, 3), AllDay: true},
+		},
+		{
+			name: "later time alone is today",
+			text: "Standup 11am",
+			want: Result{Title: "Standup", DueAt: at(2026, time.October, 16, 11, 0)},
+		},
+		{
+			name: "earlier time alone is tomorrow",
+			text: "Standup 9 am",
+			want: Result{Title: "Standup", DueAt: at(2026, time.October, 17, 9, 0)},
+		},
+		{
+			name: "noon",
+			text: "Lunch with Sam at noon",
+			want: Result{Title: "Lunch with Sam", DueAt: at(2026, time.October, 16, 12, 0)},
+		},
+		{
+			name: "twelve am is midnight",
+			text: "Backup tomorrow 12am",
+			want: Result{Title: "Backup", DueAt: at(2026, time.October, 17, 0, 0)},
+		},
+		{
+			name: "only the first date is used",
+			text: "Move monday meeting to tuesday",
+			want: Result{Title: "Move meeting to tuesday", DueAt: endOfDay(2026, time.October, 19), AllDay: true},
+		},
+		{
+			name: "labels are deduplicated case-insensitively",
+			text: "Vocab #English #grammar #english",
+			want: Result{Title: "Vocab", Labels: []string{"English", "grammar"}},
+		},
+		{
+			name: "last priority wins",
+			text: "Fix bug !low !URGENT",
+			want: Result{Title: "Fix bug", Priority: PriorityUrgent},
+		},
+		{
+			name: "medium alias",
+			text: "Tidy desk !med",
+			want: Result{Title: "Tidy desk", Priority: PriorityMedium},
+		},
+		{
+			name: "unknown markers stay in the title",
+			text: "Say hello! !wow # #",
+			want: Result{Title: "Say hello! !wow # #"},
+		},
+		{
+			name: "words that only look like dates stay in the title",
+			text: "SAT prep at home in 3 parts",
+			want: Result{Title: "SAT prep at home in 3 parts"},
+		},
+		{
+			name: "invalid dates and times stay in the title",
+			text: "Party 2026-02-30 13pm 25:00 feb 30",
+			want: Result{Title: "Party 2026-02-30 13pm 25:00 feb 30"},
+		},
+		{
+			name: "preposition without a date stays in the title",
+			text: "Read on the bus",
+			want: Result{Title: "Read on the bus"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Title, got.Title)
+			assert.Equal(t, tt.want.AllDay, got.AllDay)
+			assert.Equal(t, tt.want.Labels, got.Labels)
+			assert.Equal(t, tt.want.Priority, got.Priority)
+			if tt.want.DueAt == nil {
+				assert.Nil(t, got.DueAt)
+			} else if assert.NotNil(t, got.DueAt) {
+				assert.True(t, tt.want.DueAt.Equal(*got.DueAt), "want due %s, got %s", tt.want.DueAt, got.DueAt)
+			}
+		})
+	}
+}
+
+func TestParseEmptyTitle(t *testing.T) {
+	for _, text := range []string{"", "   ", "tomorrow 5pm #writing !high"} {
+		_, err := Parse(text, now)
+		assert.ErrorIs(t, err, ErrEmptyTitle, text)
+	}
+}
+
+func TestParseUsesLocationOfReferenceTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// The day before the end of daylight saving time.
+	reference := time.Date(2026, time.October, 31, 22, 0, 0, 0, newYork)
+	got, err := Parse("Essay tomorrow 9am", reference)
+	require.NoError(t, err)
+
+	want := time.Date(2026, time.November, 1, 9, 0, 0, 0, newYork)
+	assert.True(t, want.Equal(*got.DueAt))
+	assert.Equal(t, "EST", got.DueAt.Format("MST"))
+
+	// The same instant is already the next day in Jakarta.
+	got, err = Parse("Essay tomorrow 9am", reference.In(jakarta))
+	require.NoError(t, err)
+	assert.True(t, at(2026, time.November, 2, 9, 0).Equal(*got.DueAt))
+}
+
+func TestParseIsDeterministic(t *testing.T) {
+	first, err := Parse("Review essay tomorrow 5pm #writing !high", now)
+	require.NoError(t, err)
+	second, err := Parse("Review essay tomorrow 5pm #writing !high", now)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
@@ -0,0 +1,243 @@
+// Package rank generates lexicographically ordered keys for manual ordering.
+//
+// A key can always be generated between any two existing keys, so moving an
+// item only ever rewrites the key of that item. Keys consist of an integer
+// part, whose first character encodes its length, followed by an optional
+// fractional part. Appending or prepending increments or decrements the
+// integer part and therefore keeps keys short; inserting between two
+// neighbours extends the fractional part.
+//
+// Keys compare correctly with plain byte-wise comparison, so database
+// columns holding them must use a binary collation (COLLATE "C" in Postgres).
+package rank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// smallestInteger is the lowest integer part; nothing can be placed before it
+// without a fractional part.
+const smallestInteger = "A" + "00000000000000000000000000"
+
+// First is the key given to the first item of an empty list.
+const First = "a0"
+
+var (
+	ErrInvalidKey = errors.New("invalid rank key")
+	ErrOrder      = errors.New("rank keys out of order")
+	ErrExhausted  = errors.New("rank key space exhausted")
+)
+
+// Between returns a key that sorts strictly after a and strictly before b.
+// An empty a means "before everything", an empty b means "after everything".
+func Between(a, b string) (string, error) {
+	if a != "" {
+		if err := Validate(a); err != nil {
+			return "", err
+		}
+	}
+	if b != "" {
+		if err := Validate(b); err != nil {
+			return "", err
+		}
+	}
+	if a != "" && b != "" && a >= b {
+		return "", fmt.Errorf("%w: %q >= %q", ErrOrder, a, b)
+	}
+
+	if a == "" {
+		if b == "" {
+			return First, nil
+		}
+		ib := integerPart(b)
+		fb := b[len(ib):]
+		if ib == smallestInteger {
+			return ib + midpoint("", fb), nil
+		}
+		if ib < b {
+			return ib, nil
+		}
+		res, ok := decrementInteger(ib)
+		if !ok {
+			return "", ErrExhausted
+		}
+		if res == smallestInteger {
+			// The smallest integer is not a key on its own.
+			return res + midpoint("", ""), nil
+		}
+		return res, nil
+	}
+
+	ia := integerPart(a)
+	fa := a[len(ia):]
+
+	if b == "" {
+		res, ok := incrementInteger(ia)
+		if !ok {
+			return ia + midpoint(fa, ""), nil
+		}
+		return res, nil
+	}
+
+	ib := integerPart(b)
+	fb := b[len(ib):]
+	if ia == ib {
+		return ia + midpoint(fa, fb), nil
+	}
+	res, ok := incrementInteger(ia)
+	if !ok {
+		return "", ErrExhausted
+	}
+	if res < b {
+		return res, nil
+	}
+	return ia + midpoint(fa, ""), nil
+}
+
+// Validate reports whether key is a well-formed rank key.
+func Validate(key string) error {
+	if key == "" || key == smallestInteger {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	n := integerLength(key[0])
+	if n == 0 || len(key) < n {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for i := 1; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	if len(key) > n && key[len(key)-1] == digits[0] {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
+
+// midpoint returns a fractional part between a and b, where an empty b
+// stands for the end of the range. Neither may end with the zero digit.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix and look for a midpoint after it.
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(suffix(a, n), b[n:])
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(digits, a[0])
+	}
+	digitB := len(digits)
+	if b != "" {
+		digitB = strings.IndexByte(digits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(digits[(digitA+digitB+1)/2])
+	}
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[digitA]) + midpoint(suffix(a, 1), "")
+}
+
+// integerLength returns the length of the integer part announced by its
+// first character, or 0 when head is not a valid head character.
+func integerLength(head byte) int {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2
+	}
+	return 0
+}
+
+func integerPart(key string) string {
+	return key[:integerLength(key[0])]
+}
+
+func incrementInteger(x string) (string, bool) {
+	head, digs := x[0], []byte(x[1:])
+	carry := true
+	for i := len(digs) - 1; carry && i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) + 1
+		if d == len(digits) {
+			digs[i] = digits[0]
+		} else {
+			digs[i] = digits[d]
+			carry = false
+		}
+	}
+	if !carry {
+		return string(head) + string(digs), true
+	}
+
+	switch head {
+	case 'Z':
+		return "a" + string(digits[0]), true
+	case 'z':
+		return "", false
+	}
+	head++
+	if head > 'a' {
+		digs = append(digs, digits[0])
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(head) + string(digs), true
+}
+
+func decrementInteger(x string) (string, bool) {
+	head, digs := x[0], []byte(x[1:])
+	borrow := true
+	for i := len(digs) - 1; borrow && i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) - 1
+		if d == -1 {
+			digs[i] = digits[len(digits)-1]
+		} else {
+			digs[i] = digits[d]
+			borrow = false
+		}
+	}
+	if !borrow {
+		return string(head) + string(digs), true
+	}
+
+	switch head {
+	case 'a':
+		return "Z" + string(digits[len(digits)-1]), true
+	case 'A':
+		return "", false
+	}
+	head--
+	if head < 'Z' {
+		digs = append(digs, digits[len(digits)-1])
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(head) + string(digs), true
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return digits[0]
+}
+
+func suffix(s string, n int) string {
+	if n >= len(s) {
+		return ""
+	}
+	return s[n:]
+}
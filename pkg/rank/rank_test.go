@@ -0,0 +1,123 @@
+package rank
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// largestInteger is the highest integer part; nothing can be placed after it
+// without a fractional part.
+var largestInteger = "z" + strings.Repeat("z", 26)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{name: "empty list", want: First},
+		{name: "append", a: "a0", want: "a1"},
+		{name: "prepend", b: "a0", want: "Zz"},
+		{name: "append carries into a longer integer", a: "az", want: "b00"},
+		{name: "append carries into the positive integers", a: "Zz", want: "a0"},
+		{name: "adjacent integers need a fraction", a: "a0", b: "a1", want: "a0V"},
+		{name: "between a key and its fraction", a: "a0", b: "a0V", want: "a0G"},
+		{name: "adjacent fractions need a longer fraction", a: "a0", b: "a01", want: "a00V"},
+		{name: "after a fraction", a: "a0V", b: "a1", want: "a0l"},
+		{name: "before the smallest integer", b: smallestInteger + "V", want: smallestInteger + "G"},
+		{name: "before the integer after the smallest", b: smallestInteger[:26] + "1", want: smallestInteger + "V"},
+		{name: "after the largest integer", a: largestInteger, want: largestInteger + "V"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.a, tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, Validate(got))
+			if tt.a != "" {
+				assert.Greater(t, got, tt.a)
+			}
+			if tt.b != "" {
+				assert.Less(t, got, tt.b)
+			}
+		})
+	}
+}
+
+func TestBetweenRejectsInvalidBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want error
+	}{
+		{name: "equal keys", a: "a1", b: "a1", want: ErrOrder},
+		{name: "reversed keys", a: "a2", b: "a1", want: ErrOrder},
+		{name: "unknown head", a: "!0", want: ErrInvalidKey},
+		{name: "integer part too short", a: "b0", want: ErrInvalidKey},
+		{name: "trailing zero digit", b: "a10", want: ErrInvalidKey},
+		{name: "smallest integer alone", b: smallestInteger, want: ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Between(tt.a, tt.b)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestRepeatedInsertsKeepTheOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		insert func(keys []string) (int, string, string)
+	}{
+		{
+			name: "always at the end",
+			insert: func(keys []string) (int, string, string) {
+				return len(keys), keys[len(keys)-1], ""
+			},
+		},
+		{
+			name: "always at the start",
+			insert: func(keys []string) (int, string, string) {
+				return 0, "", keys[0]
+			},
+		},
+		{
+			name: "always right after the first key",
+			insert: func(keys []string) (int, string, string) {
+				return 1, keys[0], keys[1]
+			},
+		},
+		{
+			name: "always right before the last key",
+			insert: func(keys []string) (int, string, string) {
+				return len(keys) - 1, keys[len(keys)-2], keys[len(keys)-1]
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := []string{"a0", "a1"}
+			for i := 0; i < 500; i++ {
+				at, a, b := tt.insert(keys)
+				key, err := Between(a, b)
+				require.NoError(t, err, "insert %d", i)
+				require.NoError(t, Validate(key))
+
+				keys = append(keys[:at], append([]string{key}, keys[at:]...)...)
+			}
+
+			assert.True(t, sort.StringsAreSorted(keys))
+			for i := 1; i < len(keys); i++ {
+				assert.NotEqual(t, keys[i-1], keys[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules used for
+// recurring tasks: FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY,
+// BYMONTHDAY, COUNT and UNTIL. Weeks start on Monday.
+//
+// The start of a series (DTSTART) is always its first occurrence, numbered
+// 0, even when it does not match BYDAY. Occurrences keep the wall-clock time
+// of DTSTART in its location, so a series should be evaluated in the time
+// zone of its owner for daylight saving time to be handled correctly.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// maxPeriods bounds how many days, weeks or months are scanned for the next
+// occurrence, so that a rule which can never match does not loop forever.
+const maxPeriods = 10000
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// WeekdayNum is a BYDAY entry such as MO, 2TU or -1FR. N is 0 for "every
+// such weekday" and otherwise the position within the month (MONTHLY only).
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+	// UntilDate is set when UNTIL was given as a date. Until then holds
+	// midnight UTC of that date, and the series ends with that day in the
+	// location of DTSTART.
+	UntilDate bool
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+	time.Sunday:    "SU",
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10".
+// An "RRULE:" prefix is accepted.
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := &Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		name, val = strings.TrimSpace(name), strings.TrimSpace(val)
+		if !ok || name == "" || val == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s given more than once", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq = Frequency(val)
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly {
+				err = fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRule, val)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(name, val)
+		case "COUNT":
+			rule.Count, err = parsePositive(name, val)
+		case "UNTIL":
+			var until time.Time
+			until, rule.UntilDate, err = parseUntil(val)
+			rule.Until = &until
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(val)
+		default:
+			err = fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRule)
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly {
+			return nil, fmt.Errorf("%w: numbered BYDAY is only allowed with FREQ=MONTHLY", ErrInvalidRule)
+		}
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != Monthly {
+		return nil, fmt.Errorf("%w: BYMONTHDAY is only allowed with FREQ=MONTHLY", ErrInvalidRule)
+	}
+
+	return rule, nil
+}
+
+func parsePositive(name, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%w: %s must be a positive integer", ErrInvalidRule, name)
+	}
+	return n, nil
+}
+
+// parseUntil reads an UNTIL value and reports whether it is a date. A date
+// has no time zone of its own; see Rule.UntilDate.
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%w: UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ", ErrInvalidRule)
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+		}
+		weekday, ok := weekdayCodes[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+		}
+
+		day := WeekdayNum{Weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+			}
+			day.N = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// parseByMonthDay reads a list of days of the month such as 1,15,-1, where
+// negative days count from the end of the month.
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < -31 || n > 31 {
+			return nil, fmt.Errorf("%w: invalid BYMONTHDAY %q", ErrInvalidRule, item)
+		}
+		days = append(days, n)
+	}
+	return days, nil
+}
+
+// String formats the rule in canonical form.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = weekdayNames[day.Weekday]
+			if day.N != 0 {
+				days[i] = strconv.Itoa(day.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil && r.UntilDate {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	} else if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Occurrence returns the n-th occurrence (0-based) of the series starting at
+// dtstart. ok is false when the series ends before reaching it.
+func (r *Rule) Occurrence(dtstart time.Time, n int) (t time.Time, ok bool) {
+	r.each(dtstart, func(index int, occurrence time.Time) bool {
+		if index == n {
+			t, ok = occurrence, true
+		}
+		return index < n
+	})
+	return t, ok
+}
+
+// After returns the first occurrence numbered at least minIndex that falls
+// strictly after the given time, together with its number. ok is false when
+// the series ends before that.
+func (r *Rule) After(dtstart time.Time, after time.Time, minIndex int) (t time.Time, index int, ok bool) {
+	r.each(dtstart, func(n int, occurrence time.Time) bool {
+		if n >= minIndex && occurrence.After(after) {
+			t, index, ok = occurrence, n, true
+			return false
+		}
+		return true
+	})
+	return t, index, ok
+}
+
+// each calls fn with every occurrence in order until fn returns false or the
+// series ends.
+func (r *Rule) each(dtstart time.Time, fn func(index int, occurrence time.Time) bool) {
+	until := r.until(dtstart.Location())
+	index := 0
+	emit := func(occurrence time.Time) bool {
+		if r.Count > 0 && index >= r.Count {
+			return false
+		}
+		if until != nil && occurrence.After(*until) {
+			return false
+		}
+		more := fn(index, occurrence)
+		index++
+		return more
+	}
+
+	if !emit(dtstart) {
+		return
+	}
+	for period := 0; period < maxPeriods; period++ {
+		for _, candidate := range r.candidates(dtstart, period) {
+			if candidate.After(dtstart) && !emit(candidate) {
+				return
+			}
+		}
+	}
+}
+
+// until returns the last instant of the series, if any. A date-only UNTIL
+// includes the whole day in loc.
+func (r *Rule) until(loc *time.Location) *time.Time {
+	if r.Until == nil || !r.UntilDate {
+		return r.Until
+	}
+	end := time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+	return &end
+}
+
+// candidates returns the sorted instants of the given period (day, week or
+// month counted from dtstart) that match the rule.
+func (r *Rule) candidates(dtstart time.Time, period int) []time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	step := period * interval
+
+	switch r.Freq {
+	case Daily:
+		day := dtstart.AddDate(0, 0, step)
+		if len(r.ByDay) > 0 && !r.matchesWeekday(day.Weekday()) {
+			return nil
+		}
+		return []time.Time{day}
+
+	case Weekly:
+		// Monday of the week that contains dtstart.
+		weekStart := dtstart.AddDate(0, 0, -((int(dtstart.Weekday()) + 6) % 7))
+		weekStart = weekStart.AddDate(0, 0, 7*step)
+		if len(r.ByDay) == 0 {
+			return []time.Time{weekStart.AddDate(0, 0, (int(dtstart.Weekday())+6)%7)}
+		}
+		var days []time.Time
+		for offset := 0; offset < 7; offset++ {
+			day := weekStart.AddDate(0, 0, offset)
+			if r.matchesWeekday(day.Weekday()) {
+				days = append(days, day)
+			}
+		}
+		return days
+
+	case Monthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1,
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location())
+		daysInMonth := first.AddDate(0, 1, -1).Day()
+
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			// Months without that day (e.g. the 31st) are skipped.
+			if dtstart.Day() > daysInMonth {
+				return nil
+			}
+			return []time.Time{first.AddDate(0, 0, dtstart.Day()-1)}
+		}
+
+		var days []time.Time
+		for day := 1; day <= daysInMonth; day++ {
+			date := first.AddDate(0, 0, day-1)
+			if (len(r.ByDay) == 0 || r.matchesMonthDay(date, daysInMonth)) &&
+				(len(r.ByMonthDay) == 0 || r.matchesDayOfMonth(day, daysInMonth)) {
+				days = append(days, date)
+			}
+		}
+		sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+		return days
+	}
+	return nil
+}
+
+func (r *Rule) matchesWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesDayOfMonth reports whether day matches a BYMONTHDAY entry. Like
+// the 31st without BYMONTHDAY, a day the month does not have never matches.
+func (r *Rule) matchesDayOfMonth(day, daysInMonth int) bool {
+	for _, n := range r.ByMonthDay {
+		if n == day || (n < 0 && daysInMonth+n+1 == day) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthDay reports whether date matches a BYDAY entry of a MONTHLY
+// rule, taking positions such as 2TU or -1FR into account.
+func (r *Rule) matchesMonthDay(date time.Time, daysInMonth int) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday != date.Weekday() {
+			continue
+		}
+		switch {
+		case day.N == 0:
+			return true
+		case day.N > 0 && (date.Day()-1)/7+1 == day.N:
+			return true
+		case day.N < 0 && (daysInMonth-date.Day())/7+1 == -day.N:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+// occurrences returns the first n occurrences of the series, or fewer when
+// it ends earlier.
+func occurrences(rule *Rule, dtstart time.Time, n int) []time.Time {
+	var got []time.Time
+	rule.each(dtstart, func(index int, occurrence time.Time) bool {
+		got = append(got, occurrence)
+		return index < n-1
+	})
+	return got
+}
+
+func TestOccurrences(t *testing.T) {
+	utc := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	newYork := mustLoad(t, "America/New_York")
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		n       int
+		want    []time.Time
+	}{
+		{
+			name:    "daily",
+			rule:    "FREQ=DAILY",
+			dtstart: utc(2026, time.October, 16, 9),
+			n:       3,
+			want:    []time.Time{utc(2026, time.October, 16, 9), utc(2026, time.October, 17, 9), utc(2026, time.October, 18, 9)},
+		},
+		{
+			name:    "daily with interval",
+			rule:    "FREQ=DAILY;INTERVAL=3",
+			dtstart: utc(2026, time.October, 16, 9),
+			n:       3,
+			want:    []time.Time{utc(2026, time.October, 16, 9), utc(2026, time.October, 19, 9), utc(2026, time.October, 22, 9)},
+		},
+		{
+			name:    "weekly keeps the weekday of the start",
+			rule:    "FREQ=WEEKLY",
+			dtstart: utc(2026, time.October, 16, 9),
+			n:       3,
+			want:    []time.Time{utc(2026, time.October, 16, 9), utc(2026, time.October, 23, 9), utc(2026, time.October, 30, 9)},
+		},
+		{
+			name:    "every other week on monday and wednesday",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+			dtstart: utc(2026, time.October, 12, 9),
+			n:       5,
+			want: []time.Time{
+				utc(2026, time.October, 12, 9), utc(2026, time.October, 14, 9),
+				utc(2026, time.October, 26, 9), utc(2026, time.October, 28, 9),
+				utc(2026, time.November, 9, 9),
+			},
+		},
+		{
+			name:    "the start counts even when it does not match BYDAY",
+			rule:    "FREQ=WEEKLY;BYDAY=MO",
+			dtstart: utc(2026, time.October, 16, 9),
+			n:       2,
+			want:    []time.Time{utc(2026, time.October, 16, 9), utc(2026, time.October, 19, 9)},
+		},
+		{
+			name:    "second tuesday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=2TU",
+			dtstart: utc(2026, time.October, 13, 9),
+			n:       3,
+			want:    []time.Time{utc(2026, time.October, 13, 9), utc(2026, time.November, 10, 9), utc(2026, time.December, 8, 9)},
+		},
+		{
+			name:    "last friday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: utc(2026, time.October, 30, 9),
+			n:       3,
+			want:    []time.Time{utc(2026, time.October, 30, 9), utc(2026, time.November, 27, 9), utc(2026, time.December, 25, 9)},
+		},
+		{
+			name:    "count ends the series",
+			rule:    "FREQ=DAILY;COUNT=2",
+			dtstart: utc(2026, time.October, 16, 9),
+			n:       5,
+			want:    []time.Time{utc(2026, time.October, 16, 9), utc(2026, time.October, 17, 9)},
+		},
+		{
+			name:    "until with a time is inclusive",
+			rule:    "FREQ=DAILY;UNTIL=20261018T090000Z",
+			dtstart: utc(2026, time.October, 16, 9),
+			n:       5,
+			want:    []time.Time{utc(2026, time.October, 16, 9), utc(2026, time.October, 17, 9), utc(2026, time.October, 18, 9)},
+		},
+		{
+			name:    "until as a date ends with that local day",
+			rule:    "FREQ=DAILY;UNTIL=20261018",
+			dtstart: time.Date(2026, time.October, 16, 22, 0, 0, 0, newYork),
+			n:       5,
+			// The last one is already 19 October in UTC.
+			want: []time.Time{
+				time.Date(2026, time.October, 16, 22, 0, 0, 0, newYork),
+				time.Date(2026, time.October, 17, 22, 0, 0, 0, newYork),
+				time.Date(2026, time.October, 18, 22, 0, 0, 0, newYork),
+			},
+		},
+		{
+			name:    "the 31st skips shorter months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: utc(2026, time.January, 31, 9),
+			n:       3,
+			want:    []time.Time{utc(2026, time.January, 31, 9), utc(2026, time.March, 31, 9), utc(2026, time.May, 31, 9)},
+		},
+		{
+			name:    "BYMONTHDAY=31 skips shorter months",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31",
+			dtstart: utc(2026, time.January, 31, 9),
+			n:       3,
+			want:    []time.Time{utc(2026, time.January, 31, 9), utc(2026, time.March, 31, 9), utc(2026, time.May, 31, 9)},
+		},
+		{
+			name:    "BYMONTHDAY=-1 is the last day of every month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: utc(2026, time.January, 31, 9),
+			n:       3,
+			want:    []time.Time{utc(2026, time.January, 31, 9), utc(2026, time.February, 28, 9), utc(2026, time.March, 31, 9)},
+		},
+		{
+			name:    "BYMONTHDAY with several days",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=1,15",
+			dtstart: utc(2026, time.October, 1, 9),
+			n:       4,
+			want:    []time.Time{utc(2026, time.October, 1, 9), utc(2026, time.October, 15, 9), utc(2026, time.November, 1, 9), utc(2026, time.November, 15, 9)},
+		},
+		{
+			name:    "daily keeps the local time across the start of DST",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2026, time.March, 7, 9, 0, 0, 0, newYork),
+			n:       3,
+			// 09:00 EST is 14:00 UTC, 09:00 EDT is 13:00 UTC.
+			want: []time.Time{utc(2026, time.March, 7, 14), utc(2026, time.March, 8, 13), utc(2026, time.March, 9, 13)},
+		},
+		{
+			name:    "weekly keeps the local time across the end of DST",
+			rule:    "FREQ=WEEKLY",
+			dtstart: time.Date(2026, time.October, 26, 9, 0, 0, 0, newYork),
+			n:       2,
+			want:    []time.Time{utc(2026, time.October, 26, 13), utc(2026, time.November, 2, 14)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			require.NoError(t, err)
+
+			got := occurrences(rule, tt.dtstart, tt.n)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.True(t, tt.want[i].Equal(got[i]), "occurrence %d: want %s, got %s", i, tt.want[i], got[i])
+			}
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	rule, err := Parse("FREQ=DAILY;COUNT=5")
+	require.NoError(t, err)
+	dtstart := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	// Missed occurrences are skipped.
+	got, index, ok := rule.After(dtstart, time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC), 1)
+	require.True(t, ok)
+	assert.Equal(t, 3, index)
+	assert.Equal(t, time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC), got)
+
+	_, _, ok = rule.After(dtstart, dtstart, 5)
+	assert.False(t, ok, "the series ends after COUNT occurrences")
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{value: "rrule:freq=weekly;interval=1;byday=mo,fr", want: "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{value: "FREQ=MONTHLY;BYDAY=2TU,-1FR;COUNT=3", want: "FREQ=MONTHLY;BYDAY=2TU,-1FR;COUNT=3"},
+		{value: "FREQ=MONTHLY;BYMONTHDAY=31,-1", want: "FREQ=MONTHLY;BYMONTHDAY=31,-1"},
+		{value: "FREQ=DAILY;UNTIL=20261231", want: "FREQ=DAILY;UNTIL=20261231"},
+		{value: "FREQ=DAILY;UNTIL=20261231T100000Z", want: "FREQ=DAILY;UNTIL=20261231T100000Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			rule, err := Parse(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20261231",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=WEEKLY;BYDAY=2MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ=DAILY;BYSETPOS=1",
+	} {
+		t.Run(value, func(t *testing.T) {
+			_, err := Parse(value)
+			assert.ErrorIs(t, err, ErrInvalidRule)
+		})
+	}
+}
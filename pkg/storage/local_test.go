@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStoragePutGetDelete(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "tasks/1/a", strings.NewReader("hello"), 5, "text/plain"))
+
+	body, err := store.Get(ctx, "tasks/1/a")
+	require.NoError(t, err)
+	content, err := io.ReadAll(body)
+	body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	require.NoError(t, store.Delete(ctx, "tasks/1/a"))
+	_, err = store.Get(ctx, "tasks/1/a")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, store.Delete(ctx, "tasks/1/a"))
+}
+
+func TestLocalStorageRejectsKeysOutsideTheRoot(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	store, err := NewLocalStorage(root)
+	require.NoError(t, err)
+	outside := filepath.Join(dir, "outside")
+
+	for _, key := range []string{
+		"",
+		"/",
+		"../outside",
+		"tasks/../../outside",
+		"tasks/..",
+		outside,
+		"/etc/passwd",
+	} {
+		t.Run(key, func(t *testing.T) {
+			assert.Error(t, store.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain"))
+			_, err := store.Get(context.Background(), key)
+			assert.Error(t, err)
+			assert.Error(t, store.Delete(context.Background(), key))
+		})
+	}
+
+	_, err = os.Stat(outside)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
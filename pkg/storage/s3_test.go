@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+// fakeS3 is a path-style S3 stand-in that only accepts requests signed with
+// the test credentials.
+type fakeS3 struct {
+	verifier *S3Storage
+	mu       sync.Mutex
+	objects  map[string]string
+	requests []*http.Request
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *S3Storage) {
+	fake := &fakeS3{objects: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	opts := S3Options{
+		Endpoint:  server.URL,
+		Bucket:    "attachments",
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+	}
+	verifier, err := NewS3Storage(opts)
+	require.NoError(t, err)
+	fake.verifier = verifier
+
+	store, err := NewS3Storage(opts)
+	require.NoError(t, err)
+	return fake, store
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r)
+
+	// Sign the request as it arrived and compare the signatures.
+	signedAt, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	check := httptest.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	f.verifier.sign(check, signedAt)
+	if r.Header.Get("Authorization") != check.Header.Get("Authorization") {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = string(body)
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, "<Error><Code>NoSuchKey</Code></Error>")
+			return
+		}
+		io.WriteString(w, body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) lastRequest() *http.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[len(f.requests)-1]
+}
+
+func TestS3StoragePutGetDelete(t *testing.T) {
+	fake, store := newFakeS3(t)
+	ctx := context.Background()
+	key := "tasks/1/notes v2.txt"
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain"))
+	put := fake.lastRequest()
+	assert.Equal(t, http.MethodPut, put.Method)
+	assert.Equal(t, "/attachments/tasks/1/notes%20v2.txt", put.URL.EscapedPath())
+	assert.Equal(t, int64(5), put.ContentLength)
+	assert.Equal(t, "text/plain", put.Header.Get("Content-Type"))
+	assert.Equal(t, unsignedPayload, put.Header.Get("X-Amz-Content-Sha256"))
+	assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/\d{8}/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`, put.Header.Get("Authorization"))
+
+	body, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	content, err := io.ReadAll(body)
+	body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+	assert.Equal(t, http.MethodGet, fake.lastRequest().Method)
+
+	require.NoError(t, store.Delete(ctx, key))
+	assert.Equal(t, http.MethodDelete, fake.lastRequest().Method)
+
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Deleting a missing object is not an error.
+	assert.NoError(t, store.Delete(ctx, key))
+}
+
+func TestS3StorageReportsRejectedRequests(t *testing.T) {
+	_, store := newFakeS3(t)
+	store.opts.SecretKey = "wrong"
+
+	err := store.Put(context.Background(), "tasks/1/a", strings.NewReader("a"), 1, "text/plain")
+	assert.ErrorContains(t, err, "status 403")
+
+	_, err = store.Get(context.Background(), "tasks/1/a")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestS3StorageSign(t *testing.T) {
+	store, err := NewS3Storage(S3Options{
+		Endpoint:  "http://localhost:9000",
+		Bucket:    "attachments",
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+	})
+	require.NoError(t, err)
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	// The signatures were computed independently from the Signature Version
+	// 4 specification.
+	tests := []struct {
+		method    string
+		signature string
+	}{
+		{method: http.MethodGet, signature: "207b441d4a7f12be5e563ac6c1854d7313ee38e1fd6ad9259971e56160f57a3e"},
+		{method: http.MethodPut, signature: "bf32bdb92e27f8d4faee667983b9ad305d4acda2e0f320833a656a80e79659b5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			req, err := store.newRequest(context.Background(), tt.method, "tasks/1/notes v2.txt", nil)
+			require.NoError(t, err)
+
+			store.sign(req, now)
+
+			assert.Equal(t, "20261016T090000Z", req.Header.Get("X-Amz-Date"))
+			assert.Equal(t,
+				"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20261016/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="+tt.signature,
+				req.Header.Get("Authorization"))
+		})
+	}
+}
+
+func TestAWSURIEncode(t *testing.T) {
+	tests := []struct {
+		value       string
+		encodeSlash bool
+		want        string
+	}{
+		{value: "/bucket/tasks/a-b_c.d~e", want: "/bucket/tasks/a-b_c.d~e"},
+		{value: "/bucket/notes v2.txt", want: "/bucket/notes%20v2.txt"},
+		{value: "a+b=c&d", want: "a%2Bb%3Dc%26d"},
+		{value: "résumé", want: "r%C3%A9sum%C3%A9"},
+		{value: "a/b", encodeSlash: true, want: "a%2Fb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, awsURIEncode(tt.value, tt.encodeSlash))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Package storage keeps binary objects such as task attachments outside the
+// database.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-corenglish/internal/config"
+	"io"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var ErrNotFound = errors.New("object not found")
+
+// Storage stores objects under slash-separated keys.
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns the storage backend selected by STORAGE_DRIVER.
+func New(cfg *config.Config) (Storage, error) {
+	switch cfg.StorageDriver {
+	case DriverLocal:
+		return NewLocalStorage(cfg.StorageLocalPath)
+	case DriverS3:
+		return NewS3Storage(S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	}
+	return nil, fmt.Errorf("unknown storage driver: %s", cfg.StorageDriver)
+}